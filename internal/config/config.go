@@ -6,16 +6,23 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 
 	yaml "sigs.k8s.io/yaml/goyaml.v2"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/redact"
 )
 
 const (
 	// PathToDefaultParamsFile path to config file with default parameters.
 	PathToDefaultParamsFile = "./default.yaml"
+
+	// InstallModeOLMv0 installs the operators with a Subscription, the default.
+	InstallModeOLMv0 = "olmv0"
+	// InstallModeOLMv1 installs the operators with an OLM v1 ClusterExtension.
+	InstallModeOLMv1 = "olmv1"
 )
 
 // GeneralConfig type keeps general configuration.
@@ -30,6 +37,16 @@ type GeneralConfig struct {
 	ControlPlaneLabel    string `yaml:"control_plane_label" envconfig:"CONTROL_PLANE_LABEL"`
 	WorkerLabelMap       map[string]string
 	ControlPlaneLabelMap map[string]string
+	Clusters             []string          `yaml:"clusters" envconfig:"CLUSTERS"`
+	APIRetryMaxRetries   int               `yaml:"api_retry_max_retries" envconfig:"API_RETRY_MAX_RETRIES"`
+	APIRetryBackoff      string            `yaml:"api_retry_backoff" envconfig:"API_RETRY_BACKOFF"`
+	APIRetryMaxBackoff   string            `yaml:"api_retry_max_backoff" envconfig:"API_RETRY_MAX_BACKOFF"`
+	APIRecordMode        string            `yaml:"api_record_mode" envconfig:"API_RECORD_MODE"`
+	APICassetteFile      string            `yaml:"api_cassette_file" envconfig:"API_CASSETTE_FILE"`
+	TimeoutProfile       string            `yaml:"timeout_profile" envconfig:"TIMEOUT_PROFILE"`
+	TimeoutMultiplier    float64           `yaml:"timeout_multiplier" envconfig:"TIMEOUT_MULTIPLIER"`
+	TimeoutOverrides     map[string]string `yaml:"timeout_overrides" envconfig:"TIMEOUT_OVERRIDES"`
+	RedactPatterns       []string          `yaml:"redact_patterns" envconfig:"REDACT_PATTERNS"`
 }
 
 // NewConfig returns instance of GeneralConfig config type.
@@ -74,16 +91,59 @@ func (cfg *GeneralConfig) GetJunitReportPath(file string) string {
 	return fmt.Sprintf("%s_junit.xml", filepath.Join(cfg.ReportsDirAbsPath, reportFileName))
 }
 
+// GetRunReportPath returns full path to the JSON run report file.
+func (cfg *GeneralConfig) GetRunReportPath(file string) string {
+	reportFileName := strings.TrimSuffix(filepath.Base(file), filepath.Ext(filepath.Base(file)))
+	return fmt.Sprintf("%s_report.json", filepath.Join(cfg.ReportsDirAbsPath, reportFileName))
+}
+
 // GetArtifactPath return full path to a file in the report directory.
 func (cfg *GeneralConfig) GetReportPath(file string) string {
 	fileName := filepath.Base(file)
 	return filepath.Join(cfg.ReportsDirAbsPath, fileName)
 }
 
-// WriteReport writes contents into a file in the report directory.
+// WriteReport writes redacted contents into a file in the report directory.
 func (cfg *GeneralConfig) WriteReport(fileName string, content []byte) error {
 	file := cfg.GetReportPath(fileName)
-	return os.WriteFile(file, content, 0666)
+	return os.WriteFile(file, redact.Bytes(content), 0666)
+}
+
+// WriteEffectiveConfig writes effectiveConfig as YAML into a file in the report directory. Suites write it
+// before validating it, so that an invalid configuration can be inspected.
+func (cfg *GeneralConfig) WriteEffectiveConfig(fileName string, effectiveConfig interface{}) error {
+	content, err := yaml.Marshal(effectiveConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the effective configuration: %w", err)
+	}
+
+	return cfg.WriteReport(fileName, content)
+}
+
+// GetCassettePath returns full path to the API cassette file. Relative file names are located in
+// the report directory.
+func (cfg *GeneralConfig) GetCassettePath() string {
+	if filepath.IsAbs(cfg.APICassetteFile) {
+		return cfg.APICassetteFile
+	}
+
+	return cfg.GetReportPath(cfg.APICassetteFile)
+}
+
+// AppendReport appends redacted contents to a file in the report directory, creating it if needed.
+func (cfg *GeneralConfig) AppendReport(fileName string, content []byte) error {
+	file, err := os.OpenFile(cfg.GetReportPath(fileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	_, err = file.Write(redact.Bytes(content))
+
+	return err
 }
 
 // GetDumpFailedTestReportLocation returns destination file for failed tests logs.
@@ -102,6 +162,78 @@ func (cfg *GeneralConfig) GetDumpFailedTestReportLocation(file string) string {
 	return ""
 }
 
+// LoadProfile decodes the YAML profile file at path into cfg. An empty path leaves cfg untouched.
+func LoadProfile(path string, cfg interface{}) error {
+	if path == "" {
+		return nil
+	}
+
+	log.Printf("Loading config profile %s", path)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return yaml.UnmarshalStrict(content, cfg)
+}
+
+// ValidateInstallPlanApproval returns an error when approval, the value of the envVar variable, is neither
+// empty, Automatic nor Manual.
+func ValidateInstallPlanApproval(envVar, approval string) error {
+	switch approval {
+	case "", "Automatic", "Manual":
+		return nil
+	}
+
+	return fmt.Errorf("%s '%s' must be either Automatic or Manual", envVar, approval)
+}
+
+// ValidateInstallMode returns an error when mode, the value of the envVar variable, is neither empty,
+// InstallModeOLMv0 nor InstallModeOLMv1.
+func ValidateInstallMode(envVar, mode string) error {
+	switch mode {
+	case "", InstallModeOLMv0, InstallModeOLMv1:
+		return nil
+	}
+
+	return fmt.Errorf("%s '%s' must be either %s or %s", envVar, mode, InstallModeOLMv0, InstallModeOLMv1)
+}
+
+// ValidateInstallModeOptions returns an error for every option set in olmv0Only when olmv1, the install mode of
+// the modeVar variable, is InstallModeOLMv1, and for every option set in olmv1Only otherwise. Both maps are keyed
+// by the variable name of the option and tell whether it is set.
+func ValidateInstallModeOptions(modeVar string, olmv1 bool, olmv0Only, olmv1Only map[string]bool) []error {
+	var errs []error
+
+	if olmv1 {
+		for _, envVar := range setOptions(olmv0Only) {
+			errs = append(errs, fmt.Errorf("%s is not supported with %s %s", envVar, modeVar, InstallModeOLMv1))
+		}
+	} else {
+		for _, envVar := range setOptions(olmv1Only) {
+			errs = append(errs, fmt.Errorf("%s requires %s %s", envVar, modeVar, InstallModeOLMv1))
+		}
+	}
+
+	return errs
+}
+
+// setOptions returns the sorted names of the options set in options.
+func setOptions(options map[string]bool) []string {
+	var names []string
+
+	for name, set := range options {
+		if set {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 func readFile(cfg *GeneralConfig, cfgFile string) error {
 	openedCfgFile, err := os.Open(cfgFile)
 	if err != nil {
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateInstallModeOptions(t *testing.T) {
+	olmv0Only := map[string]bool{"NVIDIAGPU_STARTING_CSV": true, "NVIDIAGPU_DEPLOY_FROM_BUNDLE": true,
+		"NVIDIAGPU_INSTALL_PLAN_APPROVAL": false}
+	olmv1Only := map[string]bool{"NVIDIAGPU_VERSION_RANGE": true, "NVIDIAGPU_CLUSTEREXTENSION_CLUSTER_ADMIN": false}
+
+	testCases := []struct {
+		name           string
+		olmv1          bool
+		olmv0Only      map[string]bool
+		olmv1Only      map[string]bool
+		expectedErrors []string
+	}{
+		{
+			name:      "olmv0 options with olmv1",
+			olmv1:     true,
+			olmv0Only: olmv0Only,
+			olmv1Only: olmv1Only,
+			expectedErrors: []string{
+				"NVIDIAGPU_DEPLOY_FROM_BUNDLE is not supported with NVIDIAGPU_INSTALL_MODE olmv1",
+				"NVIDIAGPU_STARTING_CSV is not supported with NVIDIAGPU_INSTALL_MODE olmv1",
+			},
+		},
+		{
+			name:           "olmv1 options with olmv0",
+			olmv0Only:      olmv0Only,
+			olmv1Only:      olmv1Only,
+			expectedErrors: []string{"NVIDIAGPU_VERSION_RANGE requires NVIDIAGPU_INSTALL_MODE olmv1"},
+		},
+		{
+			name:      "no option set",
+			olmv1:     true,
+			olmv0Only: map[string]bool{"NVIDIAGPU_STARTING_CSV": false},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			errs := ValidateInstallModeOptions("NVIDIAGPU_INSTALL_MODE", testCase.olmv1, testCase.olmv0Only,
+				testCase.olmv1Only)
+
+			if len(errs) != len(testCase.expectedErrors) {
+				t.Fatalf("expected %d errors, got %v", len(testCase.expectedErrors), errs)
+			}
+
+			for index, err := range errs {
+				if !strings.Contains(err.Error(), testCase.expectedErrors[index]) {
+					t.Errorf("expected error containing %q, got %q", testCase.expectedErrors[index], err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestWriteEffectiveConfig(t *testing.T) {
+	generalConfig := &GeneralConfig{ReportsDirAbsPath: t.TempDir()}
+
+	effectiveConfig := struct {
+		InstallMode string `yaml:"install_mode"`
+		DryRun      bool   `yaml:"dry_run,omitempty"`
+	}{InstallMode: InstallModeOLMv1}
+
+	if err := generalConfig.WriteEffectiveConfig("nvidiagpu.config.yaml", effectiveConfig); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(generalConfig.ReportsDirAbsPath, "nvidiagpu.config.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read the effective configuration: %v", err)
+	}
+
+	if string(content) != "install_mode: olmv1\n" {
+		t.Errorf("expected the yaml tags to be used, got %q", content)
+	}
+}
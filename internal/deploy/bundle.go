@@ -0,0 +1,520 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	pkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/wait"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	k8swait "k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// DefaultRegistryImage is the opm image serving the bundle catalog when BundleConfig.RegistryImage is empty.
+	DefaultRegistryImage = "quay.io/operator-framework/opm:v1.28.0"
+
+	registryPort            = 50051
+	registryContainerName   = "registry-grpc"
+	registryPollInterval    = 5 * time.Second
+	registryLogTailLines    = 20
+	registryLabel           = "nvidia-ci.bundle-registry"
+	bundleCatalogPublisher  = "nvidia-ci"
+	bundleResourceMaxLength = 40
+)
+
+// BundleDeployStep is a step of the deployment of a bundle image.
+type BundleDeployStep string
+
+const (
+	// BundleStepRegistry runs the registry pod and service serving the bundle as a catalog.
+	BundleStepRegistry BundleDeployStep = "registry"
+	// BundleStepCatalogSource creates the CatalogSource of the registry and reads the bundle package from it.
+	BundleStepCatalogSource BundleDeployStep = "catalogsource"
+	// BundleStepOperatorGroup creates the OperatorGroup of the namespace, unless one exists.
+	BundleStepOperatorGroup BundleDeployStep = "operatorgroup"
+	// BundleStepSubscription creates the Subscription pinned to the bundle CSV and approves its InstallPlan.
+	BundleStepSubscription BundleDeployStep = "subscription"
+	// BundleStepCSV waits for the bundle CSV to succeed.
+	BundleStepCSV BundleDeployStep = "csv"
+)
+
+// BundleDeployError is returned when the deployment of a bundle image fails, with the failed step.
+type BundleDeployError struct {
+	BundleImage string
+	Namespace   string
+	Step        BundleDeployStep
+	Err         error
+}
+
+// Error returns the bundle image, the failed step and its error.
+func (bundleError *BundleDeployError) Error() string {
+	return fmt.Sprintf("failed to deploy bundle '%s' in namespace %s at step %s: %v", bundleError.BundleImage,
+		bundleError.Namespace, bundleError.Step, bundleError.Err)
+}
+
+// Unwrap returns the error of the failed step.
+func (bundleError *BundleDeployError) Unwrap() error {
+	return bundleError.Err
+}
+
+// RegistryPodError is returned when the registry pod cannot serve the bundle, e.g. when the bundle image
+// cannot be pulled by opm, with the last lines of the registry logs.
+type RegistryPodError struct {
+	Pod     string
+	Reason  string
+	Message string
+	Logs    string
+}
+
+// Error returns the reason, message and logs of the registry failure.
+func (podError *RegistryPodError) Error() string {
+	message := fmt.Sprintf("registry pod %s failed: %s", podError.Pod, podError.Reason)
+
+	if podError.Message != "" {
+		message += ": " + podError.Message
+	}
+
+	if podError.Logs != "" {
+		message += "\nregistry logs:\n" + podError.Logs
+	}
+
+	return message
+}
+
+// bundleDeployment holds the state of the deployment of a bundle image.
+type bundleDeployment struct {
+	client   *clients.Settings
+	logLevel glog.Level
+	config   *BundleConfig
+	ns       string
+	// name is the base name of the registry pod, service and CatalogSource.
+	name string
+}
+
+// deployBundle deploys bundleConfig.BundleImage in ns the way `operator-sdk run bundle` does: an opm registry
+// pod serves the bundle as a catalog through a service and a CatalogSource, then the operator is installed by
+// an OperatorGroup and a Subscription pinned to the bundle CSV, and the CSV is awaited. Every step is bounded
+// by timeout.
+func (d deploy) deployBundle(logLevel glog.Level, bundleConfig *BundleConfig, ns string,
+	timeout time.Duration) error {
+	if bundleConfig == nil || bundleConfig.BundleImage == "" {
+		return &BundleDeployError{Namespace: ns, Step: BundleStepRegistry,
+			Err: errors.New("bundle image cannot be empty")}
+	}
+
+	deployment := &bundleDeployment{
+		client:   d.client,
+		logLevel: logLevel,
+		config:   bundleConfig,
+		ns:       ns,
+		name:     bundleResourceName(bundleConfig.BundleImage),
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), timeout)
+	defer cancel()
+
+	glog.V(logLevel).Infof("Deploying bundle '%s' in namespace '%s' within %s", bundleConfig.BundleImage, ns,
+		timeout)
+
+	if err := deployment.runRegistry(ctx); err != nil {
+		return deployment.fail(BundleStepRegistry, err)
+	}
+
+	catalogSource, err := deployment.createCatalogSource(ctx)
+	if err != nil {
+		return deployment.fail(BundleStepCatalogSource, err)
+	}
+
+	if d.client.IsDryRun() {
+		glog.V(logLevel).Infof("Dry-run: skipping install of bundle '%s' from catalogsource '%s'",
+			bundleConfig.BundleImage, catalogSource)
+
+		return nil
+	}
+
+	packageManifest, err := deployment.bundlePackage(ctx, catalogSource)
+	if err != nil {
+		return deployment.fail(BundleStepCatalogSource, err)
+	}
+
+	channel, err := defaultChannel(packageManifest)
+	if err != nil {
+		return deployment.fail(BundleStepCatalogSource, err)
+	}
+
+	glog.V(logLevel).Infof("Bundle '%s' provides CSV '%s' of package '%s' in channel '%s'",
+		bundleConfig.BundleImage, channel.CurrentCSV, packageManifest.Name, channel.Name)
+
+	err = deployment.createOperatorGroup(ctx, packageManifest.Name, channel.CurrentCSVDesc.InstallModes)
+	if err != nil {
+		return deployment.fail(BundleStepOperatorGroup, err)
+	}
+
+	if err := deployment.subscribe(ctx, catalogSource, packageManifest.Name, channel); err != nil {
+		return deployment.fail(BundleStepSubscription, err)
+	}
+
+	err = wait.CSVSucceededWithContext(ctx, d.client, channel.CurrentCSV, ns, 0, remaining(ctx, timeout))
+	if err != nil {
+		return deployment.fail(BundleStepCSV, err)
+	}
+
+	glog.V(logLevel).Infof("Bundle '%s' deployed: CSV '%s' succeeded in namespace '%s'",
+		bundleConfig.BundleImage, channel.CurrentCSV, ns)
+
+	return nil
+}
+
+// fail returns a *BundleDeployError for the failed step.
+func (deployment *bundleDeployment) fail(step BundleDeployStep, err error) error {
+	return &BundleDeployError{BundleImage: deployment.config.BundleImage, Namespace: deployment.ns, Step: step,
+		Err: err}
+}
+
+// runRegistry runs the registry pod loading the bundle into an opm database, and the service exposing it, then
+// waits until the registry serves the bundle. An existing registry pod is replaced, so that the bundle image is
+// pulled again.
+func (deployment *bundleDeployment) runRegistry(ctx context.Context) error {
+	registryImage := deployment.config.RegistryImage
+	if registryImage == "" {
+		registryImage = DefaultRegistryImage
+	}
+
+	labels := map[string]string{registryLabel: deployment.name}
+	// The bundle image is passed as the positional parameter of the script, not interpolated into it.
+	script := fmt.Sprintf(`opm registry add -d /database/index.db -b "$1" --mode=replaces && `+
+		"opm registry serve -d /database/index.db -p %d", registryPort)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: deployment.name + "-registry", Namespace: deployment.ns, Labels: labels},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:    registryContainerName,
+				Image:   registryImage,
+				Command: []string{"/bin/sh", "-c", script, "registry", deployment.config.BundleImage},
+				Ports:   []corev1.ContainerPort{{Name: "grpc", ContainerPort: registryPort}},
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{Exec: &corev1.ExecAction{
+						Command: []string{"grpc_health_probe", fmt.Sprintf("-addr=:%d", registryPort)}}},
+					PeriodSeconds: 5,
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: "database", MountPath: "/database"}},
+				SecurityContext: &corev1.SecurityContext{
+					AllowPrivilegeEscalation: ptr.To(false),
+					Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+				},
+			}},
+			Volumes: []corev1.Volume{{Name: "database",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot:   ptr.To(true),
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+		},
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: deployment.name + "-registry", Namespace: deployment.ns, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{{Name: "grpc", Port: registryPort,
+				TargetPort: intstr.FromInt32(registryPort)}},
+		},
+	}
+
+	if deployment.client.IsDryRun() {
+		return errors.Join(deployment.client.RecordDryRun(clients.DryRunCreate, pod),
+			deployment.client.RecordDryRun(clients.DryRunCreate, service))
+	}
+
+	if err := deployment.deleteRegistryPod(ctx, pod.Name); err != nil {
+		return err
+	}
+
+	glog.V(deployment.logLevel).Infof("Creating registry pod '%s' with image '%s'", pod.Name, registryImage)
+
+	if _, err := deployment.client.Pods(deployment.ns).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create registry pod %s: %w", pod.Name, err)
+	}
+
+	_, err := deployment.client.Services(deployment.ns).Create(ctx, service, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create registry service %s: %w", service.Name, err)
+	}
+
+	return deployment.waitForRegistryPod(ctx, pod.Name)
+}
+
+// deleteRegistryPod deletes the registry pod left by a previous deployment and waits until it is gone.
+func (deployment *bundleDeployment) deleteRegistryPod(ctx context.Context, name string) error {
+	err := deployment.client.Pods(deployment.ns).Delete(ctx, name, metav1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to delete previous registry pod %s: %w", name, err)
+	}
+
+	glog.V(deployment.logLevel).Infof("Replacing previous registry pod '%s'", name)
+
+	return k8swait.PollUntilContextCancel(ctx, registryPollInterval, true, func(ctx context.Context) (bool, error) {
+		_, err := deployment.client.Pods(deployment.ns).Get(ctx, name, metav1.GetOptions{})
+
+		return k8serrors.IsNotFound(err), nil
+	})
+}
+
+// waitForRegistryPod waits until the registry pod is ready. A *RegistryPodError is returned as soon as the
+// registry fails, e.g. when the opm image cannot be pulled or opm cannot load the bundle.
+func (deployment *bundleDeployment) waitForRegistryPod(ctx context.Context, name string) error {
+	var lastState string
+
+	err := k8swait.PollUntilContextCancel(ctx, registryPollInterval, true, func(ctx context.Context) (bool, error) {
+		pod, err := deployment.client.Pods(deployment.ns).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			glog.V(deployment.logLevel).Infof("Failed to get registry pod %s: %v", name, err)
+
+			return false, nil
+		}
+
+		registryPod, _ := olm.RegistryPodState(pod)
+		if registryPod.Ready {
+			return true, nil
+		}
+
+		lastState = registryPod.String()
+
+		if registryPod.IsFatal() {
+			return false, &RegistryPodError{Pod: name, Reason: registryPod.Reason, Message: registryPod.Message,
+				Logs: deployment.registryLogs(ctx, name, registryPod.Restarts > 0)}
+		}
+
+		glog.V(deployment.logLevel).Infof("Registry pod %s is not ready yet: %s", name, lastState)
+
+		return false, nil
+	})
+
+	if err != nil && k8swait.Interrupted(err) {
+		return fmt.Errorf("registry pod %s is not ready, last state '%s': %w", name, lastState, err)
+	}
+
+	return err
+}
+
+// registryLogs returns the last lines of the registry container logs, or why they cannot be read.
+func (deployment *bundleDeployment) registryLogs(ctx context.Context, name string, previous bool) string {
+	logs, err := deployment.client.Pods(deployment.ns).GetLogs(name, &corev1.PodLogOptions{
+		Container: registryContainerName,
+		TailLines: ptr.To[int64](registryLogTailLines),
+		Previous:  previous,
+	}).DoRaw(ctx)
+	if err != nil {
+		return fmt.Sprintf("failed to get registry logs: %v", err)
+	}
+
+	return strings.TrimSpace(string(logs))
+}
+
+// createCatalogSource creates the CatalogSource of the registry service and waits until it is READY.
+func (deployment *bundleDeployment) createCatalogSource(ctx context.Context) (string, error) {
+	catalogSourceBuilder := olm.NewCatalogSourceBuilder(deployment.client, deployment.name+"-catalog",
+		deployment.ns)
+	catalogSourceBuilder.Definition.Spec = v1alpha1.CatalogSourceSpec{
+		SourceType:  v1alpha1.SourceTypeGrpc,
+		Address:     fmt.Sprintf("%s-registry.%s.svc:%d", deployment.name, deployment.ns, registryPort),
+		DisplayName: deployment.config.BundleImage,
+		Publisher:   bundleCatalogPublisher,
+	}
+
+	glog.V(deployment.logLevel).Infof("Creating catalogsource '%s' with address '%s'",
+		catalogSourceBuilder.Definition.Name, catalogSourceBuilder.Definition.Spec.Address)
+
+	catalogSourceBuilder, err := catalogSourceBuilder.Create()
+	if err != nil {
+		return "", fmt.Errorf("failed to create catalogsource %s: %w", catalogSourceBuilder.Definition.Name, err)
+	}
+
+	_, err = catalogSourceBuilder.WaitUntilReadyWithContext(ctx, remaining(ctx, 0))
+
+	return catalogSourceBuilder.Definition.Name, err
+}
+
+// bundlePackage waits until the PackageManifest of the bundle is served by catalogSource and returns it.
+func (deployment *bundleDeployment) bundlePackage(ctx context.Context,
+	catalogSource string) (*pkgManifestV1.PackageManifest, error) {
+	var packageManifests []pkgManifestV1.PackageManifest
+
+	err := k8swait.PollUntilContextCancel(ctx, registryPollInterval, true, func(ctx context.Context) (bool, error) {
+		packageManifestList, err := deployment.client.PackageManifestInterface.PackageManifests(deployment.ns).List(
+			ctx, metav1.ListOptions{LabelSelector: "catalog=" + catalogSource})
+		if err != nil {
+			glog.V(deployment.logLevel).Infof("Failed to list packagemanifests of catalogsource %s: %v",
+				catalogSource, err)
+
+			return false, nil
+		}
+
+		packageManifests = packageManifestList.Items
+
+		return len(packageManifests) != 0, nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("catalogsource %s does not serve any packagemanifest: %w", catalogSource, err)
+	}
+
+	if len(packageManifests) > 1 {
+		return nil, fmt.Errorf("catalogsource %s serves %d packagemanifests instead of the bundle package",
+			catalogSource, len(packageManifests))
+	}
+
+	return &packageManifests[0], nil
+}
+
+// defaultChannel returns the default channel of the bundle package.
+func defaultChannel(packageManifest *pkgManifestV1.PackageManifest) (*pkgManifestV1.PackageChannel, error) {
+	defaultChannelName := packageManifest.GetDefaultChannel()
+
+	for index := range packageManifest.Status.Channels {
+		channel := &packageManifest.Status.Channels[index]
+		if channel.Name == defaultChannelName && channel.CurrentCSV != "" {
+			return channel, nil
+		}
+	}
+
+	return nil, fmt.Errorf("package %s has no CSV in default channel '%s'", packageManifest.Name,
+		defaultChannelName)
+}
+
+// createOperatorGroup creates the OperatorGroup of the namespace, targeting the namespace when the CSV
+// supports it and all namespaces otherwise. An existing OperatorGroup is kept, as a namespace can only have one.
+func (deployment *bundleDeployment) createOperatorGroup(ctx context.Context, packageName string,
+	installModes []v1alpha1.InstallMode) error {
+	operatorGroups, err := deployment.client.OperatorGroups(deployment.ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list operatorgroups: %w", err)
+	}
+
+	if len(operatorGroups.Items) != 0 {
+		glog.V(deployment.logLevel).Infof("Using existing operatorgroup '%s' of namespace '%s'",
+			operatorGroups.Items[0].Name, deployment.ns)
+
+		return nil
+	}
+
+	name := deployment.config.OperatorGroupName
+	if name == "" {
+		name = packageName + "-og"
+	}
+
+	operatorGroupBuilder := olm.NewOperatorGroupBuilder(deployment.client, name, deployment.ns)
+
+	switch {
+	case supportsInstallMode(installModes, v1alpha1.InstallModeTypeOwnNamespace):
+	case supportsInstallMode(installModes, v1alpha1.InstallModeTypeAllNamespaces):
+		operatorGroupBuilder.Definition.Spec.TargetNamespaces = nil
+	case !supportsInstallMode(installModes, v1alpha1.InstallModeTypeSingleNamespace):
+		return fmt.Errorf("CSV of package %s supports neither OwnNamespace nor AllNamespaces install mode",
+			packageName)
+	}
+
+	glog.V(deployment.logLevel).Infof("Creating operatorgroup '%s' with target namespaces %v", name,
+		operatorGroupBuilder.Definition.Spec.TargetNamespaces)
+
+	if _, err := operatorGroupBuilder.Create(); err != nil {
+		return fmt.Errorf("failed to create operatorgroup %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// supportsInstallMode reports whether installModes supports installModeType. Install modes are assumed to be
+// supported when the CSV does not declare any.
+func supportsInstallMode(installModes []v1alpha1.InstallMode, installModeType v1alpha1.InstallModeType) bool {
+	if len(installModes) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(installModes, func(installMode v1alpha1.InstallMode) bool {
+		return installMode.Type == installModeType && installMode.Supported
+	})
+}
+
+// subscribe creates the Subscription of the bundle package with Manual approval and the bundle CSV as starting
+// CSV, then approves the InstallPlan of the bundle CSV, so that OLM does not install any other CSV.
+func (deployment *bundleDeployment) subscribe(ctx context.Context, catalogSource, packageName string,
+	channel *pkgManifestV1.PackageChannel) error {
+	name := deployment.config.SubscriptionName
+	if name == "" {
+		name = packageName + "-sub"
+	}
+
+	glog.V(deployment.logLevel).Infof("Creating subscription '%s' to CSV '%s' in channel '%s'", name,
+		channel.CurrentCSV, channel.Name)
+
+	_, err := olm.NewSubscriptionBuilder(deployment.client, name, deployment.ns, catalogSource, deployment.ns,
+		packageName).
+		WithChannel(channel.Name).
+		WithStartingCSV(channel.CurrentCSV).
+		WithInstallPlanApproval(v1alpha1.ApprovalManual).
+		Create()
+	if err != nil {
+		return fmt.Errorf("failed to create subscription %s: %w", name, err)
+	}
+
+	_, err = olm.ApproveInstallPlanForCSV(ctx, deployment.client, name, deployment.ns, channel.CurrentCSV,
+		remaining(ctx, 0))
+
+	return err
+}
+
+// bundleNameInvalidCharacters are the characters of an image repository not allowed in a resource name.
+var bundleNameInvalidCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// bundleResourceName returns the base name of the bundle registry resources, derived from the repository of
+// bundleImage, e.g. gpu-operator-bundle for ghcr.io/nvidia/gpu-operator/gpu-operator-bundle:main-latest.
+func bundleResourceName(bundleImage string) string {
+	repository, _, _ := strings.Cut(bundleImage, "@")
+
+	if slash := strings.LastIndex(repository, "/"); slash >= 0 {
+		repository = repository[slash+1:]
+	}
+
+	repository, _, _ = strings.Cut(repository, ":")
+
+	name := bundleNameInvalidCharacters.ReplaceAllString(strings.ToLower(repository), "-")
+	if len(name) > bundleResourceMaxLength {
+		name = name[:bundleResourceMaxLength]
+	}
+
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "bundle"
+	}
+
+	return name
+}
+
+// remaining returns the time left before the deadline of ctx, fallback when ctx has no deadline.
+func remaining(ctx context.Context, fallback time.Duration) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+
+	return fallback
+}
@@ -0,0 +1,135 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/operator-framework/api/pkg/operators/v1alpha1"
+	pkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBundleResourceName(t *testing.T) {
+	testCases := []struct {
+		bundleImage string
+		expected    string
+	}{
+		{"quay.io/nvidia/gpu-operator-bundle:v25.3.0", "gpu-operator-bundle"},
+		{"registry.example.com:5000/nvidia/gpu-operator-bundle:v25.3.0", "gpu-operator-bundle"},
+		{"ghcr.io/mellanox/network-operator-bundle@sha256:0123456789abcdef", "network-operator-bundle"},
+		{"gpu-operator-bundle", "gpu-operator-bundle"},
+		{"quay.io/nvidia/GPU_Operator.Bundle:latest", "gpu-operator-bundle"},
+		{"quay.io/nvidia/a-very-long-gpu-operator-bundle-repository-name-exceeding-the-limit:v1",
+			"a-very-long-gpu-operator-bundle-reposito"},
+		{"quay.io/nvidia/gpu-operator-bundle-for-extended-testss-run:v1", "gpu-operator-bundle-for-extended-testss"},
+		{"quay.io/nvidia/___:v1", "bundle"},
+	}
+
+	for _, testCase := range testCases {
+		if name := bundleResourceName(testCase.bundleImage); name != testCase.expected {
+			t.Errorf("%s: expected %q, got %q", testCase.bundleImage, testCase.expected, name)
+		}
+	}
+}
+
+func TestDefaultChannel(t *testing.T) {
+	testCases := []struct {
+		name            string
+		defaultChannel  string
+		channels        []pkgManifestV1.PackageChannel
+		expectedChannel string
+		expectedError   string
+	}{
+		{
+			name:           "default channel",
+			defaultChannel: "stable",
+			channels: []pkgManifestV1.PackageChannel{
+				{Name: "v25.3", CurrentCSV: "gpu-operator-certified.v25.3.0"},
+				{Name: "stable", CurrentCSV: "gpu-operator-certified.v25.3.1"},
+			},
+			expectedChannel: "stable",
+		},
+		{
+			name:            "single channel without default",
+			channels:        []pkgManifestV1.PackageChannel{{Name: "v25.3", CurrentCSV: "gpu-operator-certified.v25.3.0"}},
+			expectedChannel: "v25.3",
+		},
+		{
+			name: "several channels without default",
+			channels: []pkgManifestV1.PackageChannel{
+				{Name: "v25.3", CurrentCSV: "gpu-operator-certified.v25.3.0"},
+				{Name: "stable", CurrentCSV: "gpu-operator-certified.v25.3.1"},
+			},
+			expectedError: "has no CSV in default channel ''",
+		},
+		{
+			name:           "default channel without CSV",
+			defaultChannel: "stable",
+			channels:       []pkgManifestV1.PackageChannel{{Name: "stable"}},
+			expectedError:  "has no CSV in default channel 'stable'",
+		},
+		{
+			name:           "missing default channel",
+			defaultChannel: "stable",
+			channels:       []pkgManifestV1.PackageChannel{{Name: "v25.3", CurrentCSV: "gpu-operator-certified.v25.3.0"}},
+			expectedError:  "has no CSV in default channel 'stable'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			packageManifest := &pkgManifestV1.PackageManifest{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator-certified"},
+				Status: pkgManifestV1.PackageManifestStatus{
+					DefaultChannel: testCase.defaultChannel,
+					Channels:       testCase.channels,
+				},
+			}
+
+			channel, err := defaultChannel(packageManifest)
+
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Errorf("expected error containing %q, got %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if channel.Name != testCase.expectedChannel {
+				t.Errorf("expected channel %s, got %s", testCase.expectedChannel, channel.Name)
+			}
+		})
+	}
+}
+
+func TestSupportsInstallMode(t *testing.T) {
+	installModes := []v1alpha1.InstallMode{
+		{Type: v1alpha1.InstallModeTypeOwnNamespace, Supported: true},
+		{Type: v1alpha1.InstallModeTypeSingleNamespace, Supported: true},
+		{Type: v1alpha1.InstallModeTypeAllNamespaces, Supported: false},
+	}
+
+	testCases := []struct {
+		name            string
+		installModes    []v1alpha1.InstallMode
+		installModeType v1alpha1.InstallModeType
+		expected        bool
+	}{
+		{"supported", installModes, v1alpha1.InstallModeTypeOwnNamespace, true},
+		{"not supported", installModes, v1alpha1.InstallModeTypeAllNamespaces, false},
+		{"not listed", installModes, v1alpha1.InstallModeTypeMultiNamespace, false},
+		{"no install modes", nil, v1alpha1.InstallModeTypeAllNamespaces, true},
+	}
+
+	for _, testCase := range testCases {
+		if supported := supportsInstallMode(testCase.installModes, testCase.installModeType); supported !=
+			testCase.expected {
+			t.Errorf("%s: expected %t, got %t", testCase.name, testCase.expected, supported)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package deploy
 
 import (
 	"fmt"
-	"os/exec"
 	"time"
 
 	"github.com/golang/glog"
@@ -14,6 +13,11 @@ import (
 
 type BundleConfig struct {
 	BundleImage string
+	// RegistryImage is the opm image serving the bundle, DefaultRegistryImage when empty.
+	RegistryImage string
+	// SubscriptionName and OperatorGroupName default to the bundle package name suffixed with -sub and -og.
+	SubscriptionName  string
+	OperatorGroupName string
 }
 
 type Deploy interface {
@@ -60,15 +64,7 @@ func (d deploy) CreateAndLabelNamespaceIfNeeded(logLevel glog.Level, ns string,
 }
 
 func (d deploy) DeployBundle(logLevel glog.Level, bundleConfig *BundleConfig, ns string, timeout time.Duration) error {
-
-	cmd := exec.Command("operator-sdk", "run", "bundle", bundleConfig.BundleImage,
-		"--namespace", ns, "--timeout", timeout.String())
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to wait for operator-sdk to run the bundle: %v", err)
-	}
-
-	return nil
+	return d.deployBundle(logLevel, bundleConfig, ns, timeout)
 }
 
 func (d deploy) WaitForReadyStatus(logLevel glog.Level, name, ns string, timeout time.Duration) error {
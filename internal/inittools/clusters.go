@@ -0,0 +1,191 @@
+package inittools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+)
+
+const (
+	// DefaultClusterName is the name under which the KUBECONFIG cluster is registered.
+	DefaultClusterName = "default"
+	// ClusterResultsReportFile is the report file written by ReportClusterResults.
+	ClusterResultsReportFile = "cluster.results"
+)
+
+// ClusterRegistry keeps named API clients for every cluster the suite interacts with.
+type ClusterRegistry struct {
+	mutex    sync.RWMutex
+	clusters map[string]*clients.Settings
+}
+
+// ClusterResult holds the outcome of a check executed against a single registered cluster.
+type ClusterResult struct {
+	Cluster  string
+	Err      error
+	Duration time.Duration
+}
+
+// NewClusterRegistry returns an empty ClusterRegistry.
+func NewClusterRegistry() *ClusterRegistry {
+	return &ClusterRegistry{clusters: make(map[string]*clients.Settings)}
+}
+
+// Register adds an API client to the registry under the given name.
+func (registry *ClusterRegistry) Register(name string, apiClient *clients.Settings) error {
+	if name == "" {
+		return fmt.Errorf("cluster name cannot be empty")
+	}
+
+	if apiClient == nil {
+		return fmt.Errorf("apiClient for cluster %s cannot be nil", name)
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if _, ok := registry.clusters[name]; ok {
+		return fmt.Errorf("cluster %s is already registered", name)
+	}
+
+	glog.V(100).Infof("Registering cluster %s", name)
+
+	registry.clusters[name] = apiClient
+
+	return nil
+}
+
+// Get returns the API client registered under the given name.
+func (registry *ClusterRegistry) Get(name string) (*clients.Settings, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	apiClient, ok := registry.clusters[name]
+	if !ok {
+		return nil, fmt.Errorf("cluster %s is not registered", name)
+	}
+
+	return apiClient, nil
+}
+
+// Names returns the sorted names of all registered clusters.
+func (registry *ClusterRegistry) Names() []string {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	names := make([]string, 0, len(registry.clusters))
+	for name := range registry.clusters {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Load registers every cluster described in specs. Each spec has the form "name=/path/to/kubeconfig"
+// or "name=/path/to/kubeconfig@context".
+func (registry *ClusterRegistry) Load(specs []string) error {
+	for _, spec := range specs {
+		name, kubeconfig, kubeContext, err := parseClusterSpec(spec)
+		if err != nil {
+			return err
+		}
+
+		apiClient := clients.NewForKubeContext(kubeconfig, kubeContext)
+		if apiClient == nil {
+			return fmt.Errorf("can not load ApiClient for cluster %s from %s", name, kubeconfig)
+		}
+
+		if err := registry.Register(name, apiClient); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RunOnClusters runs check concurrently against every registered cluster and returns the
+// per-cluster results ordered by cluster name.
+func (registry *ClusterRegistry) RunOnClusters(
+	check func(clusterName string, apiClient *clients.Settings) error) []ClusterResult {
+	names := registry.Names()
+	results := make([]ClusterResult, len(names))
+
+	var waitGroup sync.WaitGroup
+
+	for index, name := range names {
+		apiClient, _ := registry.Get(name)
+
+		waitGroup.Add(1)
+
+		go func(index int, name string, apiClient *clients.Settings) {
+			defer waitGroup.Done()
+
+			start := time.Now()
+			err := check(name, apiClient)
+			results[index] = ClusterResult{Cluster: name, Err: err, Duration: time.Since(start)}
+
+			if err != nil {
+				glog.V(100).Infof("Check failed on cluster %s: %v", name, err)
+			} else {
+				glog.V(100).Infof("Check passed on cluster %s", name)
+			}
+		}(index, name, apiClient)
+	}
+
+	waitGroup.Wait()
+
+	return results
+}
+
+// ClusterResultsError returns an error naming every cluster whose check failed, or nil.
+func ClusterResultsError(results []ClusterResult) error {
+	var failed []string
+
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", result.Cluster, result.Err))
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("check failed on %d cluster(s): %s", len(failed), strings.Join(failed, "; "))
+}
+
+// ReportClusterResults appends the per-cluster results of a named check to the cluster results report file.
+func ReportClusterResults(checkName string, results []ClusterResult) error {
+	var content strings.Builder
+
+	content.WriteString(fmt.Sprintf("%s:\n", checkName))
+
+	for _, result := range results {
+		status := "passed"
+		if result.Err != nil {
+			status = fmt.Sprintf("failed: %v", result.Err)
+		}
+
+		content.WriteString(fmt.Sprintf("  %s: %s (%s)\n", result.Cluster, status, result.Duration.Round(time.Second)))
+	}
+
+	return GeneralConfig.AppendReport(ClusterResultsReportFile, []byte(content.String()))
+}
+
+func parseClusterSpec(spec string) (name, kubeconfig, kubeContext string, err error) {
+	name, location, found := strings.Cut(strings.TrimSpace(spec), "=")
+	if !found || name == "" || location == "" {
+		return "", "", "", fmt.Errorf("invalid cluster spec %q, expected name=/path/to/kubeconfig[@context]", spec)
+	}
+
+	kubeconfig, kubeContext, _ = strings.Cut(location, "@")
+
+	return name, kubeconfig, kubeContext, nil
+}
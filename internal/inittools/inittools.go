@@ -3,10 +3,16 @@ package inittools
 import (
 	"context"
 	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/onsi/ginkgo/v2"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/config"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/redact"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilversion "k8s.io/apimachinery/pkg/util/version"
@@ -14,11 +20,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
 
+const (
+	// APIRetriesReportFile is the report file written by ReportAPIRetries.
+	APIRetriesReportFile = "api.retries"
+	// APIRecordModeRecord records every API interaction into the cassette file.
+	APIRecordModeRecord = "record"
+	// APIRecordModeReplay serves every API interaction from the cassette file, without any cluster.
+	APIRecordModeReplay = "replay"
+	// DryRunPlanDir is the report directory where dry-run mutations are recorded.
+	DryRunPlanDir = "dry-run"
+)
+
 var (
 	// APIClient provides access to cluster.
 	APIClient *clients.Settings
 	// GeneralConfig provides access to general configuration parameters.
 	GeneralConfig *config.GeneralConfig
+	// Clusters provides access to every registered cluster, including APIClient as DefaultClusterName.
+	Clusters = NewClusterRegistry()
 )
 
 // init loads all variables automatically when this package is imported. Once package is imported a user has full
@@ -31,15 +50,62 @@ func init() {
 		glog.Fatalf("error to load general config")
 	}
 
+	redactor, err := redact.New(GeneralConfig.RedactPatterns)
+	if err != nil {
+		glog.Fatalf("invalid redaction patterns. Please check your REDACT_PATTERNS env var: %v", err)
+	}
+
+	redact.SetActive(redactor)
+
 	_ = flag.Lookup("logtostderr").Value.Set("true")
 	_ = flag.Lookup("v").Value.Set(GeneralConfig.VerboseLevel)
 
-	if APIClient = clients.New(""); APIClient == nil {
-		if GeneralConfig.DryRun {
-			return
+	if APIClient = newAPIClient(GeneralConfig); APIClient == nil {
+		if !GeneralConfig.DryRun {
+			glog.Fatalf("can not load ApiClient. Please check your KUBECONFIG env var")
 		}
 
-		glog.Fatalf("can not load ApiClient. Please check your KUBECONFIG env var")
+		glog.V(100).Infof("No cluster available, dry-run reads are served by empty in-memory clients")
+
+		APIClient = clients.GetTestClients(clients.TestClientParams{})
+	}
+
+	retryPolicy, err := getRetryPolicy(GeneralConfig)
+	if err != nil {
+		glog.Fatalf("invalid API retry policy: %v", err)
+	}
+
+	clients.DefaultRetryPolicy = retryPolicy
+	APIClient.SetRetryPolicy(retryPolicy)
+
+	activeTimeouts, err := timeouts.New(
+		GeneralConfig.TimeoutProfile, GeneralConfig.TimeoutMultiplier, GeneralConfig.TimeoutOverrides)
+	if err != nil {
+		glog.Fatalf("invalid timeouts. Please check your TIMEOUT_* env vars: %v", err)
+	}
+
+	timeouts.SetActive(activeTimeouts)
+
+	if err := ReportTimeouts(); err != nil {
+		glog.V(100).Infof("Failed to report the effective timeouts: %v", err)
+	}
+
+	if err := Clusters.Register(DefaultClusterName, APIClient); err != nil {
+		glog.Fatalf("can not register default cluster: %v", err)
+	}
+
+	if GeneralConfig.APIRecordMode != "" && len(GeneralConfig.Clusters) > 0 {
+		glog.Fatalf("CLUSTERS cannot be used with API_RECORD_MODE, only the default cluster is recorded and replayed")
+	}
+
+	if err := Clusters.Load(GeneralConfig.Clusters); err != nil {
+		glog.Fatalf("can not load clusters. Please check your CLUSTERS env var: %v", err)
+	}
+
+	if GeneralConfig.DryRun {
+		if err := enableDryRun(); err != nil {
+			glog.Fatalf("can not enable dry-run: %v", err)
+		}
 	}
 }
 
@@ -64,3 +130,108 @@ func GetOpenShiftVersion() (string, error) {
 
 	return ocpVersion.String(), nil
 }
+
+// ReportAPIRetries writes the number of transient API errors retried on every registered cluster
+// into the api.retries report file.
+func ReportAPIRetries() error {
+	var content strings.Builder
+
+	for _, name := range Clusters.Names() {
+		apiClient, err := Clusters.Get(name)
+		if err != nil {
+			return err
+		}
+
+		stats := apiClient.GetRetryStats()
+		glog.V(100).Infof("API retries on cluster %s: %s", name, stats)
+
+		content.WriteString(fmt.Sprintf("%s: %s\n", name, stats))
+	}
+
+	return GeneralConfig.WriteReport(APIRetriesReportFile, []byte(content.String()))
+}
+
+// ReportTimeouts logs the effective timeouts and writes them into the timeouts.yaml report file.
+func ReportTimeouts() error {
+	report, err := timeouts.Active().Report()
+	if err != nil {
+		return err
+	}
+
+	glog.V(100).Infof("Effective timeouts:\n%s", report)
+
+	return GeneralConfig.WriteReport(timeouts.ReportFile, report)
+}
+
+// enableDryRun switches every registered cluster to plan-only mode. The default cluster records its plan
+// in the dry-run directory of the reports directory, every other cluster in a subdirectory named after it.
+func enableDryRun() error {
+	planDir := GeneralConfig.GetReportPath(DryRunPlanDir)
+
+	for _, name := range Clusters.Names() {
+		apiClient, err := Clusters.Get(name)
+		if err != nil {
+			return err
+		}
+
+		clusterPlanDir := planDir
+		if name != DefaultClusterName {
+			clusterPlanDir = filepath.Join(planDir, name)
+		}
+
+		if err := apiClient.EnableDryRun(clusterPlanDir); err != nil {
+			return fmt.Errorf("cluster %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// newAPIClient returns the API client matching the configured API record mode.
+func newAPIClient(generalConfig *config.GeneralConfig) *clients.Settings {
+	switch generalConfig.APIRecordMode {
+	case "":
+		return clients.New("")
+	case APIRecordModeRecord:
+		cassette, err := clients.NewRecordingCassette(generalConfig.GetCassettePath())
+		if err != nil {
+			glog.Fatalf("can not record API interactions: %v", err)
+		}
+
+		return clients.NewRecording("", cassette)
+	case APIRecordModeReplay:
+		cassette, err := clients.LoadCassette(generalConfig.GetCassettePath())
+		if err != nil {
+			glog.Fatalf("can not replay API interactions: %v", err)
+		}
+
+		return clients.NewReplay(cassette)
+	}
+
+	glog.Fatalf("invalid API_RECORD_MODE %q, expected %q or %q",
+		generalConfig.APIRecordMode, APIRecordModeRecord, APIRecordModeReplay)
+
+	return nil
+}
+
+func getRetryPolicy(generalConfig *config.GeneralConfig) (clients.RetryPolicy, error) {
+	backoff, err := time.ParseDuration(generalConfig.APIRetryBackoff)
+	if err != nil {
+		return clients.RetryPolicy{}, fmt.Errorf("failed to parse API_RETRY_BACKOFF: %w", err)
+	}
+
+	maxBackoff, err := time.ParseDuration(generalConfig.APIRetryMaxBackoff)
+	if err != nil {
+		return clients.RetryPolicy{}, fmt.Errorf("failed to parse API_RETRY_MAX_BACKOFF: %w", err)
+	}
+
+	if generalConfig.APIRetryMaxRetries < 0 || backoff <= 0 || maxBackoff < backoff {
+		return clients.RetryPolicy{}, fmt.Errorf("API retries must be >= 0 and 0 < backoff <= max backoff")
+	}
+
+	return clients.RetryPolicy{
+		MaxRetries:     generalConfig.APIRetryMaxRetries,
+		InitialBackoff: backoff,
+		MaxBackoff:     maxBackoff,
+	}, nil
+}
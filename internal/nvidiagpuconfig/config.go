@@ -1,31 +1,60 @@
 package nvidiagpuconfig
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/config"
+)
+
+const (
+	// ProfileEnvVar selects the YAML profile file the NvidiaGPUConfig is loaded from.
+	ProfileEnvVar = "NVIDIAGPU_CONFIG_PROFILE"
+	// EffectiveConfigReportFile is the report file the effective NvidiaGPUConfig is written to.
+	EffectiveConfigReportFile = "nvidiagpu.config.yaml"
 )
 
 // NvidiaGPUConfig contains environment information related to nvidiagpu tests.
 type NvidiaGPUConfig struct {
-	InstanceType                       string `envconfig:"NVIDIAGPU_GPU_MACHINESET_INSTANCE_TYPE"`
-	CatalogSource                      string `envconfig:"NVIDIAGPU_CATALOGSOURCE"`
-	SubscriptionChannel                string `envconfig:"NVIDIAGPU_SUBSCRIPTION_CHANNEL"`
-	CleanupAfterTest                   bool   `envconfig:"NVIDIAGPU_CLEANUP" default:"true"`
-	DeployFromBundle                   bool   `envconfig:"NVIDIAGPU_DEPLOY_FROM_BUNDLE" default:"false"`
-	BundleImage                        string `envconfig:"NVIDIAGPU_BUNDLE_IMAGE"`
-	OperatorUpgradeToChannel           string `envconfig:"NVIDIAGPU_SUBSCRIPTION_UPGRADE_TO_CHANNEL"`
-	GPUFallbackCatalogsourceIndexImage string `envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
-	NFDFallbackCatalogsourceIndexImage string `envconfig:"NVIDIAGPU_NFD_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
+	InstanceType                       string `yaml:"gpu_machineset_instance_type" envconfig:"NVIDIAGPU_GPU_MACHINESET_INSTANCE_TYPE"`
+	CatalogSource                      string `yaml:"catalogsource" envconfig:"NVIDIAGPU_CATALOGSOURCE"`
+	SubscriptionChannel                string `yaml:"subscription_channel" envconfig:"NVIDIAGPU_SUBSCRIPTION_CHANNEL"`
+	CleanupAfterTest                   bool   `yaml:"cleanup" envconfig:"NVIDIAGPU_CLEANUP"`
+	DeployFromBundle                   bool   `yaml:"deploy_from_bundle" envconfig:"NVIDIAGPU_DEPLOY_FROM_BUNDLE"`
+	BundleImage                        string `yaml:"bundle_image" envconfig:"NVIDIAGPU_BUNDLE_IMAGE"`
+	OperatorUpgradeToChannel           string `yaml:"subscription_upgrade_to_channel" envconfig:"NVIDIAGPU_SUBSCRIPTION_UPGRADE_TO_CHANNEL"`
+	OperatorRollback                   bool   `yaml:"operator_rollback" envconfig:"NVIDIAGPU_OPERATOR_ROLLBACK"`
+	InstallPlanApproval                string `yaml:"install_plan_approval" envconfig:"NVIDIAGPU_INSTALL_PLAN_APPROVAL"`
+	StartingCSV                        string `yaml:"starting_csv" envconfig:"NVIDIAGPU_STARTING_CSV"`
+	InstallMode                        string `yaml:"install_mode" envconfig:"NVIDIAGPU_INSTALL_MODE"`
+	ClusterCatalog                     string `yaml:"clustercatalog" envconfig:"NVIDIAGPU_CLUSTERCATALOG"`
+	VersionRange                       string `yaml:"version_range" envconfig:"NVIDIAGPU_VERSION_RANGE"`
+	ClusterExtensionClusterAdmin       bool   `yaml:"clusterextension_cluster_admin" envconfig:"NVIDIAGPU_CLUSTEREXTENSION_CLUSTER_ADMIN"`
+	GPUFallbackCatalogsourceIndexImage string `yaml:"gpu_fallback_catalogsource_index_image" envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
+	NFDFallbackCatalogsourceIndexImage string `yaml:"nfd_fallback_catalogsource_index_image" envconfig:"NVIDIAGPU_NFD_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
 }
 
-// NewNvidiaGPUConfig returns instance of NvidiaGPUConfig type.
+// NewNvidiaGPUConfig returns instance of NvidiaGPUConfig type. Values are loaded from the defaults, then from
+// the YAML profile selected by NVIDIAGPU_CONFIG_PROFILE if any, then from the environment variables.
 func NewNvidiaGPUConfig() *NvidiaGPUConfig {
 	log.Print("Creating new NvidiaGPUConfig")
 
-	nvidiaGPUConfig := new(NvidiaGPUConfig)
+	nvidiaGPUConfig := &NvidiaGPUConfig{
+		CleanupAfterTest: true,
+		DeployFromBundle: false,
+	}
+
+	err := config.LoadProfile(os.Getenv(ProfileEnvVar), nvidiaGPUConfig)
+	if err != nil {
+		log.Printf("failed to load nvidiaGPUConfig profile: %v", err)
+
+		return nil
+	}
 
-	err := envconfig.Process("nvidiagpu_", nvidiaGPUConfig)
+	err = envconfig.Process("nvidiagpu_", nvidiaGPUConfig)
 	if err != nil {
 		log.Printf("failed to instantiate nvidiaGPUConfig: %v", err)
 
@@ -34,3 +63,55 @@ func NewNvidiaGPUConfig() *NvidiaGPUConfig {
 
 	return nvidiaGPUConfig
 }
+
+// Validate returns all the inconsistencies found in the NvidiaGPUConfig at once.
+func (nvidiaGPUConfig *NvidiaGPUConfig) Validate() error {
+	var errs []error
+
+	if nvidiaGPUConfig.OperatorUpgradeToChannel != "" &&
+		nvidiaGPUConfig.OperatorUpgradeToChannel == nvidiaGPUConfig.SubscriptionChannel {
+		errs = append(errs, fmt.Errorf("NVIDIAGPU_SUBSCRIPTION_UPGRADE_TO_CHANNEL '%s' must differ from "+
+			"NVIDIAGPU_SUBSCRIPTION_CHANNEL", nvidiaGPUConfig.OperatorUpgradeToChannel))
+	}
+
+	if nvidiaGPUConfig.OperatorRollback {
+		if nvidiaGPUConfig.OperatorUpgradeToChannel == "" {
+			errs = append(errs, errors.New("NVIDIAGPU_OPERATOR_ROLLBACK requires "+
+				"NVIDIAGPU_SUBSCRIPTION_UPGRADE_TO_CHANNEL"))
+		}
+
+		if nvidiaGPUConfig.DeployFromBundle {
+			errs = append(errs, errors.New("NVIDIAGPU_OPERATOR_ROLLBACK is not supported with "+
+				"NVIDIAGPU_DEPLOY_FROM_BUNDLE, the rollback reinstalls the operator from its CatalogSource"))
+		}
+	}
+
+	if err := config.ValidateInstallPlanApproval("NVIDIAGPU_INSTALL_PLAN_APPROVAL",
+		nvidiaGPUConfig.InstallPlanApproval); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := config.ValidateInstallMode("NVIDIAGPU_INSTALL_MODE", nvidiaGPUConfig.InstallMode); err != nil {
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, config.ValidateInstallModeOptions("NVIDIAGPU_INSTALL_MODE", nvidiaGPUConfig.UsesOLMv1(),
+		map[string]bool{
+			"NVIDIAGPU_DEPLOY_FROM_BUNDLE":              nvidiaGPUConfig.DeployFromBundle,
+			"NVIDIAGPU_SUBSCRIPTION_UPGRADE_TO_CHANNEL": nvidiaGPUConfig.OperatorUpgradeToChannel != "",
+			"NVIDIAGPU_INSTALL_PLAN_APPROVAL":           nvidiaGPUConfig.InstallPlanApproval != "",
+			"NVIDIAGPU_STARTING_CSV":                    nvidiaGPUConfig.StartingCSV != "",
+		},
+		map[string]bool{
+			"NVIDIAGPU_VERSION_RANGE":                  nvidiaGPUConfig.VersionRange != "",
+			"NVIDIAGPU_CLUSTEREXTENSION_CLUSTER_ADMIN": nvidiaGPUConfig.ClusterExtensionClusterAdmin,
+		})...)
+
+	return errors.Join(errs...)
+}
+
+// UsesOLMv1 returns true when the operator is installed with an OLM v1 ClusterExtension instead of a
+// Subscription.
+func (nvidiaGPUConfig *NvidiaGPUConfig) UsesOLMv1() bool {
+	return nvidiaGPUConfig.InstallMode == config.InstallModeOLMv1
+}
@@ -1,40 +1,70 @@
 package nvidianetworkconfig
 
 import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
 	"github.com/golang/glog"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/config"
+)
+
+const (
+	// ProfileEnvVar selects the YAML profile file the NvidiaNetworkConfig is loaded from.
+	ProfileEnvVar = "NVIDIANETWORK_CONFIG_PROFILE"
+	// EffectiveConfigReportFile is the report file the effective NvidiaNetworkConfig is written to.
+	EffectiveConfigReportFile = "nvidianetwork.config.yaml"
 )
 
 // NvidiaNetworkConfig contains environment information related to nvidianetwork tests.
 type NvidiaNetworkConfig struct {
-	CatalogSource                      string `envconfig:"NVIDIANETWORK_CATALOGSOURCE"`
-	SubscriptionChannel                string `envconfig:"NVIDIANETWORK_SUBSCRIPTION_CHANNEL"`
-	CleanupAfterTest                   bool   `envconfig:"NVIDIANETWORK_CLEANUP" default:"true"`
-	DeployFromBundle                   bool   `envconfig:"NVIDIANETWORK_DEPLOY_FROM_BUNDLE" default:"false"`
-	BundleImage                        string `envconfig:"NVIDIANETWORK_BUNDLE_IMAGE"`
-	OfedDriverVersion                  string `envconfig:"NVIDIANETWORK_OFED_DRIVER_VERSION"`
-	OfedDriverRepository               string `envconfig:"NVIDIANETWORK_OFED_REPOSITORY"`
-	RdmaClientHostname                 string `envconfig:"NVIDIANETWORK_RDMA_CLIENT_HOSTNAME"`
-	RdmaServerHostname                 string `envconfig:"NVIDIANETWORK_RDMA_SERVER_HOSTNAME"`
-	RdmaTestImage                      string `envconfig:"NVIDIANETWORK_RDMA_TEST_IMAGE"`
-	MellanoxEthernetInterfaceName      string `envconfig:"NVIDIANETWORK_MELLANOX_ETH_INTERFACE_NAME"`
-	MellanoxInfinibandInterfaceName    string `envconfig:"NVIDIANETWORK_MELLANOX_IB_INTERFACE_NAME"`
-	MacvlanNetworkName                 string `envconfig:"NVIDIANETWORK_MACVLANNETWORK_NAME"`
-	MacvlanNetworkIPAMRange            string `envconfig:"NVIDIANETWORK_MACVLANNETWORK_IPAM_RANGE"`
-	MacvlanNetworkIPAMGateway          string `envconfig:"NVIDIANETWORK_MACVLANNETWORK_IPAM_GATEWAY"`
-	OperatorUpgradeToChannel           string `envconfig:"NVIDIANETWORK_SUBSCRIPTION_UPGRADE_TO_CHANNEL"`
-	NNOFallbackCatalogsourceIndexImage string `envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
-	NFDFallbackCatalogsourceIndexImage string `envconfig:"NVIDIANETWORK_NFD_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
+	CatalogSource                      string `yaml:"catalogsource" envconfig:"NVIDIANETWORK_CATALOGSOURCE"`
+	SubscriptionChannel                string `yaml:"subscription_channel" envconfig:"NVIDIANETWORK_SUBSCRIPTION_CHANNEL"`
+	CleanupAfterTest                   bool   `yaml:"cleanup" envconfig:"NVIDIANETWORK_CLEANUP"`
+	DeployFromBundle                   bool   `yaml:"deploy_from_bundle" envconfig:"NVIDIANETWORK_DEPLOY_FROM_BUNDLE"`
+	BundleImage                        string `yaml:"bundle_image" envconfig:"NVIDIANETWORK_BUNDLE_IMAGE"`
+	OfedDriverVersion                  string `yaml:"ofed_driver_version" envconfig:"NVIDIANETWORK_OFED_DRIVER_VERSION"`
+	OfedDriverRepository               string `yaml:"ofed_repository" envconfig:"NVIDIANETWORK_OFED_REPOSITORY"`
+	RdmaClientHostname                 string `yaml:"rdma_client_hostname" envconfig:"NVIDIANETWORK_RDMA_CLIENT_HOSTNAME"`
+	RdmaServerHostname                 string `yaml:"rdma_server_hostname" envconfig:"NVIDIANETWORK_RDMA_SERVER_HOSTNAME"`
+	RdmaTestImage                      string `yaml:"rdma_test_image" envconfig:"NVIDIANETWORK_RDMA_TEST_IMAGE"`
+	MellanoxEthernetInterfaceName      string `yaml:"mellanox_eth_interface_name" envconfig:"NVIDIANETWORK_MELLANOX_ETH_INTERFACE_NAME"`
+	MellanoxInfinibandInterfaceName    string `yaml:"mellanox_ib_interface_name" envconfig:"NVIDIANETWORK_MELLANOX_IB_INTERFACE_NAME"`
+	MacvlanNetworkName                 string `yaml:"macvlannetwork_name" envconfig:"NVIDIANETWORK_MACVLANNETWORK_NAME"`
+	MacvlanNetworkIPAMRange            string `yaml:"macvlannetwork_ipam_range" envconfig:"NVIDIANETWORK_MACVLANNETWORK_IPAM_RANGE"`
+	MacvlanNetworkIPAMGateway          string `yaml:"macvlannetwork_ipam_gateway" envconfig:"NVIDIANETWORK_MACVLANNETWORK_IPAM_GATEWAY"`
+	OperatorUpgradeToChannel           string `yaml:"subscription_upgrade_to_channel" envconfig:"NVIDIANETWORK_SUBSCRIPTION_UPGRADE_TO_CHANNEL"`
+	InstallPlanApproval                string `yaml:"install_plan_approval" envconfig:"NVIDIANETWORK_INSTALL_PLAN_APPROVAL"`
+	StartingCSV                        string `yaml:"starting_csv" envconfig:"NVIDIANETWORK_STARTING_CSV"`
+	InstallMode                        string `yaml:"install_mode" envconfig:"NVIDIANETWORK_INSTALL_MODE"`
+	ClusterCatalog                     string `yaml:"clustercatalog" envconfig:"NVIDIANETWORK_CLUSTERCATALOG"`
+	VersionRange                       string `yaml:"version_range" envconfig:"NVIDIANETWORK_VERSION_RANGE"`
+	ClusterExtensionClusterAdmin       bool   `yaml:"clusterextension_cluster_admin" envconfig:"NVIDIANETWORK_CLUSTEREXTENSION_CLUSTER_ADMIN"`
+	NNOFallbackCatalogsourceIndexImage string `yaml:"nno_fallback_catalogsource_index_image" envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
+	NFDFallbackCatalogsourceIndexImage string `yaml:"nfd_fallback_catalogsource_index_image" envconfig:"NVIDIANETWORK_NFD_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
 }
 
-// NewNvidiaNetworkConfig returns instance of NvidiaNetworkConfig type.
+// NewNvidiaNetworkConfig returns instance of NvidiaNetworkConfig type. Values are loaded from the defaults, then
+// from the YAML profile selected by NVIDIANETWORK_CONFIG_PROFILE if any, then from the environment variables.
 func NewNvidiaNetworkConfig() *NvidiaNetworkConfig {
 	glog.V(100).Info("Creating new NvidiaNetworkConfig")
 
-	nvidiaNetworkConfig := new(NvidiaNetworkConfig)
+	nvidiaNetworkConfig := &NvidiaNetworkConfig{
+		CleanupAfterTest: true,
+		DeployFromBundle: false,
+	}
+
+	err := config.LoadProfile(os.Getenv(ProfileEnvVar), nvidiaNetworkConfig)
+	if err != nil {
+		glog.V(100).Infof("failed to load NvidiaNetworkConfig profile: %v", err)
+
+		return nil
+	}
 
-	err := envconfig.Process("nvidianetwork", nvidiaNetworkConfig)
+	err = envconfig.Process("nvidianetwork", nvidiaNetworkConfig)
 	if err != nil {
 		glog.V(100).Infof("failed to instantiate NvidiaNetworkConfig: %v", err)
 
@@ -43,3 +73,80 @@ func NewNvidiaNetworkConfig() *NvidiaNetworkConfig {
 
 	return nvidiaNetworkConfig
 }
+
+// Validate returns all the inconsistencies found in the NvidiaNetworkConfig at once. rdmaSelected tells
+// whether the RDMA test cases are selected to run, which requires the RDMA hostnames.
+func (nvidiaNetworkConfig *NvidiaNetworkConfig) Validate(rdmaSelected bool) error {
+	var errs []error
+
+	var ipamRange *net.IPNet
+
+	if nvidiaNetworkConfig.MacvlanNetworkIPAMRange != "" {
+		var err error
+
+		_, ipamRange, err = net.ParseCIDR(nvidiaNetworkConfig.MacvlanNetworkIPAMRange)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("NVIDIANETWORK_MACVLANNETWORK_IPAM_RANGE '%s' is not a CIDR",
+				nvidiaNetworkConfig.MacvlanNetworkIPAMRange))
+		}
+	}
+
+	if nvidiaNetworkConfig.MacvlanNetworkIPAMGateway != "" {
+		gateway := net.ParseIP(nvidiaNetworkConfig.MacvlanNetworkIPAMGateway)
+
+		switch {
+		case gateway == nil:
+			errs = append(errs, fmt.Errorf("NVIDIANETWORK_MACVLANNETWORK_IPAM_GATEWAY '%s' is not an IP address",
+				nvidiaNetworkConfig.MacvlanNetworkIPAMGateway))
+		case ipamRange != nil && !ipamRange.Contains(gateway):
+			errs = append(errs, fmt.Errorf("NVIDIANETWORK_MACVLANNETWORK_IPAM_GATEWAY '%s' is outside of "+
+				"NVIDIANETWORK_MACVLANNETWORK_IPAM_RANGE '%s'", nvidiaNetworkConfig.MacvlanNetworkIPAMGateway,
+				nvidiaNetworkConfig.MacvlanNetworkIPAMRange))
+		}
+	}
+
+	if rdmaSelected {
+		if nvidiaNetworkConfig.RdmaClientHostname == "" {
+			errs = append(errs, fmt.Errorf("NVIDIANETWORK_RDMA_CLIENT_HOSTNAME is required to run the rdma tests"))
+		}
+
+		if nvidiaNetworkConfig.RdmaServerHostname == "" {
+			errs = append(errs, fmt.Errorf("NVIDIANETWORK_RDMA_SERVER_HOSTNAME is required to run the rdma tests"))
+		}
+	}
+
+	if nvidiaNetworkConfig.OperatorUpgradeToChannel != "" &&
+		nvidiaNetworkConfig.OperatorUpgradeToChannel == nvidiaNetworkConfig.SubscriptionChannel {
+		errs = append(errs, fmt.Errorf("NVIDIANETWORK_SUBSCRIPTION_UPGRADE_TO_CHANNEL '%s' must differ from "+
+			"NVIDIANETWORK_SUBSCRIPTION_CHANNEL", nvidiaNetworkConfig.OperatorUpgradeToChannel))
+	}
+
+	if err := config.ValidateInstallPlanApproval("NVIDIANETWORK_INSTALL_PLAN_APPROVAL",
+		nvidiaNetworkConfig.InstallPlanApproval); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := config.ValidateInstallMode("NVIDIANETWORK_INSTALL_MODE", nvidiaNetworkConfig.InstallMode); err != nil {
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, config.ValidateInstallModeOptions("NVIDIANETWORK_INSTALL_MODE", nvidiaNetworkConfig.UsesOLMv1(),
+		map[string]bool{
+			"NVIDIANETWORK_DEPLOY_FROM_BUNDLE":              nvidiaNetworkConfig.DeployFromBundle,
+			"NVIDIANETWORK_SUBSCRIPTION_UPGRADE_TO_CHANNEL": nvidiaNetworkConfig.OperatorUpgradeToChannel != "",
+			"NVIDIANETWORK_INSTALL_PLAN_APPROVAL":           nvidiaNetworkConfig.InstallPlanApproval != "",
+			"NVIDIANETWORK_STARTING_CSV":                    nvidiaNetworkConfig.StartingCSV != "",
+		},
+		map[string]bool{
+			"NVIDIANETWORK_VERSION_RANGE":                  nvidiaNetworkConfig.VersionRange != "",
+			"NVIDIANETWORK_CLUSTEREXTENSION_CLUSTER_ADMIN": nvidiaNetworkConfig.ClusterExtensionClusterAdmin,
+		})...)
+
+	return errors.Join(errs...)
+}
+
+// UsesOLMv1 returns true when the operator is installed with an OLM v1 ClusterExtension instead of a
+// Subscription.
+func (nvidiaNetworkConfig *NvidiaNetworkConfig) UsesOLMv1() bool {
+	return nvidiaNetworkConfig.InstallMode == config.InstallModeOLMv1
+}
@@ -48,6 +48,8 @@ func CreateRdmaWorkloadPod(name, namespace, withCuda, mode, hostname, device, cr
 				"kubernetes.io/hostname": hostname,
 			},
 			ServiceAccountName: "rdma",
+			// ib_write_bw runs once, the pod completes when it exits.
+			RestartPolicy: corev1.RestartPolicyNever,
 			Containers: []corev1.Container{
 				{
 					Name:            name,
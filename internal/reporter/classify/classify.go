@@ -0,0 +1,364 @@
+// Package classify tells who has to act on a failed spec: the infrastructure, the product or the test.
+package classify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// Category tells who has to act on a failure.
+type Category string
+
+const (
+	// CategoryInfra is a failure of the cluster or its environment: machines, registries, catalogs, API.
+	CategoryInfra Category = "infra"
+	// CategoryProduct is a failure of the operators or their operands under test.
+	CategoryProduct Category = "product"
+	// CategoryTest is a failure of the test code or of its configuration.
+	CategoryTest Category = "test"
+	// CategoryUnknown is a failure matching no known signature.
+	CategoryUnknown Category = "unknown"
+
+	// ReportEntryName is the name of the report entry holding the Classification of a spec.
+	ReportEntryName = "failure-classification"
+
+	classifyTimeout       = time.Minute
+	classifyLogTailLines  = 200
+	classifyEvidenceChars = 300
+)
+
+// Classification is the category of a failed spec, with the signature it matched and a triage hint.
+type Classification struct {
+	Category  Category `json:"category"`
+	Signature string   `json:"signature"`
+	Hint      string   `json:"hint"`
+	Evidence  string   `json:"evidence,omitempty"`
+}
+
+// String returns the one line summary of the classification.
+func (classification Classification) String() string {
+	return fmt.Sprintf("%s/%s: %s", classification.Category, classification.Signature, classification.Hint)
+}
+
+// failureSignature is a known cause of failure, recognized by pattern in the failure message, pod states,
+// events or container logs of a spec. A signature with an appliesTo function is only looked for in the
+// evidences it accepts.
+type failureSignature struct {
+	name      string
+	category  Category
+	pattern   *regexp.Regexp
+	hint      string
+	appliesTo func(evidence Evidence) bool
+}
+
+var testPanicSignature = failureSignature{
+	name:     "test-panic",
+	category: CategoryTest,
+	pattern:  regexp.MustCompile(`runtime error:|nil pointer dereference|index out of range`),
+	hint:     "The test code panicked; fix the test.",
+}
+
+// failureSignatures are matched in order: root causes come before the symptoms they lead to.
+var failureSignatures = []failureSignature{
+	testPanicSignature,
+	{
+		name:     "invalid-config",
+		category: CategoryTest,
+		pattern: regexp.MustCompile(
+			`invalid NVIDIA(?:GPU|NETWORK) configuration|NVIDIA(?:GPU|NETWORK)_[A-Z_]+ .*(?:is not|is required|must)`),
+		hint: "The suite configuration is invalid; check the NVIDIAGPU_/NVIDIANETWORK_ env vars and profile.",
+	},
+	{
+		name:     "dtk-kernel-mismatch",
+		category: CategoryProduct,
+		pattern: regexp.MustCompile(`(?i)(?:driver[- ]?toolkit|dtk)[^\n]*kernel[^\n]*(?:mismatch|does not match|differ)|` +
+			`kernel[^\n]*(?:mismatch|does not match)[^\n]*(?:driver[- ]?toolkit|dtk)|could not resolve linux kernel version`),
+		hint: "The Driver Toolkit kernel does not match the node kernel; check the driver-toolkit imagestream " +
+			"of this OpenShift release.",
+	},
+	{
+		name:     "driver-build-failed",
+		category: CategoryProduct,
+		pattern: regexp.MustCompile(`(?i)failed to (?:build|compile|install) (?:the )?(?:nvidia |ofed |mofed )?driver|` +
+			`driver (?:build|compilation|installation) failed|make\[\d+\]: \*\*\*|dkms[^\n]*(?:failed|error)`),
+		hint: "The GPU or OFED driver container failed to build the driver; read the driver container logs.",
+	},
+	{
+		name:     "olm-resolution-failed",
+		category: CategoryProduct,
+		pattern:  regexp.MustCompile(`ResolutionFailed|constraints not satisfiable`),
+		hint:     "OLM could not resolve the Subscription; check the bundle dependencies and the catalog channel.",
+	},
+	{
+		name:     "olm-install-failed",
+		category: CategoryProduct,
+		pattern: regexp.MustCompile(`(?:ClusterServiceVersion|InstallPlan) \S+ in namespace \S+ failed|` +
+			`(?:InstallPlanFailed|BundleUnpackFailed)`),
+		hint: "OLM failed to install the operator bundle; read the CSV or InstallPlan message and the " +
+			"catalog-operator logs.",
+	},
+	{
+		name:     "image-pull",
+		category: CategoryInfra,
+		pattern:  regexp.MustCompile(`ImagePullBackOff|ErrImagePull|ErrImageNeverPull`),
+		hint:     "An image could not be pulled; check the registry availability, the image tag and the pull secret.",
+	},
+	{
+		name:     "machine-not-provisioned",
+		category: CategoryInfra,
+		pattern: regexp.MustCompile(`(?i)failed to detect at least one replica|error launching instance|` +
+			`failed to create instance|InsufficientInstanceCapacity|InstanceLimitExceeded|VcpuLimitExceeded|` +
+			`ZONE_RESOURCE_POOL_EXHAUSTED|SkuNotAvailable|QuotaExceeded`),
+		hint:      "The GPU MachineSet never got a ready machine; check the cloud quota and instance type availability.",
+		appliesTo: isMachineEvidence,
+	},
+	{
+		name:     "catalog-unreachable",
+		category: CategoryInfra,
+		pattern: regexp.MustCompile(`(?i)catalogsource[^\n]*(?:TRANSIENT_FAILURE|CONNECTING|unreachable|not ready)|` +
+			`packagemanifest[^\n]*(?:not found|timed out|timeout)|CatalogSourcesUnhealthy`),
+		hint: "The operator catalog is unreachable or does not serve the package; check the CatalogSource pod " +
+			"and its index image.",
+	},
+	{
+		name:     "api-unavailable",
+		category: CategoryInfra,
+		pattern: regexp.MustCompile(`(?i)connection refused|i/o timeout|TLS handshake timeout|` +
+			`etcdserver: request timed out|the server is currently unable to handle the request`),
+		hint: "The cluster API was unavailable; check the cluster health.",
+	},
+	{
+		name:     "scheduling",
+		category: CategoryInfra,
+		pattern:  regexp.MustCompile(`FailedScheduling|Insufficient (?:nvidia\.com|rdma)/`),
+		hint:     "A pod could not be scheduled; check the node capacity and the resources advertised by the device plugins.",
+	},
+	{
+		name:     "crash-loop",
+		category: CategoryProduct,
+		pattern:  regexp.MustCompile(`CrashLoopBackOff`),
+		hint:     "An operator or operand container keeps crashing; read its previous logs.",
+	},
+	{
+		name:     "operand-not-ready",
+		category: CategoryProduct,
+		pattern: regexp.MustCompile(`(?i)(?:ClusterPolicy|NicClusterPolicy|MacvlanNetwork|ClusterServiceVersion) ` +
+			`to be (?:Ready|succeeded)|ClusterPolicy[^\n]*notReady`),
+		hint: "The operator did not get its operands ready; check the operand pods and the operator logs.",
+	},
+	{
+		name:     "workload-failed",
+		category: CategoryProduct,
+		pattern:  regexp.MustCompile(`(?i)gpu-burn|rdma (?:server|client)|ib_write_bw`),
+		hint:     "The GPU or RDMA workload failed on a ready operator; read the workload pod logs.",
+	},
+}
+
+// Evidence is a piece of text a failure signature is looked for in, with the kind of the object of an event.
+type Evidence struct {
+	Source     string
+	Text       string
+	ObjectKind string
+}
+
+// failureMessageSource is the source of the failure message evidence.
+const failureMessageSource = "failure"
+
+// EventEvidence returns the evidence of an event of object, given as kind/name.
+func EventEvidence(text, object string) Evidence {
+	objectKind, _, _ := strings.Cut(object, "/")
+
+	return Evidence{Source: "event", Text: text, ObjectKind: objectKind}
+}
+
+// Failure returns the classification of the failed specReport from its failure message, the events
+// evidences of the spec and the pod states and container logs of namespaces.
+func Failure(
+	specReport types.SpecReport,
+	apiClient *clients.Settings,
+	namespaces map[string]string,
+	events []Evidence) Classification {
+	// A panic is a failure of the test code, wherever it happened.
+	if specReport.State == types.SpecStatePanicked {
+		return newClassification(testPanicSignature, "panic: "+specReport.Failure.ForwardedPanic)
+	}
+
+	evidences := append([]Evidence{{Source: failureMessageSource, Text: specReport.Failure.Message}}, events...)
+
+	if apiClient != nil {
+		evidences = append(evidences, collectPodEvidences(apiClient, namespaces)...)
+	}
+
+	return matchFailureSignatures(evidences)
+}
+
+// matchFailureSignatures returns the classification of the first failure signature matching evidences.
+func matchFailureSignatures(evidences []Evidence) Classification {
+	for _, signature := range failureSignatures {
+		for _, evidence := range evidences {
+			if signature.appliesTo != nil && !signature.appliesTo(evidence) {
+				continue
+			}
+
+			if location := signature.pattern.FindStringIndex(evidence.Text); location != nil {
+				return newClassification(signature, fmt.Sprintf("%s: %s", evidence.Source,
+					evidenceLine(evidence.Text, location)))
+			}
+		}
+	}
+
+	return Classification{
+		Category:  CategoryUnknown,
+		Signature: "none",
+		Hint:      "No known failure signature matched; read the failure message and the dump.",
+	}
+}
+
+// isMachineEvidence reports whether evidence is the failure message or an event of a Machine or MachineSet,
+// where the machine API errors are reported, rather than a container log mentioning machines.
+func isMachineEvidence(evidence Evidence) bool {
+	return evidence.Source == failureMessageSource || evidence.ObjectKind == "Machine" ||
+		evidence.ObjectKind == "MachineSet"
+}
+
+func newClassification(signature failureSignature, evidence string) Classification {
+	return Classification{
+		Category:  signature.category,
+		Signature: signature.name,
+		Hint:      signature.hint,
+		Evidence:  evidence,
+	}
+}
+
+// collectPodEvidences returns the states of the containers of the pods of namespaces, and the logs of the
+// containers that are restarting or terminated with an error.
+func collectPodEvidences(apiClient *clients.Settings, namespaces map[string]string) []Evidence {
+	ctx, cancel := context.WithTimeout(context.Background(), classifyTimeout)
+	defer cancel()
+
+	namespaceNames := make([]string, 0, len(namespaces))
+	for namespace := range namespaces {
+		namespaceNames = append(namespaceNames, namespace)
+	}
+
+	sort.Strings(namespaceNames)
+
+	var evidences []Evidence
+
+	for _, namespace := range namespaceNames {
+		podList, err := apiClient.Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			glog.V(100).Infof("Failed to list pods in namespace %s to classify the failure: %v", namespace, err)
+
+			continue
+		}
+
+		for _, pod := range podList.Items {
+			statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...),
+				pod.Status.ContainerStatuses...)
+
+			for _, status := range statuses {
+				source := fmt.Sprintf("pod %s/%s container %s", pod.Namespace, pod.Name, status.Name)
+
+				state, failing := describeContainerState(status)
+				if state != "" {
+					evidences = append(evidences, Evidence{Source: source, Text: state})
+				}
+
+				if !failing {
+					continue
+				}
+
+				logs, err := apiClient.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+					Container: status.Name,
+					Previous:  status.RestartCount > 0,
+					TailLines: ptr.To[int64](classifyLogTailLines),
+				}).DoRaw(ctx)
+				if err != nil {
+					glog.V(100).Infof("Failed to get logs of %s to classify the failure: %v", source, err)
+
+					continue
+				}
+
+				evidences = append(evidences, Evidence{Source: source + " logs", Text: string(logs)})
+			}
+		}
+	}
+
+	return evidences
+}
+
+// describeContainerState returns the waiting or terminated state of a container and whether it is failing.
+func describeContainerState(status corev1.ContainerStatus) (string, bool) {
+	failing := status.RestartCount > 0
+
+	switch {
+	case status.State.Waiting != nil:
+		failing = failing || status.State.Waiting.Reason == "CrashLoopBackOff"
+
+		return fmt.Sprintf("waiting %s: %s", status.State.Waiting.Reason, status.State.Waiting.Message), failing
+	case status.State.Terminated != nil && status.State.Terminated.ExitCode != 0:
+		return fmt.Sprintf("terminated %s (exit code %d): %s", status.State.Terminated.Reason,
+			status.State.Terminated.ExitCode, status.State.Terminated.Message), true
+	}
+
+	return "", failing
+}
+
+// evidenceLine returns the line of text holding the match at location, shortened for the reports.
+func evidenceLine(text string, location []int) string {
+	start := strings.LastIndex(text[:location[0]], "\n") + 1
+
+	end := strings.Index(text[location[1]:], "\n")
+	if end < 0 {
+		end = len(text)
+	} else {
+		end += location[1]
+	}
+
+	line := strings.TrimSpace(text[start:end])
+	if len(line) > classifyEvidenceChars {
+		line = line[:classifyEvidenceChars] + "..."
+	}
+
+	return line
+}
+
+// Of returns the classification recorded for specReport, or classifies it from its failure
+// message alone when none was recorded. It returns nil for a spec that did not fail.
+func Of(specReport types.SpecReport) *Classification {
+	if !specReport.Failed() {
+		return nil
+	}
+
+	for _, entry := range specReport.ReportEntries {
+		if entry.Name != ReportEntryName {
+			continue
+		}
+
+		if classification, ok := entry.Value.GetRawValue().(Classification); ok {
+			return &classification
+		}
+
+		var classification Classification
+		if err := json.Unmarshal([]byte(entry.Value.AsJSON), &classification); err == nil {
+			return &classification
+		}
+	}
+
+	classification := Failure(specReport, nil, nil, nil)
+
+	return &classification
+}
@@ -0,0 +1,152 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func TestFailure(t *testing.T) {
+	testCases := []struct {
+		name              string
+		state             types.SpecState
+		message           string
+		events            []Evidence
+		expectedCategory  Category
+		expectedSignature string
+	}{
+		{
+			name:              "panic",
+			state:             types.SpecStatePanicked,
+			message:           "anything",
+			expectedCategory:  CategoryTest,
+			expectedSignature: "test-panic",
+		},
+		{
+			name:              "invalid configuration",
+			message:           "invalid NVIDIAGPU configuration: NVIDIAGPU_BUNDLE_IMAGE is required",
+			expectedCategory:  CategoryTest,
+			expectedSignature: "invalid-config",
+		},
+		{
+			name:              "machineset without replica",
+			message:           "Failed to detect at least one replica of MachineSet gpu-worker in Ready state",
+			expectedCategory:  CategoryInfra,
+			expectedSignature: "machine-not-provisioned",
+		},
+		{
+			name:    "machine event",
+			message: "Timed out after 30m",
+			events: []Evidence{
+				EventEvidence("InsufficientInstanceCapacity: no g4dn.xlarge capacity in us-east-1a",
+					"Machine/gpu-worker-abc"),
+			},
+			expectedCategory:  CategoryInfra,
+			expectedSignature: "machine-not-provisioned",
+		},
+		{
+			name:    "machine api error of another object",
+			message: "Timed out after 30m",
+			events: []Evidence{
+				EventEvidence("InsufficientInstanceCapacity while waiting for the machine phase",
+					"Pod/gpu-operator-abc"),
+			},
+			expectedCategory:  CategoryUnknown,
+			expectedSignature: "none",
+		},
+		{
+			name:              "machine mentioned in the failure message",
+			message:           "ClusterPolicy not ready: machine config pool is in provisioning phase",
+			expectedCategory:  CategoryUnknown,
+			expectedSignature: "none",
+		},
+		{
+			name:              "resolution before catalog",
+			message:           "ResolutionFailed: CatalogSourcesUnhealthy",
+			expectedCategory:  CategoryProduct,
+			expectedSignature: "olm-resolution-failed",
+		},
+		{
+			name:    "image pull event",
+			message: "Timed out waiting for ClusterPolicy to be Ready",
+			events: []Evidence{
+				EventEvidence("Error: ErrImagePull",
+					"Pod/nvidia-driver-daemonset-abc"),
+			},
+			expectedCategory:  CategoryInfra,
+			expectedSignature: "image-pull",
+		},
+		{
+			name:              "operand not ready",
+			message:           "Timed out waiting for ClusterPolicy to be Ready",
+			expectedCategory:  CategoryProduct,
+			expectedSignature: "operand-not-ready",
+		},
+		{
+			name:              "unknown",
+			message:           "Expected true to be false",
+			expectedCategory:  CategoryUnknown,
+			expectedSignature: "none",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			state := testCase.state
+			if state == types.SpecStateInvalid {
+				state = types.SpecStateFailed
+			}
+
+			specReport := types.SpecReport{State: state, Failure: types.Failure{Message: testCase.message}}
+
+			classification := Failure(specReport, nil, nil, testCase.events)
+
+			if classification.Category != testCase.expectedCategory ||
+				classification.Signature != testCase.expectedSignature {
+				t.Errorf("expected %s/%s, got %s", testCase.expectedCategory, testCase.expectedSignature,
+					classification)
+			}
+		})
+	}
+}
+
+func TestMatchFailureSignaturesMachineLogs(t *testing.T) {
+	evidences := []Evidence{
+		{Source: failureMessageSource, Text: "Timed out after 30m"},
+		{Source: "pod nvidia-gpu-operator/gpu-operator-abc container gpu-operator logs",
+			Text: "machineset gpu-worker has 1 ready replica\nerror launching instance"},
+	}
+
+	if classification := matchFailureSignatures(evidences); classification.Signature != "none" {
+		t.Errorf("container logs must not match the machine signature, got %s", classification)
+	}
+}
+
+func TestEvidenceLine(t *testing.T) {
+	text := "first line\nsecond ErrImagePull line\nthird line"
+	location := []int{17, 29}
+
+	if line := evidenceLine(text, location); line != "second ErrImagePull line" {
+		t.Errorf("expected the line of the match, got %q", line)
+	}
+}
+
+func TestOf(t *testing.T) {
+	if classification := Of(types.SpecReport{State: types.SpecStatePassed}); classification != nil {
+		t.Errorf("expected no classification for a passed spec, got %s", classification)
+	}
+
+	recorded := Classification{Category: CategoryInfra, Signature: "image-pull"}
+	specReport := types.SpecReport{
+		State: types.SpecStateFailed,
+		ReportEntries: types.ReportEntries{{
+			Name:  ReportEntryName,
+			Value: types.WrapEntryValue(recorded),
+		}},
+	}
+
+	classification := Of(specReport)
+	if classification == nil || classification.Signature != recorded.Signature {
+		t.Errorf("expected the recorded classification, got %v", classification)
+	}
+}
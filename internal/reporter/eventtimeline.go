@@ -0,0 +1,217 @@
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/redact"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	eventTimelineFileName = "events_timeline.log"
+	eventWatchRetryDelay  = 5 * time.Second
+)
+
+// TimelineEvent is a Kubernetes event seen while a spec was running.
+type TimelineEvent struct {
+	Time      time.Time
+	Namespace string
+	Type      string
+	Reason    string
+	Object    string
+	Count     int32
+	Message   string
+}
+
+// String returns the timeline line of the event.
+func (event TimelineEvent) String() string {
+	return fmt.Sprintf("%s %s %s %s %s (x%d): %s", event.Time.UTC().Format(time.RFC3339), event.Namespace,
+		event.Type, event.Reason, event.Object, event.Count, event.Message)
+}
+
+// eventTimeline records the events of a set of namespaces from the start of a spec. Events are watched so that
+// the ones compacted by the cluster before the end of the spec are kept.
+type eventTimeline struct {
+	start     time.Time
+	cancel    context.CancelFunc
+	waitGroup sync.WaitGroup
+	mutex     sync.Mutex
+	events    []TimelineEvent
+}
+
+var (
+	activeTimelineMutex sync.Mutex
+	activeTimeline      *eventTimeline
+)
+
+// StartEventTimeline starts recording the events of namespacesToDump for the spec about to run. The timeline is
+// written next to the dump of ReportIfFailed when the spec fails.
+func StartEventTimeline(namespacesToDump map[string]string) {
+	stopEventTimeline()
+
+	if inittools.APIClient == nil {
+		glog.V(100).Infof("No API client, events timeline is not recorded")
+
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	timeline := &eventTimeline{start: time.Now(), cancel: cancel}
+
+	for namespace := range namespacesToDump {
+		timeline.waitGroup.Add(1)
+
+		go timeline.watch(ctx, inittools.APIClient, namespace)
+	}
+
+	activeTimelineMutex.Lock()
+	activeTimeline = timeline
+	activeTimelineMutex.Unlock()
+}
+
+// stopEventTimeline stops the active timeline and returns its events, oldest first.
+func stopEventTimeline() []TimelineEvent {
+	activeTimelineMutex.Lock()
+	timeline := activeTimeline
+	activeTimeline = nil
+	activeTimelineMutex.Unlock()
+
+	if timeline == nil {
+		return nil
+	}
+
+	timeline.cancel()
+	timeline.waitGroup.Wait()
+
+	sort.SliceStable(timeline.events, func(i, j int) bool {
+		return timeline.events[i].Time.Before(timeline.events[j].Time)
+	})
+
+	return timeline.events
+}
+
+// watch records the events of namespace until ctx is done. The watch is restarted from the last seen resource
+// version when it is closed by the server, and from a fresh list when that version is gone.
+func (timeline *eventTimeline) watch(ctx context.Context, apiClient *clients.Settings, namespace string) {
+	defer timeline.waitGroup.Done()
+
+	resourceVersion := ""
+
+	for ctx.Err() == nil {
+		if resourceVersion == "" {
+			eventList, err := apiClient.Events(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+			if err != nil {
+				glog.V(100).Infof("Failed to list events in namespace %s: %v", namespace, err)
+				sleepOrDone(ctx, eventWatchRetryDelay)
+
+				continue
+			}
+
+			resourceVersion = eventList.ResourceVersion
+		}
+
+		watcher, err := apiClient.Events(namespace).Watch(ctx,
+			metav1.ListOptions{ResourceVersion: resourceVersion, AllowWatchBookmarks: true})
+		if err != nil {
+			glog.V(100).Infof("Failed to watch events in namespace %s: %v", namespace, err)
+
+			resourceVersion = ""
+
+			sleepOrDone(ctx, eventWatchRetryDelay)
+
+			continue
+		}
+
+		resourceVersion = timeline.consume(ctx, watcher, namespace, resourceVersion)
+	}
+}
+
+// consume records the events of watcher until it is closed and returns the resource version to resume from.
+func (timeline *eventTimeline) consume(
+	ctx context.Context, watcher watch.Interface, namespace, resourceVersion string) string {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion
+		case result, open := <-watcher.ResultChan():
+			if !open {
+				return resourceVersion
+			}
+
+			switch result.Type {
+			case watch.Added, watch.Modified:
+				if event, ok := result.Object.(*corev1.Event); ok {
+					resourceVersion = event.ResourceVersion
+					timeline.record(namespace, event)
+				}
+			case watch.Bookmark:
+				if event, ok := result.Object.(*corev1.Event); ok {
+					resourceVersion = event.ResourceVersion
+				}
+			case watch.Error:
+				glog.V(100).Infof("Events watch in namespace %s failed: %v", namespace, result.Object)
+
+				return ""
+			}
+		}
+	}
+}
+
+func (timeline *eventTimeline) record(namespace string, event *corev1.Event) {
+	seenAt := eventTime(*event)
+	if !seenAt.IsZero() && seenAt.Before(timeline.start) {
+		return
+	}
+
+	timeline.mutex.Lock()
+	defer timeline.mutex.Unlock()
+
+	timeline.events = append(timeline.events, TimelineEvent{
+		Time:      seenAt,
+		Namespace: namespace,
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Object:    fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
+		Count:     event.Count,
+		Message:   event.Message,
+	})
+}
+
+// writeEventTimeline writes events, one per line, into the events timeline file of reportDir.
+func writeEventTimeline(reportDir string, events []TimelineEvent) error {
+	lines := make([]string, 0, len(events))
+
+	for _, event := range events {
+		lines = append(lines, event.String())
+	}
+
+	if err := os.MkdirAll(reportDir, 0755); err != nil {
+		return err
+	}
+
+	filePath := path.Join(reportDir, eventTimelineFileName)
+	glog.V(100).Infof("Writing %d events into %s", len(events), filePath)
+
+	return os.WriteFile(filePath, redact.Bytes([]byte(strings.Join(lines, "\n")+"\n")), 0644)
+}
+
+func sleepOrDone(ctx context.Context, delay time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(delay):
+	}
+}
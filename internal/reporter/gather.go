@@ -0,0 +1,325 @@
+package reporter
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/redact"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+)
+
+// gatherer writes the state of a cluster into a structured artifact directory. Gathering goes on after a
+// failure; all the failures are returned at once by err.
+type gatherer struct {
+	ctx       context.Context
+	apiClient *clients.Settings
+	dir       string
+	errs      []error
+}
+
+func newGatherer(ctx context.Context, apiClient *clients.Settings, dir string) (*gatherer, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("apiClient cannot be nil")
+	}
+
+	if dir == "" {
+		return nil, fmt.Errorf("artifact directory cannot be empty")
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create artifact directory %s: %w", dir, err)
+	}
+
+	return &gatherer{ctx: ctx, apiClient: apiClient, dir: dir}, nil
+}
+
+// fail records a gathering failure.
+func (gather *gatherer) fail(err error) {
+	glog.V(100).Infof("Gathering into %s: %v", gather.dir, err)
+
+	gather.errs = append(gather.errs, err)
+}
+
+// err returns the recorded failures, or the interruption of the gathering when it ran out of time.
+func (gather *gatherer) err() error {
+	if gather.ctx.Err() != nil {
+		return fmt.Errorf("gathering into %s interrupted: %w", gather.dir, gather.ctx.Err())
+	}
+
+	return errors.Join(gather.errs...)
+}
+
+// writeFile writes the redacted content into the file at relPath of the artifact directory.
+func (gather *gatherer) writeFile(relPath string, content []byte) {
+	filePath := filepath.Join(gather.dir, relPath)
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		gather.fail(err)
+
+		return
+	}
+
+	if err := os.WriteFile(filePath, redact.Bytes(content), 0644); err != nil {
+		gather.fail(err)
+	}
+}
+
+// writeYAML writes object as YAML into the file at relPath of the artifact directory.
+func (gather *gatherer) writeYAML(relPath string, object interface{}) {
+	content, err := yaml.Marshal(object)
+	if err != nil {
+		gather.fail(fmt.Errorf("failed to marshal %s: %w", relPath, err))
+
+		return
+	}
+
+	gather.writeFile(relPath, content)
+}
+
+// gatherList lists the objects of list with the runtime client and writes them into relPath.
+func (gather *gatherer) gatherList(relPath string, list runtimeClient.ObjectList, opts ...runtimeClient.ListOption) {
+	if gather.ctx.Err() != nil {
+		return
+	}
+
+	if err := gather.apiClient.Client.List(gather.ctx, list, opts...); err != nil {
+		gather.fail(fmt.Errorf("failed to list %s: %w", relPath, err))
+
+		return
+	}
+
+	gather.writeYAML(relPath, list)
+}
+
+// gatherResources lists the objects of resource in namespace, or in all namespaces when namespace is empty,
+// with the dynamic client and writes them into relPath. Resources not served by the cluster are skipped.
+func (gather *gatherer) gatherResources(relPath string, resource schema.GroupVersionResource, namespace string) {
+	if gather.ctx.Err() != nil {
+		return
+	}
+
+	list, err := gather.apiClient.Resource(resource).Namespace(namespace).List(gather.ctx, metav1.ListOptions{})
+	if k8serrors.IsNotFound(err) {
+		glog.V(100).Infof("Resource %s is not served by the cluster, skipping it", resource)
+
+		return
+	}
+
+	if err != nil {
+		gather.fail(fmt.Errorf("failed to list %s: %w", relPath, err))
+
+		return
+	}
+
+	gather.writeYAML(relPath, list)
+}
+
+// gatherPods writes the pods of namespace and the logs of all their containers, including the logs of the
+// previous instance of the restarted containers.
+func (gather *gatherer) gatherPods(relDir, namespace string) {
+	if gather.ctx.Err() != nil {
+		return
+	}
+
+	podList, err := gather.apiClient.Pods(namespace).List(gather.ctx, metav1.ListOptions{})
+	if err != nil {
+		gather.fail(fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err))
+
+		return
+	}
+
+	gather.writeYAML(filepath.Join(relDir, "pods.yaml"), podList)
+
+	for _, pod := range podList.Items {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...),
+			pod.Status.ContainerStatuses...)
+
+		for _, status := range statuses {
+			logDir := filepath.Join(relDir, "pods", pod.Name)
+
+			gather.gatherLog(filepath.Join(logDir, status.Name+".log"), pod, status.Name, false)
+
+			if status.RestartCount > 0 {
+				gather.gatherLog(filepath.Join(logDir, status.Name+".previous.log"), pod, status.Name, true)
+			}
+		}
+	}
+}
+
+func (gather *gatherer) gatherLog(relPath string, pod corev1.Pod, container string, previous bool) {
+	if gather.ctx.Err() != nil {
+		return
+	}
+
+	logs, err := gather.apiClient.Pods(pod.Namespace).GetLogs(pod.Name,
+		&corev1.PodLogOptions{Container: container, Previous: previous}).DoRaw(gather.ctx)
+	if err != nil {
+		gather.fail(fmt.Errorf("failed to get logs of container %s of pod %s/%s: %w",
+			container, pod.Namespace, pod.Name, err))
+
+		return
+	}
+
+	gather.writeFile(relPath, logs)
+}
+
+// gatherEvents writes the events of namespace, oldest first.
+func (gather *gatherer) gatherEvents(relPath, namespace string) {
+	if gather.ctx.Err() != nil {
+		return
+	}
+
+	eventList, err := gather.apiClient.Events(namespace).List(gather.ctx, metav1.ListOptions{})
+	if err != nil {
+		gather.fail(fmt.Errorf("failed to list events in namespace %s: %w", namespace, err))
+
+		return
+	}
+
+	sort.SliceStable(eventList.Items, func(i, j int) bool {
+		return eventTime(eventList.Items[i]).Before(eventTime(eventList.Items[j]))
+	})
+
+	gather.writeYAML(relPath, eventList)
+}
+
+// gatherOLM writes the ClusterServiceVersions, Subscriptions and InstallPlans of namespace.
+func (gather *gatherer) gatherOLM(relDir, namespace string) {
+	gather.gatherList(filepath.Join(relDir, "clusterserviceversions.yaml"),
+		&olmv1alpha1.ClusterServiceVersionList{}, runtimeClient.InNamespace(namespace))
+	gather.gatherList(filepath.Join(relDir, "subscriptions.yaml"),
+		&olmv1alpha1.SubscriptionList{}, runtimeClient.InNamespace(namespace))
+	gather.gatherList(filepath.Join(relDir, "installplans.yaml"),
+		&olmv1alpha1.InstallPlanList{}, runtimeClient.InNamespace(namespace))
+}
+
+// gatherNodeLabels writes the name and the labels matching labelPrefixes of the nodes matching labelSelector.
+func (gather *gatherer) gatherNodeLabels(relPath, labelSelector string, labelPrefixes ...string) {
+	if gather.ctx.Err() != nil {
+		return
+	}
+
+	nodeList, err := gather.apiClient.CoreV1Interface.Nodes().List(gather.ctx,
+		metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		gather.fail(fmt.Errorf("failed to list nodes with label %s: %w", labelSelector, err))
+
+		return
+	}
+
+	nodeLabels := map[string]map[string]string{}
+
+	for _, node := range nodeList.Items {
+		labels := map[string]string{}
+
+		for key, value := range node.Labels {
+			for _, prefix := range labelPrefixes {
+				if strings.HasPrefix(key, prefix) {
+					labels[key] = value
+
+					break
+				}
+			}
+		}
+
+		nodeLabels[node.Name] = labels
+	}
+
+	gather.writeYAML(relPath, nodeLabels)
+}
+
+// compress archives the artifact directory into a tar.gz file next to it and removes the directory.
+func (gather *gatherer) compress() (string, error) {
+	archivePath := strings.TrimSuffix(gather.dir, string(filepath.Separator)) + ".tar.gz"
+
+	archiveFile, err := os.Create(archivePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create archive %s: %w", archivePath, err)
+	}
+
+	defer func() {
+		_ = archiveFile.Close()
+	}()
+
+	gzipWriter := gzip.NewWriter(archiveFile)
+	tarWriter := tar.NewWriter(gzipWriter)
+	baseDir := filepath.Dir(gather.dir)
+
+	err = filepath.Walk(gather.dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+
+		if header.Name, err = filepath.Rel(baseDir, filePath); err != nil {
+			return err
+		}
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = file.Close()
+		}()
+
+		_, err = io.Copy(tarWriter, file)
+
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to archive %s: %w", gather.dir, err)
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return "", err
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return "", err
+	}
+
+	return archivePath, os.RemoveAll(gather.dir)
+}
+
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+
+	return event.FirstTimestamp.Time
+}
@@ -0,0 +1,55 @@
+package reporter
+
+import (
+	"context"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	nvidiagpuv1alpha1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1alpha1"
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
+	appsv1 "k8s.io/api/apps/v1"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GatherGPUOperator collects the state of the NVIDIA GPU Operator into artifactDir, then compresses it into
+// artifactDir.tar.gz:
+//
+//	cluster/      ClusterPolicies, NVIDIADrivers and the GPU labels of the GPU nodes
+//	namespace/    operand DaemonSets, Deployments, pods, container logs and events of the operator namespace
+//	olm/          ClusterServiceVersions, Subscriptions and InstallPlans of the operator namespace
+//
+// Collection goes on after a failure; all the failures are returned at once.
+func GatherGPUOperator(ctx context.Context, apiClient *clients.Settings, artifactDir string) error {
+	glog.V(100).Infof("Gathering GPU Operator state into %s", artifactDir)
+
+	gather, err := newGatherer(ctx, apiClient, artifactDir)
+	if err != nil {
+		return err
+	}
+
+	namespace := nvidiagpu.NvidiaGPUNamespace
+
+	gather.gatherList("cluster/clusterpolicies.yaml", &nvidiagpuv1.ClusterPolicyList{})
+	gather.gatherList("cluster/nvidiadrivers.yaml", &nvidiagpuv1alpha1.NVIDIADriverList{})
+	gather.gatherNodeLabels("cluster/gpu-nodes.yaml", nvidiagpu.NvidiaGPULabel,
+		"nvidia.com/", "feature.node.kubernetes.io/pci-10de")
+
+	gather.gatherList("namespace/daemonsets.yaml", &appsv1.DaemonSetList{}, runtimeClient.InNamespace(namespace))
+	gather.gatherList("namespace/deployments.yaml", &appsv1.DeploymentList{}, runtimeClient.InNamespace(namespace))
+	gather.gatherPods("namespace", namespace)
+	gather.gatherEvents("namespace/events.yaml", namespace)
+
+	gather.gatherOLM("olm", namespace)
+
+	gatherErr := gather.err()
+
+	archivePath, err := gather.compress()
+	if err != nil {
+		return err
+	}
+
+	glog.V(100).Infof("GPU Operator state gathered into %s", archivePath)
+
+	return gatherErr
+}
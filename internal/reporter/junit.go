@@ -0,0 +1,98 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+
+	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/classify"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/redact"
+)
+
+// junitTestSuites is the Ginkgo JUnit report with properties on its test cases.
+type junitTestSuites struct {
+	XMLName xml.Name `xml:"testsuites"`
+	reporters.JUnitTestSuites
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	reporters.JUnitTestSuite
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	reporters.JUnitTestCase
+	Properties *reporters.JUnitProperties `xml:"properties,omitempty"`
+}
+
+// WriteJUnitReport writes the JUnit report of testSuite, with the failure classification of every failed spec
+// as failure.category, failure.signature, failure.hint and failure.evidence properties of its test case.
+func WriteJUnitReport(report types.Report, testSuite string) error {
+	reportPath := inittools.GeneralConfig.GetJunitReportPath(testSuite)
+	ginkgoReportPath := reportPath + ".ginkgo"
+
+	if err := reporters.GenerateJUnitReport(report, ginkgoReportPath); err != nil {
+		return fmt.Errorf("failed to generate JUnit report: %w", err)
+	}
+
+	content, err := os.ReadFile(ginkgoReportPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(ginkgoReportPath); err != nil {
+		return err
+	}
+
+	var ginkgoReport reporters.JUnitTestSuites
+	if err := xml.Unmarshal(content, &ginkgoReport); err != nil {
+		return fmt.Errorf("failed to decode JUnit report: %w", err)
+	}
+
+	junitReport := junitTestSuites{JUnitTestSuites: ginkgoReport}
+
+	for _, ginkgoSuite := range ginkgoReport.TestSuites {
+		suite := junitTestSuite{JUnitTestSuite: ginkgoSuite}
+
+		// Ginkgo writes one test case per spec report, in the same order.
+		for index, ginkgoTestCase := range ginkgoSuite.TestCases {
+			testCase := junitTestCase{JUnitTestCase: ginkgoTestCase}
+
+			if index < len(report.SpecReports) {
+				testCase.Properties = classificationProperties(report.SpecReports[index])
+			}
+
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+
+		junitReport.TestSuites = append(junitReport.TestSuites, suite)
+	}
+
+	content, err = xml.MarshalIndent(junitReport, "  ", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+
+	glog.V(100).Infof("Writing JUnit report %s", reportPath)
+
+	return os.WriteFile(reportPath, redact.Bytes(append([]byte(xml.Header), content...)), 0666)
+}
+
+func classificationProperties(specReport types.SpecReport) *reporters.JUnitProperties {
+	classification := classify.Of(specReport)
+	if classification == nil {
+		return nil
+	}
+
+	return &reporters.JUnitProperties{Properties: []reporters.JUnitProperty{
+		{Name: "failure.category", Value: string(classification.Category)},
+		{Name: "failure.signature", Value: classification.Signature},
+		{Name: "failure.hint", Value: classification.Hint},
+		{Name: "failure.evidence", Value: classification.Evidence},
+	}}
+}
@@ -0,0 +1,139 @@
+package reporter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	nvidianetworkv1alpha1 "github.com/Mellanox/network-operator/api/v1alpha1"
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	multusNamespace    = "openshift-multus"
+	rdmaResourcePrefix = "rdma/"
+)
+
+var (
+	networkAttachmentDefinitionsGVR = schema.GroupVersionResource{
+		Group: "k8s.cni.cncf.io", Version: "v1", Resource: "network-attachment-definitions"}
+	whereaboutsIPPoolsGVR = schema.GroupVersionResource{
+		Group: "whereabouts.cni.cncf.io", Version: "v1alpha1", Resource: "ippools"}
+	whereaboutsReservationsGVR = schema.GroupVersionResource{
+		Group: "whereabouts.cni.cncf.io", Version: "v1alpha1", Resource: "overlappingrangeipreservations"}
+)
+
+// rdmaNode is the NIC and RDMA state of a node.
+type rdmaNode struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Capacity    map[string]string `json:"capacity,omitempty"`
+	Allocatable map[string]string `json:"allocatable,omitempty"`
+}
+
+// GatherNetworkOperator collects the state of the NVIDIA Network Operator installed in namespace into
+// artifactDir, then compresses it into artifactDir.tar.gz:
+//
+//	cluster/      NicClusterPolicies, MacvlanNetworks, HostDeviceNetworks, IPoIBNetworks,
+//	              NetworkAttachmentDefinitions and the NIC labels and RDMA resources of the nics inventory nodes
+//	namespace/    operand DaemonSets, pods, container logs (OFED driver included) and events of the operator namespace
+//	multus/       Multus DaemonSets and pods, whereabouts IP pools and reservations
+//	olm/          ClusterServiceVersions, Subscriptions and InstallPlans of the operator namespace
+//
+// Collection goes on after a failure; all the failures are returned at once.
+func GatherNetworkOperator(ctx context.Context, apiClient *clients.Settings, artifactDir, namespace string,
+	nics InventorySelector) error {
+	glog.V(100).Infof("Gathering Network Operator state into %s", artifactDir)
+
+	gather, err := newGatherer(ctx, apiClient, artifactDir)
+	if err != nil {
+		return err
+	}
+
+	gather.gatherList("cluster/nicclusterpolicies.yaml", &nvidianetworkv1alpha1.NicClusterPolicyList{})
+	gather.gatherList("cluster/macvlannetworks.yaml", &nvidianetworkv1alpha1.MacvlanNetworkList{})
+	gather.gatherList("cluster/hostdevicenetworks.yaml", &nvidianetworkv1alpha1.HostDeviceNetworkList{})
+	gather.gatherList("cluster/ipoibnetworks.yaml", &nvidianetworkv1alpha1.IPoIBNetworkList{})
+	gather.gatherResources("cluster/network-attachment-definitions.yaml", networkAttachmentDefinitionsGVR, "")
+	gather.gatherRDMANodes("cluster/nic-nodes.yaml", nics)
+
+	gather.gatherList("namespace/daemonsets.yaml", &appsv1.DaemonSetList{},
+		runtimeClient.InNamespace(namespace))
+	gather.gatherPods("namespace", namespace)
+	gather.gatherEvents("namespace/events.yaml", namespace)
+
+	gather.gatherList("multus/daemonsets.yaml", &appsv1.DaemonSetList{}, runtimeClient.InNamespace(multusNamespace))
+	gather.gatherList("multus/pods.yaml", &corev1.PodList{}, runtimeClient.InNamespace(multusNamespace))
+	gather.gatherResources("multus/whereabouts-ippools.yaml", whereaboutsIPPoolsGVR, "")
+	gather.gatherResources("multus/whereabouts-reservations.yaml", whereaboutsReservationsGVR, "")
+
+	gather.gatherOLM("olm", namespace)
+
+	gatherErr := gather.err()
+
+	archivePath, err := gather.compress()
+	if err != nil {
+		return err
+	}
+
+	glog.V(100).Infof("Network Operator state gathered into %s", archivePath)
+
+	return gatherErr
+}
+
+// gatherRDMANodes writes the NIC labels and the RDMA capacity and allocatable resources of the nodes of the
+// nics inventory.
+func (gather *gatherer) gatherRDMANodes(relPath string, nics InventorySelector) {
+	if gather.ctx.Err() != nil {
+		return
+	}
+
+	nodeList, err := gather.apiClient.CoreV1Interface.Nodes().List(gather.ctx,
+		metav1.ListOptions{LabelSelector: nics.NodeLabel})
+	if err != nil {
+		gather.fail(fmt.Errorf("failed to list nodes with label %s: %w", nics.NodeLabel, err))
+
+		return
+	}
+
+	rdmaNodes := map[string]rdmaNode{}
+
+	for _, node := range nodeList.Items {
+		state := rdmaNode{
+			Labels:      map[string]string{},
+			Capacity:    rdmaResources(node.Status.Capacity),
+			Allocatable: rdmaResources(node.Status.Allocatable),
+		}
+
+		for key, value := range node.Labels {
+			for _, prefix := range nics.LabelPrefixes {
+				if strings.HasPrefix(key, prefix) {
+					state.Labels[key] = value
+
+					break
+				}
+			}
+		}
+
+		rdmaNodes[node.Name] = state
+	}
+
+	gather.writeYAML(relPath, rdmaNodes)
+}
+
+func rdmaResources(resources corev1.ResourceList) map[string]string {
+	rdma := map[string]string{}
+
+	for name, quantity := range resources {
+		if strings.HasPrefix(string(name), rdmaResourcePrefix) {
+			rdma[string(name)] = quantity.String()
+		}
+	}
+
+	return rdma
+}
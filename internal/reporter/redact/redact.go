@@ -0,0 +1,231 @@
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Placeholder replaces every redacted value.
+const Placeholder = "REDACTED"
+
+// sensitiveName matches the names of keys and environment variables holding credentials.
+const sensitiveName = `(?:password|passwd|token|secret|api[_-]?key|credentials?)`
+
+// builtinPatterns match the known sensitive values found in the artifacts. When a pattern has capturing
+// groups, only the groups are redacted, otherwise the whole match is.
+var builtinPatterns = []string{
+	// Private keys and certificates, e.g. entitlement certificates, in PEM or base64 encoded PEM.
+	`-----BEGIN [A-Z0-9 ]+-----[\s\S]*?-----END [A-Z0-9 ]+-----`,
+	`LS0tLS1CRUdJTi[A-Za-z0-9+/=]*`,
+	// Credentials embedded in URLs, e.g. in repository configs.
+	`[a-zA-Z][a-zA-Z0-9+.-]*://([^/\s:@"']+:[^/\s@"']+)@`,
+	// Bearer tokens.
+	`(?i)bearer\s+([A-Za-z0-9\-._~+/]+=*)`,
+	// Pull secrets.
+	`"auth"\s*:\s*"([^"]+)"`,
+	`(?i)"?\.docker(?:configjson|cfg)"?\s*:\s*"?([A-Za-z0-9+/=]{8,})`,
+	// JSON and YAML keys ending with a sensitive name.
+	`(?i)"[^"]*` + sensitiveName + `"\s*:\s*"([^"]*)"`,
+	`(?im)^[\s-]*[A-Za-z0-9_.-]*` + sensitiveName + `\s*:[ \t]*([^\s{\[][^\n]*)`,
+	// Environment variables with a sensitive name, as JSON, YAML and shell assignments.
+	`(?i)"name"\s*:\s*"[^"]*` + sensitiveName + `[^"]*"\s*,\s*"value"\s*:\s*"([^"]*)"`,
+	`(?i)name:[ \t]*"?[A-Za-z0-9_.-]*` + sensitiveName + `[A-Za-z0-9_.-]*"?[ \t]*\n[ \t]*value:[ \t]*([^\n]+)`,
+	`(?i)\b[A-Z0-9_]*` + sensitiveName + `[A-Z0-9_]*=([^\s"',]+)`,
+}
+
+// Redactor removes the credentials from the content written into the report directory.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+var (
+	activeMutex sync.RWMutex
+	active      = mustNew(nil)
+)
+
+// New returns a Redactor applying the built-in patterns and extraPatterns, regular expressions following the
+// same capturing group convention as the built-in ones.
+func New(extraPatterns []string) (*Redactor, error) {
+	redactor := &Redactor{}
+
+	for _, pattern := range append(append([]string{}, builtinPatterns...), extraPatterns...) {
+		if strings.TrimSpace(pattern) == "" {
+			continue
+		}
+
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+
+		redactor.patterns = append(redactor.patterns, compiled)
+	}
+
+	return redactor, nil
+}
+
+// Bytes returns content without its sensitive values. Content holding a JSON or YAML document also has the
+// data of its Secrets redacted.
+func (redactor *Redactor) Bytes(content []byte) []byte {
+	content = redactSecrets(content)
+
+	for _, pattern := range redactor.patterns {
+		content = redactMatches(pattern, content)
+	}
+
+	return content
+}
+
+// String returns value without its sensitive values.
+func (redactor *Redactor) String(value string) string {
+	return string(redactor.Bytes([]byte(value)))
+}
+
+// SetActive sets the Redactor used by the package level functions.
+func SetActive(redactor *Redactor) {
+	activeMutex.Lock()
+	defer activeMutex.Unlock()
+
+	active = redactor
+}
+
+// Active returns the Redactor used by the package level functions.
+func Active() *Redactor {
+	activeMutex.RLock()
+	defer activeMutex.RUnlock()
+
+	return active
+}
+
+// Bytes returns content without its sensitive values, using the active Redactor.
+func Bytes(content []byte) []byte {
+	return Active().Bytes(content)
+}
+
+// String returns value without its sensitive values, using the active Redactor.
+func String(value string) string {
+	return Active().String(value)
+}
+
+func mustNew(extraPatterns []string) *Redactor {
+	redactor, err := New(extraPatterns)
+	if err != nil {
+		panic(err)
+	}
+
+	return redactor
+}
+
+func redactMatches(pattern *regexp.Regexp, content []byte) []byte {
+	matches := pattern.FindAllSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return content
+	}
+
+	var (
+		redacted []byte
+		last     int
+	)
+
+	for _, match := range matches {
+		spans := [][2]int{{match[0], match[1]}}
+
+		if len(match) > 2 {
+			spans = spans[:0]
+
+			for group := 2; group+1 < len(match); group += 2 {
+				if match[group] >= 0 {
+					spans = append(spans, [2]int{match[group], match[group+1]})
+				}
+			}
+		}
+
+		for _, span := range spans {
+			if span[0] < last {
+				continue
+			}
+
+			redacted = append(redacted, content[last:span[0]]...)
+			redacted = append(redacted, Placeholder...)
+			last = span[1]
+		}
+	}
+
+	return append(redacted, content[last:]...)
+}
+
+// redactSecrets replaces the data of the Secrets of a JSON or YAML document. Content that is not a single
+// document, or without any Secret, is returned unchanged.
+func redactSecrets(content []byte) []byte {
+	if !strings.Contains(string(content), "Secret") {
+		return content
+	}
+
+	isJSON := json.Valid(content)
+
+	var document interface{}
+
+	if isJSON {
+		if err := json.Unmarshal(content, &document); err != nil {
+			return content
+		}
+	} else if err := yaml.Unmarshal(content, &document); err != nil {
+		return content
+	}
+
+	if !redactSecretData(document) {
+		return content
+	}
+
+	var (
+		redacted []byte
+		err      error
+	)
+
+	if isJSON {
+		redacted, err = json.MarshalIndent(document, "", "  ")
+	} else {
+		redacted, err = yaml.Marshal(document)
+	}
+
+	if err != nil {
+		return content
+	}
+
+	return redacted
+}
+
+// redactSecretData replaces the values of the data and stringData of every Secret found in value, and
+// reports whether any was replaced.
+func redactSecretData(value interface{}) bool {
+	redacted := false
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		if typed["kind"] == "Secret" {
+			for _, field := range []string{"data", "stringData"} {
+				if data, ok := typed[field].(map[string]interface{}); ok {
+					for key := range data {
+						data[key] = Placeholder
+						redacted = true
+					}
+				}
+			}
+		}
+
+		for _, child := range typed {
+			redacted = redactSecretData(child) || redacted
+		}
+	case []interface{}:
+		for _, child := range typed {
+			redacted = redactSecretData(child) || redacted
+		}
+	}
+
+	return redacted
+}
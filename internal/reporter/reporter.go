@@ -5,22 +5,27 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
-	"os/exec"
 	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/openshift-kni/k8sreporter"
 
 	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/ginkgo/v2/types"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/classify"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/redact"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/pod"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-var (
-	pathToPodExecLogs = "/tmp/pod_exec_logs.log"
+const (
+	podExecLogsFileName = "pod_exec_logs.log"
 )
 
 func newReporter(
@@ -50,21 +55,51 @@ func newReporter(
 	return res, nil
 }
 
-// ReportIfFailed dumps requested cluster CRs if TC is failed to the given directory.
+// ReportIfFailed classifies the failure of TC and dumps requested cluster CRs if TC is failed to the given
+// directory, along with the events timeline recorded since StartEventTimeline and the pod exec records of the TC.
+// The pod exec records are discarded either way, so that each TC starts with an empty exec log.
 func ReportIfFailed(
 	report types.SpecReport,
 	testSuite string,
 	nSpaces map[string]string,
 	cRDs []k8sreporter.CRData,
 	apiScheme func(scheme *runtime.Scheme) error) {
+	timelineEvents := stopEventTimeline()
+
+	defer func() {
+		if err := removeFile(pod.ExecLogPath); err != nil {
+			glog.Fatalf(err.Error())
+		}
+	}()
+
 	if !types.SpecStateFailureStates.Is(report.State) {
 		return
 	}
 
+	eventEvidences := make([]classify.Evidence, 0, len(timelineEvents))
+	for _, event := range timelineEvents {
+		eventEvidences = append(eventEvidences, classify.EventEvidence(event.String(), event.Object))
+	}
+
+	classification := classify.Failure(report, inittools.APIClient, nSpaces, eventEvidences)
+	glog.V(100).Infof("Failure classified as %s", classification)
+	ginkgo.AddReportEntry(classify.ReportEntryName, classification, types.ReportEntryVisibilityFailureOrVerbose)
+
 	dumpDir := inittools.GeneralConfig.GetDumpFailedTestReportLocation(testSuite)
 
 	if dumpDir != "" {
-		reporter, err := newReporter(dumpDir, nSpaces, apiScheme, cRDs)
+		// The cluster state is dumped into a staging directory, then redacted into the report directory, so
+		// that no credential is ever written into the report directory.
+		stagingDir, err := os.MkdirTemp("", "k8sreporter-")
+		if err != nil {
+			glog.Fatalf("Failed to create log reporter staging directory due to %s", err)
+		}
+
+		defer func() {
+			_ = os.RemoveAll(stagingDir)
+		}()
+
+		reporter, err := newReporter(stagingDir, nSpaces, apiScheme, cRDs)
 
 		if err != nil {
 			glog.Fatalf("Failed to create log reporter due to %s", err)
@@ -73,20 +108,21 @@ func ReportIfFailed(
 		tcReportFolderName := strings.ReplaceAll(report.FullText(), " ", "_")
 		reporter.Dump(report.RunTime, tcReportFolderName)
 
-		_, podExecLogsFName := path.Split(pathToPodExecLogs)
+		err = copyRedacted(path.Join(stagingDir, tcReportFolderName), path.Join(dumpDir, tcReportFolderName))
+		if err != nil {
+			glog.Fatalf("Failed to copy log reporter dump to report folder: %s", err)
+		}
+
+		if err := writeEventTimeline(path.Join(dumpDir, tcReportFolderName), timelineEvents); err != nil {
+			glog.Errorf("Failed to write events timeline: %v", err)
+		}
 
-		err = moveFile(
-			pathToPodExecLogs, path.Join(inittools.GeneralConfig.ReportsDirAbsPath, tcReportFolderName, podExecLogsFName))
+		err = moveFile(pod.ExecLogPath, path.Join(dumpDir, tcReportFolderName, podExecLogsFileName))
 
 		if err != nil {
-			glog.Fatalf("Failed to move pod exec logs %s to report folder: %s", pathToPodExecLogs, err)
+			glog.Fatalf("Failed to move pod exec logs %s to report folder: %s", pod.ExecLogPath, err)
 		}
 	}
-
-	err := removeFile(pathToPodExecLogs)
-	if err != nil {
-		glog.Fatalf(err.Error())
-	}
 }
 
 func moveFile(sourcePath, destPath string) error {
@@ -114,7 +150,12 @@ func moveFile(sourcePath, destPath string) error {
 		_ = outputFile.Close()
 	}()
 
-	_, err = io.Copy(outputFile, inputFile)
+	content, err := io.ReadAll(inputFile)
+	if err != nil {
+		return fmt.Errorf("reading from source file failed: %w", err)
+	}
+
+	_, err = outputFile.Write(redact.Bytes(content))
 
 	if err != nil {
 		return fmt.Errorf("writing to output file failed: %w", err)
@@ -123,6 +164,33 @@ func moveFile(sourcePath, destPath string) error {
 	return nil
 }
 
+// copyRedacted copies the files of the sourceDir tree into destDir, redacting their content.
+func copyRedacted(sourceDir, destDir string) error {
+	return filepath.WalkDir(sourceDir, func(sourcePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(sourceDir, sourcePath)
+		if err != nil {
+			return err
+		}
+
+		destPath := filepath.Join(destDir, relPath)
+
+		if entry.IsDir() {
+			return os.MkdirAll(destPath, 0755)
+		}
+
+		content, err := os.ReadFile(sourcePath)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(destPath, redact.Bytes(content), 0644)
+	})
+}
+
 func removeFile(fPath string) error {
 	if _, err := os.Stat(fPath); err == nil {
 		err := os.Remove(fPath)
@@ -134,32 +202,28 @@ func removeFile(fPath string) error {
 	return nil
 }
 
+// MustGatherIfFailed gathers the GPU Operator state into artifactDir if TC is failed, within timeout.
 func MustGatherIfFailed(specReport types.SpecReport, artifactDir string, timeout time.Duration) error {
 	if !types.SpecStateFailureStates.Is(specReport.State) {
 		return nil
 	}
-	if artifactDir == "" {
-		return fmt.Errorf("artifact directory cannot be empty")
-	}
 
-	mustGatherScriptPath := os.Getenv("PATH_TO_MUST_GATHER_SCRIPT")
-	if mustGatherScriptPath == "" {
-		return fmt.Errorf("PATH_TO_MUST_GATHER_SCRIPT environment variable is not set")
-	}
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, mustGatherScriptPath)
-	cmd.Env = append(os.Environ(), fmt.Sprintf("ARTIFACT_DIR=%s", artifactDir))
-	output, err := cmd.CombinedOutput()
-	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-		glog.Errorf("%s script timed out: %v", mustGatherScriptPath, ctx.Err())
-		return fmt.Errorf("must gather script timed out: %w", ctx.Err())
-	}
-	if err != nil {
-		glog.Errorf("Error running %s script: %v\nOutput: %s", mustGatherScriptPath, err, output)
-		return fmt.Errorf("error running must gather script: %w", err)
+	return GatherGPUOperator(ctx, inittools.APIClient, artifactDir)
+}
+
+// NetworkMustGatherIfFailed gathers the state of the Network Operator installed in namespace, with the nodes
+// of the nics inventory, into artifactDir if TC is failed, within timeout.
+func NetworkMustGatherIfFailed(specReport types.SpecReport, artifactDir, namespace string, nics InventorySelector,
+	timeout time.Duration) error {
+	if !types.SpecStateFailureStates.Is(specReport.State) {
+		return nil
 	}
-	glog.V(100).Infof("must gather script output: %s", output)
-	return nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return GatherNetworkOperator(ctx, inittools.APIClient, artifactDir, namespace, nics)
 }
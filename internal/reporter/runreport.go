@@ -0,0 +1,300 @@
+package reporter
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/classify"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/redact"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nodes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RunReport is the structured report of a suite run, written as JSON next to the JUnit report.
+type RunReport struct {
+	Suite                 string                        `json:"suite"`
+	StartTime             time.Time                     `json:"startTime"`
+	EndTime               time.Time                     `json:"endTime"`
+	DurationSeconds       float64                       `json:"durationSeconds"`
+	SuccessfullyCompleted bool                          `json:"successfullyCompleted"`
+	OpenShiftVersion      string                        `json:"openshiftVersion,omitempty"`
+	ClusterArchitecture   string                        `json:"clusterArchitecture,omitempty"`
+	Operators             []OperatorVersion             `json:"operators,omitempty"`
+	Inventory             map[string][]InventoryNode    `json:"inventory,omitempty"`
+	Config                map[string]interface{}        `json:"config,omitempty"`
+	APIRetries            map[string]clients.RetryStats `json:"apiRetries,omitempty"`
+	Specs                 []SpecResult                  `json:"specs"`
+}
+
+// OperatorVersion is an operator CSV deployed during the run.
+type OperatorVersion struct {
+	Name    string `json:"name"`
+	CSV     string `json:"csv"`
+	Version string `json:"version"`
+}
+
+// InventoryNode is a node holding hardware of interest, with its hardware related labels.
+type InventoryNode struct {
+	Name         string            `json:"name"`
+	Architecture string            `json:"architecture"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+// SpecResult is the outcome of a single spec with its By steps timeline.
+type SpecResult struct {
+	Name            string       `json:"name"`
+	Labels          []string     `json:"labels,omitempty"`
+	State           string       `json:"state"`
+	StartTime       time.Time    `json:"startTime"`
+	DurationSeconds float64      `json:"durationSeconds"`
+	Failure         *SpecFailure `json:"failure,omitempty"`
+	Steps           []SpecStep   `json:"steps,omitempty"`
+}
+
+// SpecFailure describes why a spec failed.
+type SpecFailure struct {
+	Message        string                   `json:"message"`
+	Location       string                   `json:"location"`
+	Classification *classify.Classification `json:"classification,omitempty"`
+}
+
+// SpecStep is a By step of a spec.
+type SpecStep struct {
+	Text            string    `json:"text"`
+	StartTime       time.Time `json:"startTime"`
+	DurationSeconds float64   `json:"durationSeconds"`
+}
+
+// InventorySelector selects the nodes and labels of a hardware inventory in the run report.
+type InventorySelector struct {
+	// Name is the inventory name in the run report, e.g. "gpu".
+	Name string
+	// NodeLabel is the label selector of the nodes holding the hardware.
+	NodeLabel string
+	// LabelPrefixes are the prefixes of the node labels describing the hardware.
+	LabelPrefixes []string
+}
+
+var (
+	runFactsMutex sync.Mutex
+	runFacts      = RunReport{Config: map[string]interface{}{}}
+)
+
+// RecordOpenShiftVersion records the OpenShift version of the cluster in the run report.
+func RecordOpenShiftVersion(version string) {
+	runFactsMutex.Lock()
+	defer runFactsMutex.Unlock()
+
+	runFacts.OpenShiftVersion = version
+}
+
+// RecordClusterArchitecture records the architecture of the cluster worker nodes in the run report.
+func RecordClusterArchitecture(architecture string) {
+	runFactsMutex.Lock()
+	defer runFactsMutex.Unlock()
+
+	runFacts.ClusterArchitecture = architecture
+}
+
+// RecordOperatorVersion records the CSV of an operator deployed during the run in the run report.
+// Recording the same operator again replaces its previous CSV, e.g. after an upgrade.
+func RecordOperatorVersion(name, csv, version string) {
+	runFactsMutex.Lock()
+	defer runFactsMutex.Unlock()
+
+	for index, operator := range runFacts.Operators {
+		if operator.Name == name {
+			runFacts.Operators[index] = OperatorVersion{Name: name, CSV: csv, Version: version}
+
+			return
+		}
+	}
+
+	runFacts.Operators = append(runFacts.Operators, OperatorVersion{Name: name, CSV: csv, Version: version})
+}
+
+// RecordConfig records a configuration used by the run under the given name in the run report.
+func RecordConfig(name string, config interface{}) {
+	runFactsMutex.Lock()
+	defer runFactsMutex.Unlock()
+
+	runFacts.Config[name] = config
+}
+
+// WriteRunReport writes the JSON run report of report, the recorded facts and the inventories collected
+// from the cluster next to the JUnit report of testSuite.
+func WriteRunReport(report types.Report, testSuite string, inventories ...InventorySelector) error {
+	runFactsMutex.Lock()
+	runReport := runFacts
+	runReport.Operators = append([]OperatorVersion{}, runFacts.Operators...)
+	runReport.Config = map[string]interface{}{}
+
+	for name, config := range runFacts.Config {
+		runReport.Config[name] = config
+	}
+	runFactsMutex.Unlock()
+
+	runReport.Suite = report.SuiteDescription
+	runReport.StartTime = report.StartTime
+	runReport.EndTime = report.EndTime
+	runReport.DurationSeconds = report.RunTime.Seconds()
+	runReport.SuccessfullyCompleted = report.SuiteSucceeded
+	runReport.Config["general"] = inittools.GeneralConfig
+	runReport.Config["timeouts"] = effectiveTimeouts()
+	runReport.Inventory = collectInventories(inventories)
+	runReport.APIRetries = collectRetryStats()
+	runReport.Specs = []SpecResult{}
+
+	for _, specReport := range report.SpecReports {
+		if specReport.LeafNodeType != types.NodeTypeIt && !specReport.Failed() {
+			continue
+		}
+
+		runReport.Specs = append(runReport.Specs, newSpecResult(specReport))
+	}
+
+	content, err := json.MarshalIndent(runReport, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	reportPath := inittools.GeneralConfig.GetRunReportPath(testSuite)
+	glog.V(100).Infof("Writing run report %s", reportPath)
+
+	return os.WriteFile(reportPath, redact.Bytes(content), 0666)
+}
+
+func newSpecResult(specReport types.SpecReport) SpecResult {
+	name := specReport.FullText()
+	if name == "" {
+		name = specReport.LeafNodeType.String()
+	}
+
+	specResult := SpecResult{
+		Name:            name,
+		Labels:          specReport.Labels(),
+		State:           specReport.State.String(),
+		StartTime:       specReport.StartTime,
+		DurationSeconds: specReport.RunTime.Seconds(),
+	}
+
+	if specReport.Failed() {
+		specResult.Failure = &SpecFailure{
+			Message:        specReport.Failure.Message,
+			Location:       specReport.Failure.Location.String(),
+			Classification: classify.Of(specReport),
+		}
+	}
+
+	// By steps without a callback have no end event: they last until the next step, the end of their node
+	// or the end of the spec.
+	openStep := -1
+	closeStep := func(end time.Time) {
+		if openStep >= 0 && !end.IsZero() {
+			specResult.Steps[openStep].DurationSeconds = end.Sub(specResult.Steps[openStep].StartTime).Seconds()
+		}
+
+		openStep = -1
+	}
+
+	for _, event := range specReport.SpecEvents {
+		switch event.SpecEventType {
+		case types.SpecEventByStart:
+			closeStep(event.TimelineLocation.Time)
+
+			specResult.Steps = append(specResult.Steps, SpecStep{
+				Text:      event.Message,
+				StartTime: event.TimelineLocation.Time,
+			})
+			openStep = len(specResult.Steps) - 1
+		case types.SpecEventByEnd, types.SpecEventNodeEnd:
+			closeStep(event.TimelineLocation.Time)
+		}
+	}
+
+	closeStep(specReport.EndTime)
+
+	return specResult
+}
+
+func collectInventories(inventories []InventorySelector) map[string][]InventoryNode {
+	collected := map[string][]InventoryNode{}
+
+	for _, inventory := range inventories {
+		nodeBuilders, err := nodes.List(inittools.APIClient, metav1.ListOptions{LabelSelector: inventory.NodeLabel})
+		if err != nil {
+			glog.V(100).Infof("Failed to collect %s inventory: %v", inventory.Name, err)
+
+			continue
+		}
+
+		inventoryNodes := []InventoryNode{}
+
+		for _, nodeBuilder := range nodeBuilders {
+			inventoryNode := InventoryNode{
+				Name:         nodeBuilder.Object.Name,
+				Architecture: nodeBuilder.Object.Status.NodeInfo.Architecture,
+				Labels:       map[string]string{},
+			}
+
+			for key, value := range nodeBuilder.Object.Labels {
+				for _, prefix := range inventory.LabelPrefixes {
+					if strings.HasPrefix(key, prefix) {
+						inventoryNode.Labels[key] = value
+
+						break
+					}
+				}
+			}
+
+			inventoryNodes = append(inventoryNodes, inventoryNode)
+		}
+
+		sort.Slice(inventoryNodes, func(i, j int) bool { return inventoryNodes[i].Name < inventoryNodes[j].Name })
+
+		collected[inventory.Name] = inventoryNodes
+	}
+
+	return collected
+}
+
+// collectRetryStats returns the transient API errors retried so far on every registered cluster.
+func collectRetryStats() map[string]clients.RetryStats {
+	retries := map[string]clients.RetryStats{}
+
+	for _, name := range inittools.Clusters.Names() {
+		apiClient, err := inittools.Clusters.Get(name)
+		if err != nil {
+			glog.V(100).Infof("Failed to collect API retries of cluster %s: %v", name, err)
+
+			continue
+		}
+
+		retries[name] = apiClient.GetRetryStats()
+	}
+
+	return retries
+}
+
+func effectiveTimeouts() map[string]interface{} {
+	values := map[timeouts.Key]string{}
+
+	for _, key := range timeouts.Keys() {
+		values[key] = timeouts.Get(key).String()
+	}
+
+	return map[string]interface{}{
+		"profile":    timeouts.Active().Profile,
+		"multiplier": timeouts.Active().Multiplier,
+		"values":     values,
+	}
+}
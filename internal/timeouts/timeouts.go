@@ -0,0 +1,301 @@
+package timeouts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	yaml "sigs.k8s.io/yaml/goyaml.v2"
+)
+
+// Key identifies a timeout, delay or poll interval used by the test suites.
+type Key string
+
+const (
+	// ProfileDefault is the timeout profile matching the usual cloud clusters.
+	ProfileDefault = "default"
+	// ProfileSlow doubles every timeout and delay for slow bare-metal or ARM clusters.
+	ProfileSlow = "slow"
+	// ProfileSNO extends the timeouts of the operands rollout for single node clusters.
+	ProfileSNO = "sno"
+
+	// ReportFile is the report file the effective timeouts are written to.
+	ReportFile = "timeouts.yaml"
+)
+
+// Keys of the timeouts, delays and poll intervals of the GPU and NNO suites. Poll interval keys end with
+// "_interval".
+const (
+	CatalogSourceReadyTimeout           Key = "catalogsource_ready_timeout"
+	DeletionPollInterval                Key = "deletion_poll_interval"
+	DeletionTimeout                     Key = "deletion_timeout"
+	MachineReadyTimeout                 Key = "machine_ready_timeout"
+	NodeLabelingCheckInterval           Key = "node_labeling_check_interval"
+	NodeLabelingTimeout                 Key = "node_labeling_timeout"
+	PackageManifestCheckInterval        Key = "packagemanifest_check_interval"
+	PackageManifestTimeout              Key = "packagemanifest_timeout"
+	BundleDeploymentTimeout             Key = "bundle_deployment_timeout"
+	DeploymentCreationCheckInterval     Key = "deployment_creation_check_interval"
+	DeploymentCreationTimeout           Key = "deployment_creation_timeout"
+	OperatorDeploymentReadyTimeout      Key = "operator_deployment_ready_timeout"
+	CSVSucceededCheckInterval           Key = "csv_succeeded_check_interval"
+	CSVSucceededTimeout                 Key = "csv_succeeded_timeout"
+	SubscriptionResolutionCheckInterval Key = "subscription_resolution_check_interval"
+	SubscriptionResolutionTimeout       Key = "subscription_resolution_timeout"
+	InstallPlanCompleteCheckInterval    Key = "installplan_complete_check_interval"
+	InstallPlanCompleteTimeout          Key = "installplan_complete_timeout"
+	SubscriptionUpgradeCheckInterval    Key = "subscription_upgrade_check_interval"
+	SubscriptionUpgradeTimeout          Key = "subscription_upgrade_timeout"
+	OLMFailureGracePeriod               Key = "olm_failure_grace_period"
+	MustGatherTimeout                   Key = "must_gather_timeout"
+
+	ClusterCatalogServingCheckInterval     Key = "clustercatalog_serving_check_interval"
+	ClusterCatalogServingTimeout           Key = "clustercatalog_serving_timeout"
+	ClusterExtensionInstalledCheckInterval Key = "clusterextension_installed_check_interval"
+	ClusterExtensionInstalledTimeout       Key = "clusterextension_installed_timeout"
+
+	ClusterPolicyReadyCheckInterval   Key = "clusterpolicy_ready_check_interval"
+	ClusterPolicyReadyTimeout         Key = "clusterpolicy_ready_timeout"
+	ClusterPolicyUpgradeReadyTimeout  Key = "clusterpolicy_upgrade_ready_timeout"
+	BurnPodCreationTimeout            Key = "burn_pod_creation_timeout"
+	BurnPodRunningTimeout             Key = "burn_pod_running_timeout"
+	BurnPodSuccessTimeout             Key = "burn_pod_success_timeout"
+	BurnLogCollectionPeriod           Key = "burn_log_collection_period"
+	BurnPodPostUpgradeCreationTimeout Key = "burn_pod_post_upgrade_creation_timeout"
+	RedeployedBurnPodRunningTimeout   Key = "redeployed_burn_pod_running_timeout"
+	RedeployedBurnPodSuccessTimeout   Key = "redeployed_burn_pod_success_timeout"
+	RedeployedBurnLogCollectionPeriod Key = "redeployed_burn_log_collection_period"
+
+	NicClusterPolicyReadyCheckInterval Key = "nicclusterpolicy_ready_check_interval"
+	NicClusterPolicyReadyTimeout       Key = "nicclusterpolicy_ready_timeout"
+	MacvlanNetworkReadyCheckInterval   Key = "macvlannetwork_ready_check_interval"
+	MacvlanNetworkReadyTimeout         Key = "macvlannetwork_ready_timeout"
+	RdmaServerRunningCheckInterval     Key = "rdma_server_running_check_interval"
+	RdmaServerRunningTimeout           Key = "rdma_server_running_timeout"
+	RdmaClientCompletedCheckInterval   Key = "rdma_client_completed_check_interval"
+	RdmaClientCompletedTimeout         Key = "rdma_client_completed_timeout"
+)
+
+// defaultProfile holds the value of every Key in the default profile.
+var defaultProfile = map[Key]time.Duration{
+	CatalogSourceReadyTimeout:           4 * time.Minute,
+	DeletionPollInterval:                30 * time.Second,
+	DeletionTimeout:                     5 * time.Minute,
+	MachineReadyTimeout:                 15 * time.Minute,
+	NodeLabelingCheckInterval:           30 * time.Second,
+	NodeLabelingTimeout:                 10 * time.Minute,
+	PackageManifestCheckInterval:        30 * time.Second,
+	PackageManifestTimeout:              5 * time.Minute,
+	BundleDeploymentTimeout:             5 * time.Minute,
+	DeploymentCreationCheckInterval:     30 * time.Second,
+	DeploymentCreationTimeout:           4 * time.Minute,
+	OperatorDeploymentReadyTimeout:      4 * time.Minute,
+	CSVSucceededCheckInterval:           60 * time.Second,
+	CSVSucceededTimeout:                 5 * time.Minute,
+	SubscriptionResolutionCheckInterval: 15 * time.Second,
+	SubscriptionResolutionTimeout:       5 * time.Minute,
+	InstallPlanCompleteCheckInterval:    15 * time.Second,
+	InstallPlanCompleteTimeout:          5 * time.Minute,
+	SubscriptionUpgradeCheckInterval:    30 * time.Second,
+	SubscriptionUpgradeTimeout:          10 * time.Minute,
+	OLMFailureGracePeriod:               2 * time.Minute,
+	MustGatherTimeout:                   5 * time.Minute,
+
+	ClusterCatalogServingCheckInterval:     15 * time.Second,
+	ClusterCatalogServingTimeout:           5 * time.Minute,
+	ClusterExtensionInstalledCheckInterval: 15 * time.Second,
+	ClusterExtensionInstalledTimeout:       10 * time.Minute,
+
+	ClusterPolicyReadyCheckInterval:   60 * time.Second,
+	ClusterPolicyReadyTimeout:         12 * time.Minute,
+	ClusterPolicyUpgradeReadyTimeout:  15 * time.Minute,
+	BurnPodCreationTimeout:            5 * time.Minute,
+	BurnPodRunningTimeout:             3 * time.Minute,
+	BurnPodSuccessTimeout:             8 * time.Minute,
+	BurnLogCollectionPeriod:           500 * time.Second,
+	BurnPodPostUpgradeCreationTimeout: 5 * time.Minute,
+	RedeployedBurnPodRunningTimeout:   3 * time.Minute,
+	RedeployedBurnPodSuccessTimeout:   8 * time.Minute,
+	RedeployedBurnLogCollectionPeriod: 500 * time.Second,
+
+	NicClusterPolicyReadyCheckInterval: 60 * time.Second,
+	NicClusterPolicyReadyTimeout:       24 * time.Minute,
+	MacvlanNetworkReadyCheckInterval:   60 * time.Second,
+	MacvlanNetworkReadyTimeout:         5 * time.Minute,
+	RdmaServerRunningCheckInterval:     10 * time.Second,
+	RdmaServerRunningTimeout:           4 * time.Minute,
+	RdmaClientCompletedCheckInterval:   10 * time.Second,
+	RdmaClientCompletedTimeout:         7 * time.Minute,
+}
+
+// profiles holds, for every profile, the values overriding the default profile.
+var profiles = map[string]map[Key]time.Duration{
+	ProfileDefault: {},
+	ProfileSlow:    scaledProfile(2),
+	ProfileSNO: {
+		CatalogSourceReadyTimeout:        8 * time.Minute,
+		DeploymentCreationTimeout:        8 * time.Minute,
+		OperatorDeploymentReadyTimeout:   8 * time.Minute,
+		CSVSucceededTimeout:              10 * time.Minute,
+		ClusterExtensionInstalledTimeout: 15 * time.Minute,
+		ClusterPolicyReadyTimeout:        20 * time.Minute,
+		ClusterPolicyUpgradeReadyTimeout: 25 * time.Minute,
+		NicClusterPolicyReadyTimeout:     30 * time.Minute,
+	},
+}
+
+// Timeouts resolves the value of every Key from a profile, a multiplier and per-key overrides.
+type Timeouts struct {
+	Profile    string
+	Multiplier float64
+	overrides  map[Key]time.Duration
+}
+
+var (
+	activeMutex sync.RWMutex
+	active      = &Timeouts{Profile: ProfileDefault, Multiplier: 1}
+)
+
+// New returns the Timeouts of profile, with every timeout and delay of the profile multiplied by multiplier,
+// then replaced by the values of overrides. Poll intervals are not multiplied. An empty profile selects
+// ProfileDefault.
+func New(profile string, multiplier float64, overrides map[string]string) (*Timeouts, error) {
+	if profile == "" {
+		profile = ProfileDefault
+	}
+
+	if _, ok := profiles[profile]; !ok {
+		return nil, fmt.Errorf("unknown timeout profile %q, expected one of %s", profile,
+			strings.Join(profileNames(), ", "))
+	}
+
+	if multiplier <= 0 {
+		return nil, fmt.Errorf("timeout multiplier must be > 0, got %v", multiplier)
+	}
+
+	timeouts := &Timeouts{Profile: profile, Multiplier: multiplier, overrides: map[Key]time.Duration{}}
+
+	for name, value := range overrides {
+		key := Key(strings.TrimSpace(name))
+		if _, ok := defaultProfile[key]; !ok {
+			return nil, fmt.Errorf("unknown timeout key %q", name)
+		}
+
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse timeout %s: %w", name, err)
+		}
+
+		if duration <= 0 {
+			return nil, fmt.Errorf("timeout %s must be > 0, got %s", name, duration)
+		}
+
+		timeouts.overrides[key] = duration
+	}
+
+	return timeouts, nil
+}
+
+// Get returns the effective value of key.
+func (timeouts *Timeouts) Get(key Key) time.Duration {
+	if duration, ok := timeouts.overrides[key]; ok {
+		return duration
+	}
+
+	duration, ok := profiles[timeouts.Profile][key]
+	if !ok {
+		duration = defaultProfile[key]
+	}
+
+	if isPollInterval(key) {
+		return duration
+	}
+
+	return time.Duration(float64(duration) * timeouts.Multiplier)
+}
+
+// Report returns the profile, multiplier and effective value of every Key as YAML.
+func (timeouts *Timeouts) Report() ([]byte, error) {
+	values := yaml.MapSlice{}
+
+	for _, key := range Keys() {
+		values = append(values, yaml.MapItem{Key: string(key), Value: timeouts.Get(key).String()})
+	}
+
+	return yaml.Marshal(yaml.MapSlice{
+		{Key: "profile", Value: timeouts.Profile},
+		{Key: "multiplier", Value: timeouts.Multiplier},
+		{Key: "timeouts", Value: values},
+	})
+}
+
+// Keys returns every Key sorted by name.
+func Keys() []Key {
+	keys := make([]Key, 0, len(defaultProfile))
+	for key := range defaultProfile {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	return keys
+}
+
+// SetActive makes timeouts the Timeouts returned by Active and Get.
+func SetActive(timeouts *Timeouts) {
+	activeMutex.Lock()
+	defer activeMutex.Unlock()
+
+	active = timeouts
+}
+
+// Active returns the Timeouts consulted by the test suites.
+func Active() *Timeouts {
+	activeMutex.RLock()
+	defer activeMutex.RUnlock()
+
+	return active
+}
+
+// Get returns the effective value of key in the active Timeouts.
+func Get(key Key) time.Duration {
+	return Active().Get(key)
+}
+
+// OrDefault returns value when it is set, otherwise the effective value of key in the active Timeouts.
+func OrDefault(value time.Duration, key Key) time.Duration {
+	if value > 0 {
+		return value
+	}
+
+	return Get(key)
+}
+
+func scaledProfile(factor time.Duration) map[Key]time.Duration {
+	scaled := map[Key]time.Duration{}
+
+	for key, duration := range defaultProfile {
+		if !isPollInterval(key) {
+			scaled[key] = duration * factor
+		}
+	}
+
+	return scaled
+}
+
+func isPollInterval(key Key) bool {
+	return strings.HasSuffix(string(key), "_interval")
+}
+
+func profileNames() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -7,4 +7,6 @@ const (
 	GPUTestNamespace = "test-gpu-burn"
 	// NetworkLabelSuite represents Netowrk Operator  label that can be used for test cases selection.
 	NetworkLabelSuite = "nno"
+	// NetworkOperatorNamespace represents the namespace of the Network Operator and its operands.
+	NetworkOperatorNamespace = "nvidia-network-operator"
 )
@@ -4,6 +4,7 @@ import (
 	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	"github.com/openshift-kni/k8sreporter"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter"
 )
 
 var (
@@ -21,4 +22,11 @@ var (
 	ReporterCRDsToDump = []k8sreporter.CRData{
 		{Cr: &nvidiagpuv1.ClusterPolicyList{}},
 	}
+
+	// GPUInventory tells to the run report how to collect the GPU inventory.
+	GPUInventory = reporter.InventorySelector{
+		Name:          "gpu",
+		NodeLabel:     "feature.node.kubernetes.io/pci-10de.present",
+		LabelPrefixes: []string{"nvidia.com/", "feature.node.kubernetes.io/pci-10de"},
+	}
 )
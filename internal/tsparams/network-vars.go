@@ -4,6 +4,7 @@ import (
 	nvidianetworkv1alpha1 "github.com/Mellanox/network-operator/api/v1alpha1"
 	"github.com/openshift-kni/k8sreporter"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/networkparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter"
 )
 
 var (
@@ -12,12 +13,20 @@ var (
 
 	// NetworkReporterNamespacesToDump tells to the reporter from where to collect logs.
 	NetworkReporterNamespacesToDump = map[string]string{
-		"openshift-nfd":           "nfd-operator",
-		"nvidia-network-operator": "network-operator",
+		"openshift-nfd":          "nfd-operator",
+		NetworkOperatorNamespace: "network-operator",
 	}
 
 	// NetworkReporterCRDsToDump tells to the reporter what CRs to dump.
 	NetworkReporterCRDsToDump = []k8sreporter.CRData{
 		{Cr: &nvidianetworkv1alpha1.NicClusterPolicyList{}},
 	}
+
+	// NetworkInventory tells to the run report how to collect the NVIDIA NIC inventory.
+	NetworkInventory = reporter.InventorySelector{
+		Name:      "nic",
+		NodeLabel: "feature.node.kubernetes.io/pci-15b3.present",
+		LabelPrefixes: []string{
+			"feature.node.kubernetes.io/pci-15b3", "feature.node.kubernetes.io/rdma", "network.nvidia.com/"},
+	}
 )
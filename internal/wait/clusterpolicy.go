@@ -0,0 +1,302 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// clusterPolicyDiagnosticsTimeout bounds the collection of the operands state once the wait has failed.
+const clusterPolicyDiagnosticsTimeout = 30 * time.Second
+
+// gpuOperandComponent is a GPU operand deployed by the ClusterPolicy as a DaemonSet.
+type gpuOperandComponent struct {
+	name            string
+	daemonSetPrefix string
+}
+
+// gpuOperandComponents are the GPU operands in rollout order: a component usually waits on the ones before it,
+// so the first component that is not ready is the one blocking the ClusterPolicy.
+var gpuOperandComponents = []gpuOperandComponent{
+	{name: "driver", daemonSetPrefix: "nvidia-driver-daemonset"},
+	{name: "container-toolkit", daemonSetPrefix: "nvidia-container-toolkit-daemonset"},
+	{name: "operator-validator", daemonSetPrefix: "nvidia-operator-validator"},
+	{name: "device-plugin", daemonSetPrefix: "nvidia-device-plugin-daemonset"},
+	{name: "gfd", daemonSetPrefix: "gpu-feature-discovery"},
+	{name: "dcgm-exporter", daemonSetPrefix: "nvidia-dcgm-exporter"},
+	{name: "mig-manager", daemonSetPrefix: "nvidia-mig-manager"},
+}
+
+// ComponentStatus is the rollout progress of a GPU operand.
+type ComponentStatus struct {
+	Name      string
+	DaemonSet string
+	Desired   int32
+	Ready     int32
+	Updated   int32
+	// PodState describes the first pod of the component that is not ready, empty when every pod is ready.
+	PodState string
+}
+
+// IsReady reports whether every desired pod of the component is updated and ready.
+func (status ComponentStatus) IsReady() bool {
+	return status.Ready >= status.Desired && status.Updated >= status.Desired
+}
+
+// String returns the progress of the component.
+func (status ComponentStatus) String() string {
+	progress := fmt.Sprintf("%s (daemonset %s): %d/%d pods ready, %d/%d updated", status.Name,
+		status.DaemonSet, status.Ready, status.Desired, status.Updated, status.Desired)
+
+	if status.PodState != "" {
+		progress += ", " + status.PodState
+	}
+
+	return progress
+}
+
+// ClusterPolicyNotReadyError is returned when the ClusterPolicy did not become ready. It names the operand
+// blocking the rollout, when one is found, with the state of its pods.
+type ClusterPolicyNotReadyError struct {
+	Name       string
+	State      string
+	Conditions []metav1.Condition
+	Components []ComponentStatus
+	// Blocking is the first component, in rollout order, that is not ready.
+	Blocking *ComponentStatus
+	Err      error
+}
+
+// Error returns the ClusterPolicy state, its failing conditions and the blocking component.
+func (clusterPolicyError *ClusterPolicyNotReadyError) Error() string {
+	var message strings.Builder
+
+	fmt.Fprintf(&message, "ClusterPolicy %s is not ready", clusterPolicyError.Name)
+
+	if clusterPolicyError.State != "" {
+		fmt.Fprintf(&message, " (state %s)", clusterPolicyError.State)
+	}
+
+	for _, condition := range clusterPolicyError.Conditions {
+		if (condition.Type == "Ready" && condition.Status != metav1.ConditionTrue) ||
+			(condition.Type == "Error" && condition.Status == metav1.ConditionTrue) {
+			fmt.Fprintf(&message, ", condition %s=%s %s: %s", condition.Type, condition.Status, condition.Reason,
+				condition.Message)
+		}
+	}
+
+	if clusterPolicyError.Blocking != nil {
+		fmt.Fprintf(&message, ", blocked by %s", clusterPolicyError.Blocking)
+	}
+
+	if clusterPolicyError.Err != nil {
+		fmt.Fprintf(&message, ": %v", clusterPolicyError.Err)
+	}
+
+	return message.String()
+}
+
+// Unwrap returns the error that ended the wait.
+func (clusterPolicyError *ClusterPolicyNotReadyError) Unwrap() error {
+	return clusterPolicyError.Err
+}
+
+// ClusterPolicyComponents returns the rollout progress of the GPU operands deployed in namespace, in rollout
+// order. Operands disabled in the ClusterPolicy, without DaemonSet, are skipped.
+func ClusterPolicyComponents(ctx context.Context, apiClient *clients.Settings,
+	namespace string) ([]ComponentStatus, error) {
+	daemonSets, err := apiClient.DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets in namespace %s: %w", namespace, err)
+	}
+
+	pods, err := apiClient.CoreV1Interface.Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+	}
+
+	var components []ComponentStatus
+
+	for _, component := range gpuOperandComponents {
+		for index := range daemonSets.Items {
+			daemonSet := &daemonSets.Items[index]
+			if !strings.HasPrefix(daemonSet.Name, component.daemonSetPrefix) {
+				continue
+			}
+
+			status := ComponentStatus{
+				Name:      component.name,
+				DaemonSet: daemonSet.Name,
+				Desired:   daemonSet.Status.DesiredNumberScheduled,
+				Ready:     daemonSet.Status.NumberReady,
+				Updated:   daemonSet.Status.UpdatedNumberScheduled,
+			}
+
+			if !status.IsReady() {
+				status.PodState = notReadyPodState(daemonSet, pods.Items)
+			}
+
+			components = append(components, status)
+		}
+	}
+
+	return components, nil
+}
+
+// blockingComponent returns the first component that is not ready, nil when they all are.
+func blockingComponent(components []ComponentStatus) *ComponentStatus {
+	for index := range components {
+		if !components[index].IsReady() {
+			return &components[index]
+		}
+	}
+
+	return nil
+}
+
+// notReadyPodState describes the first pod of daemonSet that is not ready.
+func notReadyPodState(daemonSet *appsv1.DaemonSet, pods []corev1.Pod) string {
+	for index := range pods {
+		pod := &pods[index]
+		if !metav1.IsControlledBy(pod, daemonSet) || isPodReady(pod) {
+			continue
+		}
+
+		state := fmt.Sprintf("pod %s on node %s is %s", pod.Name, pod.Spec.NodeName, pod.Status.Phase)
+
+		if pod.Status.Reason != "" {
+			state += fmt.Sprintf(" (%s: %s)", pod.Status.Reason, pod.Status.Message)
+		}
+
+		for _, containerStatuses := range [][]corev1.ContainerStatus{
+			pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, containerStatus := range containerStatuses {
+				if containerStatus.Ready {
+					continue
+				}
+
+				state += fmt.Sprintf(", container %s %s", containerStatus.Name, containerState(containerStatus))
+			}
+		}
+
+		return state
+	}
+
+	return ""
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func containerState(containerStatus corev1.ContainerStatus) string {
+	var state string
+
+	switch {
+	case containerStatus.State.Waiting != nil:
+		state = fmt.Sprintf("waiting (%s: %s)", containerStatus.State.Waiting.Reason,
+			containerStatus.State.Waiting.Message)
+	case containerStatus.State.Terminated != nil:
+		state = fmt.Sprintf("terminated (%s, exit code %d)", containerStatus.State.Terminated.Reason,
+			containerStatus.State.Terminated.ExitCode)
+	case containerStatus.State.Running != nil:
+		state = "running, not ready"
+	default:
+		state = "not started"
+	}
+
+	if containerStatus.RestartCount > 0 {
+		state += fmt.Sprintf(", %d restarts", containerStatus.RestartCount)
+	}
+
+	return state
+}
+
+// operandsNamespace returns the namespace of the GPU operands of clusterPolicy.
+func operandsNamespace(clusterPolicy *nvidiagpuv1.ClusterPolicy) string {
+	if clusterPolicy != nil && clusterPolicy.Status.Namespace != "" {
+		return clusterPolicy.Status.Namespace
+	}
+
+	return nvidiagpu.NvidiaGPUNamespace
+}
+
+// reportClusterPolicyProgress logs the rollout progress of the GPU operands every interval, when it changed,
+// until ctx is done.
+func reportClusterPolicyProgress(ctx context.Context, apiClient *clients.Settings, namespace func() string,
+	interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastProgress string
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		components, err := ClusterPolicyComponents(ctx, apiClient, namespace())
+		if err != nil {
+			glog.V(gpuparams.GpuLogLevel).Infof("Failed to get GPU operands progress: %v", err)
+
+			continue
+		}
+
+		var progress []string
+		for _, component := range components {
+			progress = append(progress, component.String())
+		}
+
+		if current := strings.Join(progress, "\n"); current != lastProgress {
+			glog.V(gpuparams.GpuLogLevel).Infof("GPU operands progress:\n%s", current)
+
+			lastProgress = current
+		}
+	}
+}
+
+// newClusterPolicyNotReadyError returns the ClusterPolicyNotReadyError of clusterPolicy, the last observed
+// state of the ClusterPolicy, with the rollout progress of its operands.
+func newClusterPolicyNotReadyError(ctx context.Context, apiClient *clients.Settings, clusterPolicyName string,
+	clusterPolicy *nvidiagpuv1.ClusterPolicy, err error) *ClusterPolicyNotReadyError {
+	clusterPolicyError := &ClusterPolicyNotReadyError{Name: clusterPolicyName, Err: err}
+
+	if clusterPolicy != nil {
+		clusterPolicyError.State = string(clusterPolicy.Status.State)
+		clusterPolicyError.Conditions = clusterPolicy.Status.Conditions
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), clusterPolicyDiagnosticsTimeout)
+	defer cancel()
+
+	components, componentsErr := ClusterPolicyComponents(ctx, apiClient, operandsNamespace(clusterPolicy))
+	if componentsErr != nil {
+		glog.V(gpuparams.GpuLogLevel).Infof("Failed to get GPU operands state: %v", componentsErr)
+
+		return clusterPolicyError
+	}
+
+	clusterPolicyError.Components = components
+	clusterPolicyError.Blocking = blockingComponent(components)
+
+	return clusterPolicyError
+}
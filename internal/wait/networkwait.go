@@ -4,12 +4,10 @@ import (
 	"context"
 	"time"
 
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidianetwork"
-
 	"github.com/golang/glog"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/networkparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
-	"k8s.io/apimachinery/pkg/util/wait"
 
 	networkoperator "github.com/Mellanox/network-operator/api/v1alpha1"
 )
@@ -17,41 +15,71 @@ import (
 // NicClusterPolicyReady Waits until nicClusterPolicy is Ready.
 func NicClusterPolicyReady(apiClient *clients.Settings, nicClusterPolicyName string, pollInterval,
 	timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(
-		context.Background(), pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			nicClusterPolicy, err := nvidianetwork.PullNicClusterPolicy(apiClient, nicClusterPolicyName)
+	return NicClusterPolicyReadyWithContext(context.TODO(), apiClient, nicClusterPolicyName, pollInterval, timeout)
+}
+
+// NicClusterPolicyReadyWithContext Waits until nicClusterPolicy is Ready.
+// The wait stops as soon as ctx is cancelled.
+// A zero pollInterval or timeout falls back to the active timeout profile.
+func NicClusterPolicyReadyWithContext(
+	ctx context.Context, apiClient *clients.Settings, nicClusterPolicyName string, pollInterval,
+	timeout time.Duration) error {
+	if apiClient.IsDryRun() {
+		glog.V(networkparams.LogLevel).Info("Dry-run: skipping NicClusterPolicyReady wait")
+
+		return nil
+	}
+
+	pollInterval = timeouts.OrDefault(pollInterval, timeouts.NicClusterPolicyReadyCheckInterval)
+	timeout = timeouts.OrDefault(timeout, timeouts.NicClusterPolicyReadyTimeout)
 
-			if err != nil {
-				glog.V(networkparams.LogLevel).Infof("NicClusterPolicy pull from cluster error: %s\n", err)
+	return ForObject(ctx, apiClient, nicClusterPolicyName, "",
+		func(nicClusterPolicy *networkoperator.NicClusterPolicy, exists bool) (bool, error) {
+			if !exists {
+				glog.V(networkparams.LogLevel).Infof("NicClusterPolicy %s not found", nicClusterPolicyName)
 
-				return false, err
+				return false, nil
 			}
 
 			glog.V(networkparams.LogLevel).Infof("NicClusterPolicy %s in now in %s state",
-				nicClusterPolicy.Object.Name, nicClusterPolicy.Object.Status.State)
+				nicClusterPolicy.Name, nicClusterPolicy.Status.State)
 
-			// returns true, nil when NicClusterPolicy is ready, this exits out of the PollUntilContextTimeout()
-			return nicClusterPolicy.Object.Status.State == networkoperator.StateReady, nil
-		})
+			return nicClusterPolicy.Status.State == networkoperator.StateReady, nil
+		}, pollInterval, timeout)
 }
 
 // MacvlanNetworkReady Waits until macvlanNetwork is Ready.
 func MacvlanNetworkReady(apiClient *clients.Settings, macvlanNetworkName string, pollInterval,
 	timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(
-		context.Background(), pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			macVlanNetwork, err := nvidianetwork.PullMacvlanNetwork(apiClient, macvlanNetworkName)
+	return MacvlanNetworkReadyWithContext(context.TODO(), apiClient, macvlanNetworkName, pollInterval, timeout)
+}
+
+// MacvlanNetworkReadyWithContext Waits until macvlanNetwork is Ready.
+// The wait stops as soon as ctx is cancelled.
+// A zero pollInterval or timeout falls back to the active timeout profile.
+func MacvlanNetworkReadyWithContext(
+	ctx context.Context, apiClient *clients.Settings, macvlanNetworkName string, pollInterval,
+	timeout time.Duration) error {
+	if apiClient.IsDryRun() {
+		glog.V(networkparams.LogLevel).Info("Dry-run: skipping MacvlanNetworkReady wait")
+
+		return nil
+	}
+
+	pollInterval = timeouts.OrDefault(pollInterval, timeouts.MacvlanNetworkReadyCheckInterval)
+	timeout = timeouts.OrDefault(timeout, timeouts.MacvlanNetworkReadyTimeout)
 
-			if err != nil {
-				glog.V(networkparams.LogLevel).Infof("MacvlanNetwork pull from cluster error: %s\n", err)
+	return ForObject(ctx, apiClient, macvlanNetworkName, "",
+		func(macVlanNetwork *networkoperator.MacvlanNetwork, exists bool) (bool, error) {
+			if !exists {
+				glog.V(networkparams.LogLevel).Infof("MacvlanNetwork %s not found", macvlanNetworkName)
 
-				return false, err
+				return false, nil
 			}
 
 			glog.V(networkparams.LogLevel).Infof("MacvlanNetwork %s in now in %s state",
-				macVlanNetwork.Object.Name, macVlanNetwork.Object.Status.State)
+				macVlanNetwork.Name, macVlanNetwork.Status.State)
 
-			// returns true, nil when MacvlanNetwork is ready, this exits out of the PollUntilContextTimeout()
-			return macVlanNetwork.Object.Status.State == networkoperator.StateReady, nil
-		})
+			return macVlanNetwork.Status.State == networkoperator.StateReady, nil
+		}, pollInterval, timeout)
 }
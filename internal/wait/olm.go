@@ -0,0 +1,301 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// subscriptionFailureConditions are the Subscription conditions reporting that OLM cannot install the operator.
+// ResolutionFailed is only a failure once it persisted for the grace period, since OLM reports it while the
+// catalogs are being updated. CatalogSourcesUnhealthy is checked separately, against the Subscription's own
+// CatalogSource.
+var subscriptionFailureConditions = []struct {
+	conditionType olmv1alpha1.SubscriptionConditionType
+	persistent    bool
+}{
+	{conditionType: olmv1alpha1.SubscriptionResolutionFailed, persistent: true},
+	{conditionType: olmv1alpha1.SubscriptionInstallPlanFailed},
+	{conditionType: olmv1alpha1.SubscriptionBundleUnpackFailed},
+}
+
+// CSVFailedError is returned when a ClusterServiceVersion reached the Failed phase.
+type CSVFailedError struct {
+	Name      string
+	Namespace string
+	Reason    olmv1alpha1.ConditionReason
+	Message   string
+}
+
+// Error returns the reason and message of the CSV failure.
+func (csvError *CSVFailedError) Error() string {
+	return fmt.Sprintf("ClusterServiceVersion %s in namespace %s failed: %s: %s", csvError.Name,
+		csvError.Namespace, csvError.Reason, csvError.Message)
+}
+
+// SubscriptionFailedError is returned when a Subscription has a condition reporting that OLM cannot install
+// the operator, e.g. a persistent ResolutionFailed, or when its CatalogSource stays unhealthy.
+type SubscriptionFailedError struct {
+	Name      string
+	Namespace string
+	Condition olmv1alpha1.SubscriptionConditionType
+	Reason    string
+	Message   string
+}
+
+// Error returns the failing condition of the Subscription, with its reason and message.
+func (subscriptionError *SubscriptionFailedError) Error() string {
+	return fmt.Sprintf("Subscription %s in namespace %s failed: %s %s: %s", subscriptionError.Name,
+		subscriptionError.Namespace, subscriptionError.Condition, subscriptionError.Reason, subscriptionError.Message)
+}
+
+// InstallPlanFailedError is returned when an InstallPlan reached the Failed phase.
+type InstallPlanFailedError struct {
+	Name      string
+	Namespace string
+	Reason    olmv1alpha1.InstallPlanConditionReason
+	Message   string
+}
+
+// Error returns the reason and message of the InstallPlan failure.
+func (installPlanError *InstallPlanFailedError) Error() string {
+	return fmt.Sprintf("InstallPlan %s in namespace %s failed: %s: %s", installPlanError.Name,
+		installPlanError.Namespace, installPlanError.Reason, installPlanError.Message)
+}
+
+// failurePersistence tells whether a failure reported by OLM lasted for a grace period, counted from the last
+// transition reported by OLM, or from when the wait first saw the failure when OLM did not report one.
+type failurePersistence struct {
+	gracePeriod time.Duration
+	firstSeen   map[string]time.Time
+	pending     error
+}
+
+func newFailurePersistence() *failurePersistence {
+	return &failurePersistence{
+		gracePeriod: timeouts.Get(timeouts.OLMFailureGracePeriod),
+		firstSeen:   map[string]time.Time{},
+	}
+}
+
+// check returns failure once the failure named key, ongoing since lastTransition, lasted for the grace period.
+// A nil failure clears key. A failure that did not last yet is kept, to be reported if the wait times out.
+func (persistence *failurePersistence) check(key string, lastTransition *metav1.Time, failure error) error {
+	if failure == nil {
+		delete(persistence.firstSeen, key)
+
+		return nil
+	}
+
+	since, seen := persistence.firstSeen[key]
+	if !seen {
+		since = time.Now()
+		persistence.firstSeen[key] = since
+	}
+
+	if lastTransition != nil && lastTransition.Time.Before(since) {
+		since = lastTransition.Time
+	}
+
+	if time.Since(since) >= persistence.gracePeriod {
+		return failure
+	}
+
+	glog.V(100).Infof("Waiting up to %s for the failure to persist: %v", persistence.gracePeriod, failure)
+
+	persistence.pending = failure
+
+	return nil
+}
+
+// wrap adds to err, returned by a wait, the last failure that had not lasted for the grace period yet.
+func (persistence *failurePersistence) wrap(err error) error {
+	if err == nil || persistence.pending == nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: last reported %w", err, persistence.pending)
+}
+
+// subscriptionFailure returns a *SubscriptionFailedError when subscription has a failure condition, or when its
+// own CatalogSource is unhealthy, once the failure lasted for the grace period of persistence. It returns nil
+// otherwise.
+func subscriptionFailure(subscription *olmv1alpha1.Subscription, persistence *failurePersistence) error {
+	for _, failureCondition := range subscriptionFailureConditions {
+		condition := subscription.Status.GetCondition(failureCondition.conditionType)
+
+		var failure error
+		if condition.Status == corev1.ConditionTrue {
+			failure = &SubscriptionFailedError{
+				Name:      subscription.Name,
+				Namespace: subscription.Namespace,
+				Condition: failureCondition.conditionType,
+				Reason:    condition.Reason,
+				Message:   condition.Message,
+			}
+		}
+
+		if !failureCondition.persistent {
+			if failure != nil {
+				return failure
+			}
+
+			continue
+		}
+
+		if err := persistence.check(string(failureCondition.conditionType), condition.LastTransitionTime,
+			failure); err != nil {
+			return err
+		}
+	}
+
+	lastUpdated, failure := subscriptionCatalogSourceFailure(subscription)
+
+	return persistence.check(string(olmv1alpha1.SubscriptionCatalogSourcesUnhealthy), lastUpdated, failure)
+}
+
+// subscriptionCatalogSourceFailure returns the time the health of the CatalogSource of subscription last changed
+// and a *SubscriptionFailedError when that CatalogSource is unhealthy. Other unhealthy CatalogSources are
+// ignored.
+func subscriptionCatalogSourceFailure(subscription *olmv1alpha1.Subscription) (*metav1.Time, error) {
+	if subscription.Spec == nil {
+		return nil, nil
+	}
+
+	for _, health := range subscription.Status.CatalogHealth {
+		if health.Healthy || health.CatalogSourceRef == nil ||
+			health.CatalogSourceRef.Name != subscription.Spec.CatalogSource ||
+			health.CatalogSourceRef.Namespace != subscription.Spec.CatalogSourceNamespace {
+			continue
+		}
+
+		condition := subscription.Status.GetCondition(olmv1alpha1.SubscriptionCatalogSourcesUnhealthy)
+
+		return health.LastUpdated, &SubscriptionFailedError{
+			Name:      subscription.Name,
+			Namespace: subscription.Namespace,
+			Condition: olmv1alpha1.SubscriptionCatalogSourcesUnhealthy,
+			Reason:    condition.Reason,
+			Message: fmt.Sprintf("CatalogSource %s in namespace %s is unhealthy: %s",
+				health.CatalogSourceRef.Name, health.CatalogSourceRef.Namespace, condition.Message),
+		}
+	}
+
+	return nil, nil
+}
+
+// SubscriptionResolved waits until OLM resolved the Subscription into an InstallPlan, and returns the name of
+// the InstallPlan. A *SubscriptionFailedError is returned as soon as the resolution fails.
+func SubscriptionResolved(apiClient *clients.Settings, subscriptionName, subscriptionNamespace string,
+	pollInterval, timeout time.Duration) (string, error) {
+	return SubscriptionResolvedWithContext(context.TODO(), apiClient, subscriptionName, subscriptionNamespace,
+		pollInterval, timeout)
+}
+
+// SubscriptionResolvedWithContext waits until OLM resolved the Subscription into an InstallPlan, and returns the
+// name of the InstallPlan. A *SubscriptionFailedError is returned once the resolution failed for the
+// olm_failure_grace_period.
+// The wait stops as soon as ctx is cancelled.
+// A zero pollInterval or timeout falls back to the active timeout profile.
+func SubscriptionResolvedWithContext(
+	ctx context.Context, apiClient *clients.Settings, subscriptionName, subscriptionNamespace string,
+	pollInterval, timeout time.Duration) (string, error) {
+	if apiClient.IsDryRun() {
+		glog.V(100).Info("Dry-run: skipping SubscriptionResolved wait")
+
+		return "", nil
+	}
+
+	pollInterval = timeouts.OrDefault(pollInterval, timeouts.SubscriptionResolutionCheckInterval)
+	timeout = timeouts.OrDefault(timeout, timeouts.SubscriptionResolutionTimeout)
+
+	var installPlanName string
+
+	persistence := newFailurePersistence()
+
+	err := ForObject(ctx, apiClient, subscriptionName, subscriptionNamespace,
+		func(subscription *olmv1alpha1.Subscription, exists bool) (bool, error) {
+			if !exists {
+				glog.V(100).Infof("Subscription %s not found", subscriptionName)
+
+				return false, nil
+			}
+
+			glog.V(100).Infof("Subscription %s is in %s state with current CSV '%s'", subscription.Name,
+				subscription.Status.State, subscription.Status.CurrentCSV)
+
+			if err := subscriptionFailure(subscription, persistence); err != nil {
+				return false, err
+			}
+
+			if subscription.Status.InstallPlanRef != nil {
+				installPlanName = subscription.Status.InstallPlanRef.Name
+			} else if subscription.Status.Install != nil {
+				installPlanName = subscription.Status.Install.Name
+			}
+
+			return installPlanName != "", nil
+		}, pollInterval, timeout)
+
+	return installPlanName, persistence.wrap(err)
+}
+
+// InstallPlanComplete waits until the InstallPlan is Complete. An *InstallPlanFailedError is returned as soon
+// as the InstallPlan fails.
+func InstallPlanComplete(apiClient *clients.Settings, installPlanName, installPlanNamespace string,
+	pollInterval, timeout time.Duration) error {
+	return InstallPlanCompleteWithContext(context.TODO(), apiClient, installPlanName, installPlanNamespace,
+		pollInterval, timeout)
+}
+
+// InstallPlanCompleteWithContext waits until the InstallPlan is Complete. An *InstallPlanFailedError is
+// returned as soon as the InstallPlan fails.
+// The wait stops as soon as ctx is cancelled.
+// A zero pollInterval or timeout falls back to the active timeout profile.
+func InstallPlanCompleteWithContext(
+	ctx context.Context, apiClient *clients.Settings, installPlanName, installPlanNamespace string,
+	pollInterval, timeout time.Duration) error {
+	if apiClient.IsDryRun() {
+		glog.V(100).Info("Dry-run: skipping InstallPlanComplete wait")
+
+		return nil
+	}
+
+	pollInterval = timeouts.OrDefault(pollInterval, timeouts.InstallPlanCompleteCheckInterval)
+	timeout = timeouts.OrDefault(timeout, timeouts.InstallPlanCompleteTimeout)
+
+	return ForObject(ctx, apiClient, installPlanName, installPlanNamespace,
+		func(installPlan *olmv1alpha1.InstallPlan, exists bool) (bool, error) {
+			if !exists {
+				glog.V(100).Infof("InstallPlan %s not found", installPlanName)
+
+				return false, nil
+			}
+
+			glog.V(100).Infof("InstallPlan %s is in %s phase", installPlan.Name, installPlan.Status.Phase)
+
+			switch installPlan.Status.Phase {
+			case olmv1alpha1.InstallPlanPhaseComplete:
+				return true, nil
+			case olmv1alpha1.InstallPlanPhaseFailed:
+				condition := installPlan.Status.GetCondition(olmv1alpha1.InstallPlanInstalled)
+
+				return false, &InstallPlanFailedError{
+					Name:      installPlan.Name,
+					Namespace: installPlan.Namespace,
+					Reason:    condition.Reason,
+					Message:   condition.Message,
+				}
+			}
+
+			return false, nil
+		}, pollInterval, timeout)
+}
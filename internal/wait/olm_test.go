@@ -0,0 +1,215 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testSubscriptionName = "gpu-subscription"
+	testCatalogSource    = "certified-operators"
+	testCSVName          = "gpu-operator-certified.v25.3.0"
+)
+
+func TestSubscriptionFailure(t *testing.T) {
+	recent := metav1.NewTime(time.Now())
+	old := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	testCases := []struct {
+		name              string
+		conditions        []olmv1alpha1.SubscriptionCondition
+		catalogHealth     []olmv1alpha1.SubscriptionCatalogHealth
+		expectedCondition olmv1alpha1.SubscriptionConditionType
+	}{
+		{
+			name: "no failure",
+		},
+		{
+			name: "recent resolution failure",
+			conditions: []olmv1alpha1.SubscriptionCondition{
+				failedCondition(olmv1alpha1.SubscriptionResolutionFailed, &recent),
+			},
+		},
+		{
+			name: "persistent resolution failure",
+			conditions: []olmv1alpha1.SubscriptionCondition{
+				failedCondition(olmv1alpha1.SubscriptionResolutionFailed, &old),
+			},
+			expectedCondition: olmv1alpha1.SubscriptionResolutionFailed,
+		},
+		{
+			name: "install plan failure",
+			conditions: []olmv1alpha1.SubscriptionCondition{
+				failedCondition(olmv1alpha1.SubscriptionInstallPlanFailed, &recent),
+			},
+			expectedCondition: olmv1alpha1.SubscriptionInstallPlanFailed,
+		},
+		{
+			name: "other catalog source unhealthy",
+			conditions: []olmv1alpha1.SubscriptionCondition{
+				failedCondition(olmv1alpha1.SubscriptionCatalogSourcesUnhealthy, &old),
+			},
+			catalogHealth: []olmv1alpha1.SubscriptionCatalogHealth{
+				catalogHealth("redhat-marketplace", false, &old),
+				catalogHealth(testCatalogSource, true, &old),
+			},
+		},
+		{
+			name: "own catalog source recently unhealthy",
+			conditions: []olmv1alpha1.SubscriptionCondition{
+				failedCondition(olmv1alpha1.SubscriptionCatalogSourcesUnhealthy, &recent),
+			},
+			catalogHealth: []olmv1alpha1.SubscriptionCatalogHealth{catalogHealth(testCatalogSource, false, &recent)},
+		},
+		{
+			name: "own catalog source persistently unhealthy",
+			conditions: []olmv1alpha1.SubscriptionCondition{
+				failedCondition(olmv1alpha1.SubscriptionCatalogSourcesUnhealthy, &old),
+			},
+			catalogHealth:     []olmv1alpha1.SubscriptionCatalogHealth{catalogHealth(testCatalogSource, false, &old)},
+			expectedCondition: olmv1alpha1.SubscriptionCatalogSourcesUnhealthy,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			subscription := buildSubscription(testCase.conditions, testCase.catalogHealth)
+
+			err := subscriptionFailure(subscription, newFailurePersistence())
+
+			if testCase.expectedCondition == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			var subscriptionError *SubscriptionFailedError
+			if !errors.As(err, &subscriptionError) || subscriptionError.Condition != testCase.expectedCondition {
+				t.Errorf("expected a %s failure, got %v", testCase.expectedCondition, err)
+			}
+		})
+	}
+}
+
+func TestFailurePersistence(t *testing.T) {
+	persistence := newFailurePersistence()
+	persistence.gracePeriod = 50 * time.Millisecond
+	failure := errors.New("failed")
+
+	if err := persistence.check("phase", nil, failure); err != nil {
+		t.Errorf("expected a new failure to be pending, got %v", err)
+	}
+
+	time.Sleep(persistence.gracePeriod)
+
+	if err := persistence.check("phase", nil, failure); !errors.Is(err, failure) {
+		t.Errorf("expected the failure once it persisted, got %v", err)
+	}
+
+	if err := persistence.check("phase", nil, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := persistence.check("phase", nil, failure); err != nil {
+		t.Errorf("expected a recovered failure to be pending again, got %v", err)
+	}
+
+	timeout := errors.New("timed out")
+	if err := persistence.wrap(timeout); !errors.Is(err, timeout) || !errors.Is(err, failure) {
+		t.Errorf("expected the timeout to wrap the pending failure, got %v", err)
+	}
+
+	if err := persistence.wrap(nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCSVSucceeded(t *testing.T) {
+	recent := metav1.NewTime(time.Now())
+	old := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	testCases := []struct {
+		name            string
+		phase           olmv1alpha1.ClusterServiceVersionPhase
+		lastTransition  *metav1.Time
+		expectedError   bool
+		expectedFailure bool
+	}{
+		{name: "succeeded", phase: olmv1alpha1.CSVPhaseSucceeded, lastTransition: &recent},
+		{name: "installing", phase: olmv1alpha1.CSVPhaseInstalling, lastTransition: &recent, expectedError: true},
+		{name: "recently failed", phase: olmv1alpha1.CSVPhaseFailed, lastTransition: &recent, expectedError: true,
+			expectedFailure: true},
+		{name: "persistently failed", phase: olmv1alpha1.CSVPhaseFailed, lastTransition: &old, expectedError: true,
+			expectedFailure: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			csv := &olmv1alpha1.ClusterServiceVersion{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "operators.coreos.com/v1alpha1", Kind: "ClusterServiceVersion"},
+				ObjectMeta: metav1.ObjectMeta{Name: testCSVName, Namespace: testNamespace},
+				Status: olmv1alpha1.ClusterServiceVersionStatus{
+					Phase:              testCase.phase,
+					Reason:             olmv1alpha1.CSVReasonInstallCheckFailed,
+					LastTransitionTime: testCase.lastTransition,
+				},
+			}
+			apiClient := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{csv}})
+
+			err := CSVSucceededWithContext(context.TODO(), apiClient, testCSVName, testNamespace, testPollInterval,
+				200*time.Millisecond)
+
+			if testCase.expectedError != (err != nil) {
+				t.Fatalf("expected error %t, got %v", testCase.expectedError, err)
+			}
+
+			var csvError *CSVFailedError
+			if testCase.expectedFailure != errors.As(err, &csvError) {
+				t.Errorf("expected CSV failure %t, got %v", testCase.expectedFailure, err)
+			}
+		})
+	}
+}
+
+func failedCondition(
+	conditionType olmv1alpha1.SubscriptionConditionType,
+	lastTransition *metav1.Time) olmv1alpha1.SubscriptionCondition {
+	return olmv1alpha1.SubscriptionCondition{
+		Type:               conditionType,
+		Status:             corev1.ConditionTrue,
+		Reason:             "Failed",
+		LastTransitionTime: lastTransition,
+	}
+}
+
+func catalogHealth(name string, healthy bool, lastUpdated *metav1.Time) olmv1alpha1.SubscriptionCatalogHealth {
+	return olmv1alpha1.SubscriptionCatalogHealth{
+		CatalogSourceRef: &corev1.ObjectReference{Name: name, Namespace: "openshift-marketplace"},
+		LastUpdated:      lastUpdated,
+		Healthy:          healthy,
+	}
+}
+
+func buildSubscription(
+	conditions []olmv1alpha1.SubscriptionCondition,
+	health []olmv1alpha1.SubscriptionCatalogHealth) *olmv1alpha1.Subscription {
+	return &olmv1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: testSubscriptionName, Namespace: testNamespace},
+		Spec: &olmv1alpha1.SubscriptionSpec{
+			CatalogSource:          testCatalogSource,
+			CatalogSourceNamespace: "openshift-marketplace",
+			Package:                "gpu-operator-certified",
+		},
+		Status: olmv1alpha1.SubscriptionStatus{Conditions: conditions, CatalogHealth: health},
+	}
+}
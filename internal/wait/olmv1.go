@@ -0,0 +1,170 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm/ocv1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterCatalogFailedError is returned when catalogd reports that a ClusterCatalog cannot be served without a
+// change of its spec.
+type ClusterCatalogFailedError struct {
+	Name      string
+	Condition string
+	Reason    string
+	Message   string
+}
+
+// Error returns the failing condition of the ClusterCatalog, with its reason and message.
+func (catalogError *ClusterCatalogFailedError) Error() string {
+	return fmt.Sprintf("ClusterCatalog %s failed: %s %s: %s", catalogError.Name, catalogError.Condition,
+		catalogError.Reason, catalogError.Message)
+}
+
+// ClusterExtensionFailedError is returned when operator-controller reports that a ClusterExtension cannot be
+// installed without a change of its spec, e.g. no bundle matches its version range.
+type ClusterExtensionFailedError struct {
+	Name      string
+	Condition string
+	Reason    string
+	Message   string
+}
+
+// Error returns the failing condition of the ClusterExtension, with its reason and message.
+func (extensionError *ClusterExtensionFailedError) Error() string {
+	return fmt.Sprintf("ClusterExtension %s failed: %s %s: %s", extensionError.Name, extensionError.Condition,
+		extensionError.Reason, extensionError.Message)
+}
+
+// blockedCondition returns the Progressing condition of conditions when its reason is Blocked for the given
+// generation, nil otherwise. A Retrying reason is transient and is not returned.
+func blockedCondition(conditions []metav1.Condition, generation int64) *metav1.Condition {
+	condition := meta.FindStatusCondition(conditions, ocv1.TypeProgressing)
+	if condition == nil || condition.Reason != ocv1.ReasonBlocked || condition.ObservedGeneration != generation {
+		return nil
+	}
+
+	return condition
+}
+
+// ClusterCatalogServing waits until catalogd serves the content of the ClusterCatalog. A
+// *ClusterCatalogFailedError is returned as soon as the ClusterCatalog is blocked.
+func ClusterCatalogServing(apiClient *clients.Settings, catalogName string, pollInterval, timeout time.Duration) error {
+	return ClusterCatalogServingWithContext(context.TODO(), apiClient, catalogName, pollInterval, timeout)
+}
+
+// ClusterCatalogServingWithContext waits until catalogd serves the content of the ClusterCatalog. A
+// *ClusterCatalogFailedError is returned as soon as the ClusterCatalog is blocked.
+// The wait stops as soon as ctx is cancelled.
+// A zero pollInterval or timeout falls back to the active timeout profile.
+func ClusterCatalogServingWithContext(
+	ctx context.Context, apiClient *clients.Settings, catalogName string, pollInterval, timeout time.Duration) error {
+	if apiClient.IsDryRun() {
+		glog.V(100).Info("Dry-run: skipping ClusterCatalogServing wait")
+
+		return nil
+	}
+
+	pollInterval = timeouts.OrDefault(pollInterval, timeouts.ClusterCatalogServingCheckInterval)
+	timeout = timeouts.OrDefault(timeout, timeouts.ClusterCatalogServingTimeout)
+
+	return ForObject(ctx, apiClient, catalogName, "",
+		func(catalog *ocv1.ClusterCatalog, exists bool) (bool, error) {
+			if !exists {
+				glog.V(100).Infof("ClusterCatalog %s not found", catalogName)
+
+				return false, nil
+			}
+
+			if condition := blockedCondition(catalog.Status.Conditions, catalog.Generation); condition != nil {
+				return false, &ClusterCatalogFailedError{
+					Name:      catalog.Name,
+					Condition: condition.Type,
+					Reason:    condition.Reason,
+					Message:   condition.Message,
+				}
+			}
+
+			serving := meta.IsStatusConditionTrue(catalog.Status.Conditions, ocv1.TypeServing)
+
+			glog.V(100).Infof("ClusterCatalog %s serving: %t", catalog.Name, serving)
+
+			return serving, nil
+		}, pollInterval, timeout)
+}
+
+// ClusterExtensionInstalled waits until operator-controller installed a bundle of the ClusterExtension, and
+// returns the installed bundle. A *ClusterExtensionFailedError is returned as soon as the ClusterExtension is
+// blocked.
+func ClusterExtensionInstalled(apiClient *clients.Settings, extensionName string,
+	pollInterval, timeout time.Duration) (*ocv1.BundleMetadata, error) {
+	return ClusterExtensionInstalledWithContext(context.TODO(), apiClient, extensionName, pollInterval, timeout)
+}
+
+// ClusterExtensionInstalledWithContext waits until operator-controller installed a bundle of the
+// ClusterExtension, and returns the installed bundle. Only the conditions observed for the current generation
+// count, so the wait can follow an update of the ClusterExtension. A *ClusterExtensionFailedError is returned
+// as soon as the ClusterExtension is blocked.
+// The wait stops as soon as ctx is cancelled.
+// A zero pollInterval or timeout falls back to the active timeout profile.
+func ClusterExtensionInstalledWithContext(ctx context.Context, apiClient *clients.Settings, extensionName string,
+	pollInterval, timeout time.Duration) (*ocv1.BundleMetadata, error) {
+	if apiClient.IsDryRun() {
+		glog.V(100).Info("Dry-run: skipping ClusterExtensionInstalled wait")
+
+		return &ocv1.BundleMetadata{}, nil
+	}
+
+	pollInterval = timeouts.OrDefault(pollInterval, timeouts.ClusterExtensionInstalledCheckInterval)
+	timeout = timeouts.OrDefault(timeout, timeouts.ClusterExtensionInstalledTimeout)
+
+	var bundle *ocv1.BundleMetadata
+
+	err := ForObject(ctx, apiClient, extensionName, "",
+		func(extension *ocv1.ClusterExtension, exists bool) (bool, error) {
+			if !exists {
+				glog.V(100).Infof("ClusterExtension %s not found", extensionName)
+
+				return false, nil
+			}
+
+			if condition := blockedCondition(extension.Status.Conditions, extension.Generation); condition != nil {
+				return false, &ClusterExtensionFailedError{
+					Name:      extension.Name,
+					Condition: condition.Type,
+					Reason:    condition.Reason,
+					Message:   condition.Message,
+				}
+			}
+
+			installed := meta.FindStatusCondition(extension.Status.Conditions, ocv1.TypeInstalled)
+			if installed == nil || installed.Status != metav1.ConditionTrue ||
+				installed.ObservedGeneration != extension.Generation || extension.Status.Install == nil {
+				if progressing := meta.FindStatusCondition(extension.Status.Conditions,
+					ocv1.TypeProgressing); progressing != nil {
+					glog.V(100).Infof("ClusterExtension %s is progressing: %s: %s", extension.Name,
+						progressing.Reason, progressing.Message)
+				}
+
+				return false, nil
+			}
+
+			installedBundle := extension.Status.Install.Bundle
+			bundle = &installedBundle
+
+			glog.V(100).Infof("ClusterExtension %s installed bundle %s version %s", extension.Name, bundle.Name,
+				bundle.Version)
+
+			return true, nil
+		}, pollInterval, timeout)
+
+	return bundle, err
+}
@@ -2,104 +2,315 @@ package wait
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/glog"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/deployment"
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
-	"k8s.io/apimachinery/pkg/util/wait"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // ClusterPolicyReady Waits until clusterPolicy is Ready.
 func ClusterPolicyReady(apiClient *clients.Settings, clusterPolicyName string, pollInterval,
 	timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(
-		context.TODO(), pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			clusterPolicy, err := nvidiagpu.Pull(apiClient, clusterPolicyName)
+	return ClusterPolicyReadyWithContext(context.TODO(), apiClient, clusterPolicyName, pollInterval, timeout)
+}
 
-			if err != nil {
-				glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy pull from cluster error: %s\n", err)
+// ClusterPolicyReadyWithContext Waits until clusterPolicy is Ready.
+// The rollout progress of the GPU operands is logged every pollInterval while waiting, and a
+// *ClusterPolicyNotReadyError naming the blocking operand is returned when clusterPolicy is not Ready in time.
+// The wait stops as soon as ctx is cancelled.
+// A zero pollInterval or timeout falls back to the active timeout profile.
+func ClusterPolicyReadyWithContext(
+	ctx context.Context, apiClient *clients.Settings, clusterPolicyName string, pollInterval,
+	timeout time.Duration) error {
+	if apiClient.IsDryRun() {
+		glog.V(gpuparams.GpuLogLevel).Info("Dry-run: skipping ClusterPolicyReady wait")
 
-				return false, err
-			}
+		return nil
+	}
 
-			if clusterPolicy.Object != nil && clusterPolicy.Object.Status.State == "ready" {
-				glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy %s in now in %s state",
-					clusterPolicy.Object.Name, clusterPolicy.Object.Status.State)
+	pollInterval = timeouts.OrDefault(pollInterval, timeouts.ClusterPolicyReadyCheckInterval)
+	timeout = timeouts.OrDefault(timeout, timeouts.ClusterPolicyReadyTimeout)
+
+	var lastClusterPolicy atomic.Pointer[nvidiagpuv1.ClusterPolicy]
+
+	progressCtx, stopProgress := context.WithCancel(ctx)
+	defer stopProgress()
+
+	go reportClusterPolicyProgress(progressCtx, apiClient, func() string {
+		return operandsNamespace(lastClusterPolicy.Load())
+	}, pollInterval)
+
+	err := ForObject(ctx, apiClient, clusterPolicyName, "",
+		func(clusterPolicy *nvidiagpuv1.ClusterPolicy, exists bool) (bool, error) {
+			if !exists {
+				glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy %s not found", clusterPolicyName)
 
-				// this exits out of the PollUntilContextTimeout()
-				return true, nil
-			}
-			if clusterPolicy.Object == nil {
-				glog.V(gpuparams.GpuLogLevel).Info("ClusterPolicy object is nil")
 				return false, nil
 			}
 
+			lastClusterPolicy.Store(clusterPolicy)
+
 			glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy %s in now in %s state",
-				clusterPolicy.Object.Name, clusterPolicy.Object.Status.State)
+				clusterPolicy.Name, clusterPolicy.Status.State)
 
-			return false, nil
-		})
+			for _, condition := range clusterPolicy.Status.Conditions {
+				glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy %s condition %s=%s %s: %s", clusterPolicy.Name,
+					condition.Type, condition.Status, condition.Reason, condition.Message)
+			}
+
+			return clusterPolicy.Status.State == nvidiagpuv1.Ready, nil
+		}, pollInterval, timeout)
+
+	stopProgress()
+
+	if err != nil {
+		return newClusterPolicyNotReadyError(ctx, apiClient, clusterPolicyName, lastClusterPolicy.Load(), err)
+	}
+
+	return nil
 }
 
 // CSVSucceeded waits for a defined period of time for CSV to be in Succeeded state.
+// A *CSVFailedError is returned once the CSV stayed in Failed state for the olm_failure_grace_period, since
+// OLM moves CSVs out of Failed, e.g. from InstallCheckFailed back to Pending during upgrades.
 func CSVSucceeded(apiClient *clients.Settings, csvName, csvNamespace string, pollInterval,
 	timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(
-		context.TODO(), pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			csvPulled, err := olm.PullClusterServiceVersion(apiClient, csvName, csvNamespace)
+	return CSVSucceededWithContext(context.TODO(), apiClient, csvName, csvNamespace, pollInterval, timeout)
+}
 
-			if err != nil {
-				glog.V(gpuparams.GpuLogLevel).Infof("ClusterServiceVersion pull from cluster error: %s\n", err)
+// CSVSucceededWithContext waits for a defined period of time for CSV to be in Succeeded state.
+// A *CSVFailedError is returned once the CSV stayed in Failed state for the olm_failure_grace_period.
+// The wait stops as soon as ctx is cancelled.
+// A zero pollInterval or timeout falls back to the active timeout profile.
+func CSVSucceededWithContext(
+	ctx context.Context, apiClient *clients.Settings, csvName, csvNamespace string, pollInterval,
+	timeout time.Duration) error {
+	if apiClient.IsDryRun() {
+		glog.V(gpuparams.GpuLogLevel).Info("Dry-run: skipping CSVSucceeded wait")
 
-				return false, err
+		return nil
+	}
+
+	pollInterval = timeouts.OrDefault(pollInterval, timeouts.CSVSucceededCheckInterval)
+	timeout = timeouts.OrDefault(timeout, timeouts.CSVSucceededTimeout)
+
+	persistence := newFailurePersistence()
+
+	err := ForObject(ctx, apiClient, csvName, csvNamespace,
+		func(csv *olmv1alpha1.ClusterServiceVersion, exists bool) (bool, error) {
+			if !exists {
+				glog.V(gpuparams.GpuLogLevel).Infof("ClusterServiceVersion %s not found", csvName)
+
+				return false, nil
 			}
 
-			if csvPulled.Object.Status.Phase == "Succeeded" {
-				glog.V(gpuparams.GpuLogLevel).Infof("ClusterServiceVersion %s in now in %s state",
-					csvPulled.Object.Name, csvPulled.Object.Status.Phase)
+			glog.V(gpuparams.GpuLogLevel).Infof("ClusterServiceVersion %s in now in %s state",
+				csv.Name, csv.Status.Phase)
 
-				// this exists out of the wait.PollImmediate().
-				return true, nil
+			var failure error
+			if csv.Status.Phase == olmv1alpha1.CSVPhaseFailed {
+				failure = &CSVFailedError{
+					Name:      csv.Name,
+					Namespace: csv.Namespace,
+					Reason:    csv.Status.Reason,
+					Message:   csv.Status.Message,
+				}
 			}
 
-			glog.V(gpuparams.GpuLogLevel).Infof("clusterPolicy %s in now in %s state",
-				csvPulled.Object.Name, csvPulled.Object.Status.Phase)
+			if err := persistence.check(string(olmv1alpha1.CSVPhaseFailed), csv.Status.LastTransitionTime,
+				failure); err != nil {
+				return false, err
+			}
+
+			return csv.Status.Phase == olmv1alpha1.CSVPhaseSucceeded, nil
+		}, pollInterval, timeout)
 
-			return false, err
-		})
+	return persistence.wrap(err)
 }
 
 // DeploymentCreated waits for a defined period of time for deployment to be created.
 func DeploymentCreated(apiClient *clients.Settings, deploymentName, deploymentNamespace string, pollInterval,
 	timeout time.Duration) bool {
-	// Note: the value for boolean variable "immediate" is false here, meaning check AFTER polling interval
-	//       on the very first try.  Otherwise the first check was causing an error and failing testcase.
-	err := wait.PollUntilContextTimeout(
-		context.TODO(), pollInterval, timeout, false, func(ctx context.Context) (bool, error) {
-			var err error
-			deploymentPulled, err := deployment.Pull(apiClient, deploymentName, deploymentNamespace)
+	return DeploymentCreatedWithContext(context.TODO(), apiClient, deploymentName, deploymentNamespace, pollInterval,
+		timeout)
+}
+
+// DeploymentCreatedWithContext waits for a defined period of time for deployment to be created.
+// The wait stops as soon as ctx is cancelled.
+// A zero pollInterval or timeout falls back to the active timeout profile.
+func DeploymentCreatedWithContext(
+	ctx context.Context, apiClient *clients.Settings, deploymentName, deploymentNamespace string, pollInterval,
+	timeout time.Duration) bool {
+	if apiClient.IsDryRun() {
+		glog.V(gpuparams.GpuLogLevel).Info("Dry-run: skipping DeploymentCreated wait")
+
+		return true
+	}
+
+	pollInterval = timeouts.OrDefault(pollInterval, timeouts.DeploymentCreationCheckInterval)
+	timeout = timeouts.OrDefault(timeout, timeouts.DeploymentCreationTimeout)
+
+	err := ForObject(ctx, apiClient, deploymentName, deploymentNamespace,
+		func(deployment *appsv1.Deployment, exists bool) (bool, error) {
+			if exists {
+				glog.V(gpuparams.GpuLogLevel).Infof("Deployment '%s' in namespace '%s' has been created",
+					deployment.Name, deploymentNamespace)
+			}
+
+			return exists, nil
+		}, pollInterval, timeout)
+
+	if err != nil {
+		glog.V(gpuparams.GpuLogLevel).Infof("Deployment '%s' in namespace '%s' was not created: %v",
+			deploymentName, deploymentNamespace, err)
+	}
+
+	return err == nil
+}
 
-			if err != nil {
-				glog.V(gpuparams.GpuLogLevel).Infof("Deployment '%s' pull from cluster namespace '%s' error:"+
-					" %v", deploymentName, deploymentNamespace, err)
+// NodeLabeled waits until at least one node has all the nodeSelector labels.
+func NodeLabeled(apiClient *clients.Settings, nodeSelector map[string]string, pollInterval,
+	timeout time.Duration) error {
+	return NodeLabeledWithContext(context.TODO(), apiClient, nodeSelector, pollInterval, timeout)
+}
+
+// NodeLabeledWithContext waits until at least one node has all the nodeSelector labels.
+// The wait stops as soon as ctx is cancelled.
+// A zero pollInterval or timeout falls back to the active timeout profile.
+func NodeLabeledWithContext(
+	ctx context.Context, apiClient *clients.Settings, nodeSelector map[string]string, pollInterval,
+	timeout time.Duration) error {
+	pollInterval = timeouts.OrDefault(pollInterval, timeouts.NodeLabelingCheckInterval)
+	timeout = timeouts.OrDefault(timeout, timeouts.NodeLabelingTimeout)
+
+	return ForObjects(ctx, apiClient, "", labels.SelectorFromSet(nodeSelector),
+		func(nodes []*corev1.Node) (bool, error) {
+			for _, node := range nodes {
+				glog.V(gpuparams.GpuLogLevel).Infof("Node '%s' has labels %v", node.Name, nodeSelector)
+			}
+
+			return len(nodes) > 0, nil
+		}, pollInterval, timeout)
+}
+
+// SubscriptionCSVChanged waits until the Subscription has installed a CSV other than previousCSV, and returns
+// the name of the installed CSV. A *SubscriptionFailedError is returned once OLM failed to install the new
+// CSV for the olm_failure_grace_period.
+func SubscriptionCSVChanged(apiClient *clients.Settings, subscriptionName, subscriptionNamespace,
+	previousCSV string, pollInterval, timeout time.Duration) (string, error) {
+	return SubscriptionCSVChangedWithContext(context.TODO(), apiClient, subscriptionName, subscriptionNamespace,
+		previousCSV, pollInterval, timeout)
+}
+
+// SubscriptionCSVChangedWithContext waits until the Subscription has installed a CSV other than previousCSV,
+// and returns the name of the installed CSV. A *SubscriptionFailedError is returned once OLM failed to
+// install the new CSV for the olm_failure_grace_period.
+// The wait stops as soon as ctx is cancelled.
+// A zero pollInterval or timeout falls back to the active timeout profile.
+func SubscriptionCSVChangedWithContext(
+	ctx context.Context, apiClient *clients.Settings, subscriptionName, subscriptionNamespace, previousCSV string,
+	pollInterval, timeout time.Duration) (string, error) {
+	pollInterval = timeouts.OrDefault(pollInterval, timeouts.SubscriptionUpgradeCheckInterval)
+	timeout = timeouts.OrDefault(timeout, timeouts.SubscriptionUpgradeTimeout)
 
+	var installedCSV string
+
+	persistence := newFailurePersistence()
+
+	err := ForObject(ctx, apiClient, subscriptionName, subscriptionNamespace,
+		func(subscription *olmv1alpha1.Subscription, exists bool) (bool, error) {
+			if !exists {
+				glog.V(gpuparams.GpuLogLevel).Infof("Subscription %s not found", subscriptionName)
+
+				return false, nil
+			}
+
+			glog.V(gpuparams.GpuLogLevel).Infof("Subscription %s is in %s state with current CSV '%s' and "+
+				"installed CSV '%s'", subscription.Name, subscription.Status.State, subscription.Status.CurrentCSV,
+				subscription.Status.InstalledCSV)
+
+			if err := subscriptionFailure(subscription, persistence); err != nil {
 				return false, err
 			}
 
-			if deploymentPulled.Exists() {
-				glog.V(gpuparams.GpuLogLevel).Infof("Deployment '%s' in namespace '%s' has been created",
-					deploymentPulled.Object.Name, deploymentNamespace)
+			installedCSV = subscription.Status.InstalledCSV
+
+			return installedCSV != "" && installedCSV != previousCSV, nil
+		}, pollInterval, timeout)
+
+	return installedCSV, persistence.wrap(err)
+}
 
-				// this exists out of the wait.PollImmediate().
+// PodRunning waits until the pod is in Running phase. A pod that has terminated fails the wait.
+func PodRunning(apiClient *clients.Settings, podName, podNamespace string, pollInterval,
+	timeout time.Duration) error {
+	return PodRunningWithContext(context.TODO(), apiClient, podName, podNamespace, pollInterval, timeout)
+}
+
+// PodRunningWithContext waits until the pod is in Running phase. A pod that has terminated fails the wait.
+// The wait stops as soon as ctx is cancelled.
+func PodRunningWithContext(
+	ctx context.Context, apiClient *clients.Settings, podName, podNamespace string, pollInterval,
+	timeout time.Duration) error {
+	return ForObject(ctx, apiClient, podName, podNamespace,
+		func(pod *corev1.Pod, exists bool) (bool, error) {
+			if !exists {
+				glog.V(100).Infof("Pod '%s' in namespace '%s' not found", podName, podNamespace)
+
+				return false, nil
+			}
+
+			glog.V(100).Infof("Pod '%s' in namespace '%s' is in %s phase", podName, podNamespace, pod.Status.Phase)
+
+			switch pod.Status.Phase {
+			case corev1.PodRunning:
 				return true, nil
+			case corev1.PodSucceeded, corev1.PodFailed:
+				return false, fmt.Errorf("pod '%s' in namespace '%s' terminated in %s phase: %s",
+					podName, podNamespace, pod.Status.Phase, pod.Status.Message)
 			}
 
 			return false, nil
-		})
+		}, pollInterval, timeout)
+}
 
-	return err == nil
+// PodCompleted waits until the pod is in Succeeded or Failed phase, and returns that phase.
+func PodCompleted(apiClient *clients.Settings, podName, podNamespace string, pollInterval,
+	timeout time.Duration) (corev1.PodPhase, error) {
+	return PodCompletedWithContext(context.TODO(), apiClient, podName, podNamespace, pollInterval, timeout)
+}
+
+// PodCompletedWithContext waits until the pod is in Succeeded or Failed phase, and returns that phase.
+// The wait stops as soon as ctx is cancelled.
+func PodCompletedWithContext(
+	ctx context.Context, apiClient *clients.Settings, podName, podNamespace string, pollInterval,
+	timeout time.Duration) (corev1.PodPhase, error) {
+	var phase corev1.PodPhase
+
+	err := ForObject(ctx, apiClient, podName, podNamespace,
+		func(pod *corev1.Pod, exists bool) (bool, error) {
+			if !exists {
+				glog.V(100).Infof("Pod '%s' in namespace '%s' not found", podName, podNamespace)
+
+				return false, nil
+			}
+
+			glog.V(100).Infof("Pod '%s' in namespace '%s' is in %s phase", podName, podNamespace, pod.Status.Phase)
+
+			phase = pod.Status.Phase
+
+			return phase == corev1.PodSucceeded || phase == corev1.PodFailed, nil
+		}, pollInterval, timeout)
+
+	return phase, err
 }
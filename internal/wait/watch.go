@@ -0,0 +1,330 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// errWatchFailed reports that the objects cannot be watched, and have to be polled instead.
+var errWatchFailed = errors.New("watch failed")
+
+// ForObject waits until condition returns true for the object of type T named name in namespace, an empty
+// namespace for cluster scoped kinds. The condition is evaluated on the current object, then again on every
+// change of the object, so the wait ends as soon as the object reaches the expected state. While the object
+// is not found, exists is false and object is nil. When the object cannot be watched, it is polled every
+// pollInterval instead. In dry-run mode the wait is skipped.
+func ForObject[T runtimeClient.Object](
+	ctx context.Context, apiClient *clients.Settings, name, namespace string,
+	condition func(object T, exists bool) (bool, error), pollInterval, timeout time.Duration) error {
+	options := metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String()}
+
+	return forObjects(ctx, apiClient, namespace, name, options, func(objects []T) (bool, error) {
+		if len(objects) == 0 {
+			var object T
+
+			return condition(object, false)
+		}
+
+		return condition(objects[0], true)
+	}, pollInterval, timeout)
+}
+
+// ForObjects waits until condition returns true for the objects of type T in namespace, every namespace when
+// empty, whose labels match selector. It behaves like ForObject, with condition evaluated on every object
+// matching selector, sorted by namespace and name.
+func ForObjects[T runtimeClient.Object](
+	ctx context.Context, apiClient *clients.Settings, namespace string, selector labels.Selector,
+	condition func(objects []T) (bool, error), pollInterval, timeout time.Duration) error {
+	options := metav1.ListOptions{}
+	if selector != nil {
+		options.LabelSelector = selector.String()
+	}
+
+	return forObjects(ctx, apiClient, namespace, "", options, condition, pollInterval, timeout)
+}
+
+func forObjects[T runtimeClient.Object](
+	ctx context.Context, apiClient *clients.Settings, namespace, name string, options metav1.ListOptions,
+	condition func(objects []T) (bool, error), pollInterval, timeout time.Duration) error {
+	if apiClient.IsDryRun() {
+		glog.V(100).Info("Dry-run: skipping wait")
+
+		return nil
+	}
+
+	watcher, err := newObjectWatcher[T](apiClient, namespace, name, options)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	glog.V(100).Infof("Watching %s for up to %s", watcher, timeout)
+
+	err = watcher.watch(ctx, condition)
+
+	if errors.Is(err, errWatchFailed) && ctx.Err() == nil {
+		glog.V(100).Infof("Polling %s every %s: %v", watcher, pollInterval, err)
+
+		err = watcher.poll(ctx, condition, pollInterval)
+	}
+
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("timed out waiting for %s: %w", watcher, ctx.Err())
+	}
+
+	return err
+}
+
+// objectWatcher lists and watches the objects of type T through the dynamic client.
+type objectWatcher[T runtimeClient.Object] struct {
+	resource  dynamic.ResourceInterface
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+	options   metav1.ListOptions
+	selector  labels.Selector
+	newObject func() T
+}
+
+func newObjectWatcher[T runtimeClient.Object](
+	apiClient *clients.Settings, namespace, name string, options metav1.ListOptions) (*objectWatcher[T], error) {
+	objectType := reflect.TypeOf((*T)(nil)).Elem()
+	if objectType.Kind() != reflect.Pointer {
+		return nil, fmt.Errorf("cannot wait for objects of non pointer type %s", objectType)
+	}
+
+	newObject := func() T {
+		return reflect.New(objectType.Elem()).Interface().(T)
+	}
+
+	gvks, _, err := apiClient.Client.Scheme().ObjectKinds(newObject())
+	if err != nil {
+		return nil, fmt.Errorf("cannot wait for objects of type %s: %w", objectType, err)
+	}
+
+	gvk := gvks[0]
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+
+	if mapping, err := apiClient.Client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+		gvr = mapping.Resource
+	}
+
+	selector, err := labels.Parse(options.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", options.LabelSelector, err)
+	}
+
+	var resource dynamic.ResourceInterface = apiClient.Resource(gvr)
+	if namespace != "" {
+		resource = apiClient.Resource(gvr).Namespace(namespace)
+	}
+
+	return &objectWatcher[T]{
+		resource:  resource,
+		gvk:       gvk,
+		namespace: namespace,
+		name:      name,
+		options:   options,
+		selector:  selector,
+		newObject: newObject,
+	}, nil
+}
+
+// String describes the watched objects.
+func (watcher *objectWatcher[T]) String() string {
+	description := watcher.gvk.Kind
+
+	if watcher.name != "" {
+		description += fmt.Sprintf(" '%s'", watcher.name)
+	} else if watcher.options.LabelSelector != "" {
+		description += fmt.Sprintf(" objects matching '%s'", watcher.options.LabelSelector)
+	}
+
+	if watcher.namespace != "" {
+		description += fmt.Sprintf(" in namespace '%s'", watcher.namespace)
+	}
+
+	return description
+}
+
+// watch evaluates condition on the listed objects, then on every change reported by a watch started from the
+// list's resourceVersion. The objects are listed again whenever the watch is closed or expires.
+func (watcher *objectWatcher[T]) watch(ctx context.Context, condition func(objects []T) (bool, error)) error {
+	for {
+		objects, resourceVersion, err := watcher.list(ctx)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errWatchFailed, err)
+		}
+
+		if done, err := evaluate(objects, condition); done || err != nil {
+			return err
+		}
+
+		options := watcher.options
+		options.ResourceVersion = resourceVersion
+		options.AllowWatchBookmarks = true
+
+		events, err := watcher.resource.Watch(ctx, options)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errWatchFailed, err)
+		}
+
+		done, err := watcher.consume(ctx, events, objects, condition)
+		events.Stop()
+
+		if done || err != nil {
+			return err
+		}
+
+		glog.V(100).Infof("Watch of %s closed, listing again", watcher)
+	}
+}
+
+// consume applies the events to objects and evaluates condition after every change. It returns false and no
+// error when the watch is closed or expired.
+func (watcher *objectWatcher[T]) consume(ctx context.Context, events watch.Interface, objects map[string]T,
+	condition func(objects []T) (bool, error)) (bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case event, ok := <-events.ResultChan():
+			if !ok {
+				return false, nil
+			}
+
+			switch event.Type {
+			case watch.Bookmark:
+				continue
+			case watch.Error:
+				err := apierrors.FromObject(event.Object)
+				if apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+					return false, nil
+				}
+
+				return false, fmt.Errorf("%w: %w", errWatchFailed, err)
+			}
+
+			item, ok := event.Object.(*unstructured.Unstructured)
+			if !ok || !watcher.matches(item) {
+				continue
+			}
+
+			key := item.GetNamespace() + "/" + item.GetName()
+
+			if event.Type == watch.Deleted {
+				delete(objects, key)
+			} else {
+				object, err := watcher.convert(item)
+				if err != nil {
+					return false, err
+				}
+
+				objects[key] = object
+			}
+
+			if done, err := evaluate(objects, condition); done || err != nil {
+				return done, err
+			}
+		}
+	}
+}
+
+// poll evaluates condition on the listed objects every pollInterval. Failing lists are retried.
+func (watcher *objectWatcher[T]) poll(ctx context.Context, condition func(objects []T) (bool, error),
+	pollInterval time.Duration) error {
+	return wait.PollUntilContextCancel(ctx, pollInterval, true, func(ctx context.Context) (bool, error) {
+		objects, _, err := watcher.list(ctx)
+		if err != nil {
+			glog.V(100).Infof("Failed to list %s: %v", watcher, err)
+
+			return false, nil
+		}
+
+		return evaluate(objects, condition)
+	})
+}
+
+// list returns the watched objects by namespace and name, with the resourceVersion of the list.
+func (watcher *objectWatcher[T]) list(ctx context.Context) (map[string]T, string, error) {
+	list, err := watcher.resource.List(ctx, watcher.options)
+	if err != nil {
+		return nil, "", err
+	}
+
+	objects := map[string]T{}
+
+	for index := range list.Items {
+		item := &list.Items[index]
+		if !watcher.matches(item) {
+			continue
+		}
+
+		object, err := watcher.convert(item)
+		if err != nil {
+			return nil, "", err
+		}
+
+		objects[item.GetNamespace()+"/"+item.GetName()] = object
+	}
+
+	return objects, list.GetResourceVersion(), nil
+}
+
+// matches reports whether item is watched. Selectors are also checked here, since not every client applies
+// them.
+func (watcher *objectWatcher[T]) matches(item *unstructured.Unstructured) bool {
+	if watcher.name != "" && item.GetName() != watcher.name {
+		return false
+	}
+
+	return watcher.selector.Matches(labels.Set(item.GetLabels()))
+}
+
+func (watcher *objectWatcher[T]) convert(item *unstructured.Unstructured) (T, error) {
+	object := watcher.newObject()
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, object); err != nil {
+		return object, fmt.Errorf("failed to convert %s '%s': %w", watcher.gvk.Kind, item.GetName(), err)
+	}
+
+	return object, nil
+}
+
+// evaluate calls condition with objects sorted by namespace and name.
+func evaluate[T runtimeClient.Object](objects map[string]T, condition func(objects []T) (bool, error)) (bool, error) {
+	keys := make([]string, 0, len(objects))
+	for key := range objects {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	sorted := make([]T, 0, len(keys))
+	for _, key := range keys {
+		sorted = append(sorted, objects[key])
+	}
+
+	return condition(sorted)
+}
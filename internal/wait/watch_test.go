@@ -0,0 +1,256 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	testNamespace    = "nvidia-network-operator"
+	testPollInterval = 10 * time.Millisecond
+	testTimeout      = 2 * time.Second
+)
+
+var podsResource = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func TestForObject(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pods          []runtime.Object
+		podName       string
+		update        *corev1.Pod
+		expectedError bool
+	}{
+		{
+			name:    "condition already met",
+			pods:    []runtime.Object{buildPod("rdma-server", corev1.PodRunning, nil)},
+			podName: "rdma-server",
+		},
+		{
+			name:    "condition met by an update",
+			pods:    []runtime.Object{buildPod("rdma-server", corev1.PodPending, nil)},
+			podName: "rdma-server",
+			update:  buildPod("rdma-server", corev1.PodRunning, nil),
+		},
+		{
+			name:    "object created while waiting",
+			podName: "rdma-server",
+			update:  buildPod("rdma-server", corev1.PodRunning, nil),
+		},
+		{
+			name:          "other object updated",
+			pods:          []runtime.Object{buildPod("rdma-server", corev1.PodPending, nil)},
+			podName:       "rdma-server",
+			update:        buildPod("rdma-client", corev1.PodRunning, nil),
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: testCase.pods})
+
+			if testCase.update != nil {
+				go applyAfter(t, apiClient, testCase.update, 50*time.Millisecond)
+			}
+
+			err := ForObject(context.TODO(), apiClient, testCase.podName, testNamespace,
+				func(pod *corev1.Pod, exists bool) (bool, error) {
+					return exists && pod.Status.Phase == corev1.PodRunning, nil
+				}, testPollInterval, 300*time.Millisecond)
+
+			if testCase.expectedError != (err != nil) {
+				t.Errorf("expected error %t, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestForObjectConditionError(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildPod("rdma-server", corev1.PodFailed, nil)},
+	})
+	conditionError := errors.New("pod failed")
+
+	err := ForObject(context.TODO(), apiClient, "rdma-server", testNamespace,
+		func(pod *corev1.Pod, exists bool) (bool, error) {
+			if exists && pod.Status.Phase == corev1.PodFailed {
+				return false, conditionError
+			}
+
+			return false, nil
+		}, testPollInterval, testTimeout)
+
+	if !errors.Is(err, conditionError) {
+		t.Errorf("expected the condition error, got %v", err)
+	}
+}
+
+func TestForObjectCancelled(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	err := ForObject(ctx, apiClient, "rdma-server", testNamespace,
+		func(pod *corev1.Pod, exists bool) (bool, error) {
+			return exists, nil
+		}, testPollInterval, testTimeout)
+
+	if err == nil {
+		t.Error("expected an error for a cancelled context")
+	}
+}
+
+func TestForObjectDryRun(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{})
+	if err := apiClient.EnableDryRun(t.TempDir()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := ForObject(context.TODO(), apiClient, "rdma-server", testNamespace,
+		func(pod *corev1.Pod, exists bool) (bool, error) {
+			t.Error("the condition must not be evaluated in dry-run mode")
+
+			return false, nil
+		}, testPollInterval, testTimeout)
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestForObjects(t *testing.T) {
+	workload := map[string]string{"app": "rdma"}
+	apiClient := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+		buildPod("rdma-server", corev1.PodRunning, workload),
+		buildPod("rdma-client", corev1.PodPending, workload),
+		buildPod("other", corev1.PodPending, nil),
+	}})
+
+	go applyAfter(t, apiClient, buildPod("rdma-client", corev1.PodRunning, workload), 50*time.Millisecond)
+
+	var names []string
+
+	err := ForObjects(context.TODO(), apiClient, testNamespace, labels.SelectorFromSet(workload),
+		func(pods []*corev1.Pod) (bool, error) {
+			names = names[:0]
+
+			for _, pod := range pods {
+				if pod.Status.Phase != corev1.PodRunning {
+					return false, nil
+				}
+
+				names = append(names, pod.Name)
+			}
+
+			return true, nil
+		}, testPollInterval, testTimeout)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(names) != 2 || names[0] != "rdma-client" || names[1] != "rdma-server" {
+		t.Errorf("expected the selected pods sorted by name, got %v", names)
+	}
+}
+
+func TestPodCompleted(t *testing.T) {
+	testCases := []struct {
+		name          string
+		phase         corev1.PodPhase
+		expectedPhase corev1.PodPhase
+		expectedError bool
+	}{
+		{name: "succeeded", phase: corev1.PodSucceeded, expectedPhase: corev1.PodSucceeded},
+		{name: "failed", phase: corev1.PodFailed, expectedPhase: corev1.PodFailed},
+		{name: "still running", phase: corev1.PodRunning, expectedPhase: corev1.PodRunning, expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient := clients.GetTestClients(clients.TestClientParams{
+				K8sMockObjects: []runtime.Object{buildPod("rdma-client", testCase.phase, nil)},
+			})
+
+			phase, err := PodCompleted(apiClient, "rdma-client", testNamespace, testPollInterval,
+				100*time.Millisecond)
+
+			if testCase.expectedError != (err != nil) {
+				t.Errorf("expected error %t, got %v", testCase.expectedError, err)
+			}
+
+			if phase != testCase.expectedPhase {
+				t.Errorf("expected phase %s, got %s", testCase.expectedPhase, phase)
+			}
+		})
+	}
+}
+
+func TestPodRunning(t *testing.T) {
+	testCases := []struct {
+		name          string
+		phase         corev1.PodPhase
+		expectedError bool
+	}{
+		{name: "running", phase: corev1.PodRunning},
+		{name: "terminated", phase: corev1.PodFailed, expectedError: true},
+		{name: "pending", phase: corev1.PodPending, expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient := clients.GetTestClients(clients.TestClientParams{
+				K8sMockObjects: []runtime.Object{buildPod("rdma-server", testCase.phase, nil)},
+			})
+
+			err := PodRunning(apiClient, "rdma-server", testNamespace, testPollInterval, 100*time.Millisecond)
+
+			if testCase.expectedError != (err != nil) {
+				t.Errorf("expected error %t, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func buildPod(name string, phase corev1.PodPhase, podLabels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: podLabels},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+// applyAfter creates or updates pod through the dynamic client watched by the waiters, after delay.
+func applyAfter(t *testing.T, apiClient *clients.Settings, pod *corev1.Pod, delay time.Duration) {
+	time.Sleep(delay)
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+
+		return
+	}
+
+	resource := apiClient.Resource(podsResource).Namespace(testNamespace)
+	object := &unstructured.Unstructured{Object: content}
+
+	if _, err := resource.Update(context.TODO(), object, metav1.UpdateOptions{}); err == nil {
+		return
+	}
+
+	if _, err := resource.Create(context.TODO(), object, metav1.CreateOptions{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
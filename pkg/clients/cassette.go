@@ -0,0 +1,270 @@
+package clients
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/redact"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// replayHost is the API server address used by clients replaying a cassette.
+const replayHost = "http://replay.invalid"
+
+// Interaction is a single API request and its response stored in a cassette.
+type Interaction struct {
+	Method       string      `json:"method"`
+	URI          string      `json:"uri"`
+	RequestBody  string      `json:"requestBody,omitempty"`
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header,omitempty"`
+	ResponseBody string      `json:"responseBody,omitempty"`
+}
+
+// Cassette records API interactions into a JSON lines file, or replays them from it.
+type Cassette struct {
+	mutex        sync.Mutex
+	path         string
+	interactions map[string][]Interaction
+	served       map[string]int
+}
+
+// NewRecordingCassette returns a Cassette appending every recorded interaction to the file at path.
+func NewRecordingCassette(path string) (*Cassette, error) {
+	glog.V(100).Infof("Recording API interactions into cassette %s", path)
+
+	if err := os.WriteFile(path, nil, 0666); err != nil {
+		return nil, fmt.Errorf("failed to create cassette %s: %w", path, err)
+	}
+
+	return &Cassette{path: path}, nil
+}
+
+// LoadCassette returns a Cassette replaying the interactions stored in the file at path.
+func LoadCassette(path string) (*Cassette, error) {
+	glog.V(100).Infof("Loading API interactions from cassette %s", path)
+
+	cassetteFile, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cassette %s: %w", path, err)
+	}
+
+	defer func() {
+		_ = cassetteFile.Close()
+	}()
+
+	cassette := &Cassette{
+		path:         path,
+		interactions: map[string][]Interaction{},
+		served:       map[string]int{},
+	}
+
+	scanner := bufio.NewScanner(cassetteFile)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+
+	for scanner.Scan() {
+		var interaction Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+
+		key := interactionKey(interaction.Method, interaction.URI)
+		cassette.interactions[key] = append(cassette.interactions[key], interaction)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cassette %s: %w", path, err)
+	}
+
+	return cassette, nil
+}
+
+// NewRecording returns a *Settings like New, whose API interactions are all recorded into cassette.
+func NewRecording(kubeconfig string, cassette *Cassette) *Settings {
+	if kubeconfig == "" {
+		kubeconfig = os.Getenv("KUBECONFIG")
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		log.Printf("Error to load kube client config for recording: %v", err)
+
+		return nil
+	}
+
+	config.Wrap(cassette.record)
+
+	return newForConfig(config, kubeconfig)
+}
+
+// NewReplay returns a *Settings serving every API request from cassette, without any cluster.
+func NewReplay(cassette *Cassette) *Settings {
+	config := &rest.Config{Host: replayHost, Transport: cassette}
+
+	return newForConfig(config, "")
+}
+
+// record is a rest.Config WrapTransport function recording the interactions of delegate.
+func (cassette *Cassette) record(delegate http.RoundTripper) http.RoundTripper {
+	return recordingRoundTripper{delegate: delegate, cassette: cassette}
+}
+
+type recordingRoundTripper struct {
+	delegate http.RoundTripper
+	cassette *Cassette
+}
+
+// RoundTrip implements http.RoundTripper.
+func (transport recordingRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	// Watches, followed logs and upgraded exec or attach sessions are streamed for the lifetime of the
+	// connection and cannot be recorded.
+	if isStreamedRequest(request) {
+		return transport.delegate.RoundTrip(request)
+	}
+
+	interaction := Interaction{Method: request.Method, URI: request.URL.RequestURI()}
+
+	if request.Body != nil && request.GetBody != nil {
+		if body, err := request.GetBody(); err == nil {
+			requestBody, _ := io.ReadAll(body)
+			interaction.RequestBody = string(requestBody)
+		}
+	}
+
+	response, err := transport.delegate.RoundTrip(request)
+	if err != nil {
+		return response, err
+	}
+
+	if isStreamedResponse(response) {
+		glog.V(100).Infof("Not recording streamed response of %s %s", interaction.Method, interaction.URI)
+
+		return response, nil
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	if err != nil {
+		return response, nil
+	}
+
+	interaction.StatusCode = response.StatusCode
+	interaction.Header = response.Header
+	interaction.ResponseBody = string(responseBody)
+
+	if err := transport.cassette.append(interaction); err != nil {
+		glog.V(100).Infof("Failed to record %s %s into cassette: %v", interaction.Method, interaction.URI, err)
+	}
+
+	return response, nil
+}
+
+// isStreamedRequest reports whether the response of request is a stream: a watch, followed logs or a connection
+// upgraded for exec, attach or port-forward.
+func isStreamedRequest(request *http.Request) bool {
+	query := request.URL.Query()
+
+	return query.Get("watch") == "true" || query.Get("follow") == "true" ||
+		httpstream.IsUpgradeRequest(request)
+}
+
+// isStreamedResponse reports whether response is a stream of unknown length, reading it would block until the
+// server closes it. API objects are JSON and are recorded even when chunked.
+func isStreamedResponse(response *http.Response) bool {
+	if response.StatusCode == http.StatusSwitchingProtocols {
+		return true
+	}
+
+	if response.ContentLength >= 0 {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+
+	return err != nil || mediaType != "application/json"
+}
+
+func (cassette *Cassette) append(interaction Interaction) error {
+	interaction.RequestBody = redact.String(interaction.RequestBody)
+	interaction.ResponseBody = redact.String(interaction.ResponseBody)
+
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+
+	cassette.mutex.Lock()
+	defer cassette.mutex.Unlock()
+
+	cassetteFile, err := os.OpenFile(cassette.path, os.O_APPEND|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = cassetteFile.Close()
+	}()
+
+	_, err = cassetteFile.Write(append(line, '\n'))
+
+	return err
+}
+
+// RoundTrip implements http.RoundTripper by replaying the recorded interactions for the request's method and URI
+// in the order they were recorded. Once exhausted, the last one keeps being served. Requests that were never
+// recorded get a NotFound response.
+func (cassette *Cassette) RoundTrip(request *http.Request) (*http.Response, error) {
+	key := interactionKey(request.Method, request.URL.RequestURI())
+
+	cassette.mutex.Lock()
+	recorded := cassette.interactions[key]
+	index := cassette.served[key]
+
+	if index < len(recorded)-1 {
+		cassette.served[key]++
+	}
+	cassette.mutex.Unlock()
+
+	if len(recorded) == 0 {
+		glog.V(100).Infof("No recorded interaction for %s, replying NotFound", key)
+
+		return replayResponse(request, http.StatusNotFound, http.Header{"Content-Type": {"application/json"}},
+			fmt.Sprintf(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404,`+
+				`"message":"no recorded interaction for %s"}`, key)), nil
+	}
+
+	interaction := recorded[index]
+
+	return replayResponse(request, interaction.StatusCode, interaction.Header, interaction.ResponseBody), nil
+}
+
+func replayResponse(request *http.Request, statusCode int, header http.Header, body string) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(body))),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}
+}
+
+func interactionKey(method, uri string) string {
+	return method + " " + uri
+}
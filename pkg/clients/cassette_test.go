@@ -0,0 +1,156 @@
+package clients
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// staticRoundTripper answers every request with a copy of response.
+type staticRoundTripper struct {
+	response *http.Response
+	body     string
+	calls    int
+}
+
+func (transport *staticRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	transport.calls++
+
+	response := *transport.response
+	response.Header = transport.response.Header.Clone()
+	response.Body = io.NopCloser(strings.NewReader(transport.body))
+	response.Request = request
+
+	return &response, nil
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	testCases := []struct {
+		name           string
+		url            string
+		header         http.Header
+		statusCode     int
+		contentType    string
+		contentLength  int64
+		expectRecorded bool
+	}{
+		{name: "object", url: "/api/v1/namespaces/default", statusCode: http.StatusOK,
+			contentType: "application/json", contentLength: 2, expectRecorded: true},
+		{name: "chunked list", url: "/api/v1/pods", statusCode: http.StatusOK,
+			contentType: "application/json; charset=utf-8", contentLength: -1, expectRecorded: true},
+		{name: "watch", url: "/api/v1/pods?watch=true", statusCode: http.StatusOK,
+			contentType: "application/json", contentLength: -1, expectRecorded: false},
+		{name: "followed logs", url: "/api/v1/namespaces/default/pods/a/log?follow=true",
+			statusCode: http.StatusOK, contentType: "text/plain", contentLength: -1, expectRecorded: false},
+		{name: "streamed logs", url: "/api/v1/namespaces/default/pods/a/log", statusCode: http.StatusOK,
+			contentType: "text/plain", contentLength: -1, expectRecorded: false},
+		{name: "exec", url: "/api/v1/namespaces/default/pods/a/exec?command=ls",
+			header:     http.Header{"Connection": {"Upgrade"}, "Upgrade": {"SPDY/3.1"}},
+			statusCode: http.StatusSwitchingProtocols, contentLength: -1, expectRecorded: false},
+	}
+
+	for _, testCase := range testCases {
+		cassettePath := filepath.Join(t.TempDir(), "cassette.jsonl")
+
+		cassette, err := NewRecordingCassette(cassettePath)
+		if err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+
+		delegate := &staticRoundTripper{
+			response: &http.Response{
+				StatusCode:    testCase.statusCode,
+				Header:        http.Header{"Content-Type": {testCase.contentType}},
+				ContentLength: testCase.contentLength,
+			},
+			body: "{}",
+		}
+
+		request, err := http.NewRequest(http.MethodGet, "https://apiserver"+testCase.url, http.NoBody)
+		if err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+
+		for key, values := range testCase.header {
+			request.Header[key] = values
+		}
+
+		response, err := cassette.record(delegate).RoundTrip(request)
+		if err != nil {
+			t.Fatalf("%s: unexpected round trip error %v", testCase.name, err)
+		}
+
+		if body, _ := io.ReadAll(response.Body); string(body) != "{}" {
+			t.Errorf("%s: expected the response body to stay readable, got %q", testCase.name, body)
+		}
+
+		content, err := os.ReadFile(cassettePath)
+		if err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+
+		if recorded := len(content) > 0; recorded != testCase.expectRecorded {
+			t.Fatalf("%s: expected recorded %t, got cassette %q", testCase.name, testCase.expectRecorded, content)
+		}
+
+		if !testCase.expectRecorded {
+			continue
+		}
+
+		replay, err := LoadCassette(cassettePath)
+		if err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+
+		replayed, err := replay.RoundTrip(request)
+		if err != nil || replayed.StatusCode != testCase.statusCode {
+			t.Errorf("%s: unexpected replayed response %v, %v", testCase.name, replayed, err)
+		}
+	}
+}
+
+func TestCassetteReplayOrder(t *testing.T) {
+	cassettePath := filepath.Join(t.TempDir(), "cassette.jsonl")
+	content := `{"method":"GET","uri":"/api/v1/pods","statusCode":200,"responseBody":"first"}
+{"method":"GET","uri":"/api/v1/pods","statusCode":200,"responseBody":"second"}
+`
+
+	if err := os.WriteFile(cassettePath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cassette, err := LoadCassette(cassettePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		uri          string
+		expectedCode int
+		expectedBody string
+	}{
+		{uri: "/api/v1/pods", expectedCode: http.StatusOK, expectedBody: "first"},
+		{uri: "/api/v1/pods", expectedCode: http.StatusOK, expectedBody: "second"},
+		{uri: "/api/v1/pods", expectedCode: http.StatusOK, expectedBody: "second"},
+		{uri: "/api/v1/nodes", expectedCode: http.StatusNotFound},
+	}
+
+	for _, testCase := range testCases {
+		request, _ := http.NewRequest(http.MethodGet, replayHost+testCase.uri, http.NoBody)
+
+		response, err := cassette.RoundTrip(request)
+		if err != nil {
+			t.Fatalf("unexpected replay error %v", err)
+		}
+
+		body, _ := io.ReadAll(response.Body)
+		if response.StatusCode != testCase.expectedCode ||
+			(testCase.expectedBody != "" && string(body) != testCase.expectedBody) {
+			t.Errorf("%s: expected %d %q, got %d %q", testCase.uri, testCase.expectedCode, testCase.expectedBody,
+				response.StatusCode, body)
+		}
+	}
+}
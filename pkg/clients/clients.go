@@ -39,6 +39,8 @@ import (
 	machinev1beta1client "github.com/openshift/client-go/machine/clientset/versioned/typed/machine/v1beta1"
 	operatorv1alpha1 "github.com/openshift/client-go/operator/clientset/versioned/typed/operator/v1alpha1"
 	nfdv1 "github.com/openshift/cluster-nfd-operator/api/v1"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm/ocv1"
 )
 
 // Settings provides the struct to talk with relevant API.
@@ -57,6 +59,8 @@ type Settings struct {
 	PackageManifestInterface clientPkgManifestV1.OperatorsV1Interface
 	operatorv1alpha1.OperatorV1alpha1Interface
 	machinev1beta1client.MachineV1beta1Interface
+	retry  *retryState
+	dryRun *dryRunState
 }
 
 // New returns a *Settings with the given kubeconfig.
@@ -86,7 +90,38 @@ func New(kubeconfig string) *Settings {
 		return nil
 	}
 
-	clientSet := &Settings{}
+	return newForConfig(config, kubeconfig)
+}
+
+// NewForKubeContext returns a *Settings with the given kubeconfig, using kubeContext instead of the
+// kubeconfig's current context. An empty kubeContext falls back to the current context.
+func NewForKubeContext(kubeconfig, kubeContext string) *Settings {
+	if kubeContext == "" {
+		return New(kubeconfig)
+	}
+
+	log.Printf("Loading kube client config from path %q with context %q", kubeconfig, kubeContext)
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+		&clientcmd.ConfigOverrides{CurrentContext: kubeContext}).ClientConfig()
+
+	if err != nil {
+		log.Printf("Error to load kube client config for context %q: %v", kubeContext, err)
+
+		return nil
+	}
+
+	return newForConfig(config, kubeconfig)
+}
+
+// newForConfig builds all clients of a *Settings from the given rest config.
+func newForConfig(config *rest.Config, kubeconfig string) *Settings {
+	var err error
+
+	clientSet := &Settings{retry: newRetryState()}
+	config.Wrap(clientSet.retry.wrap)
+
 	clientSet.CoreV1Interface = coreV1Client.NewForConfigOrDie(config)
 	clientSet.ConfigV1Interface = clientConfigV1.NewForConfigOrDie(config)
 	clientSet.AppsV1Interface = appsV1Client.NewForConfigOrDie(config)
@@ -164,6 +199,10 @@ func SetScheme(crScheme *runtime.Scheme) error {
 		return err
 	}
 
+	if err := ocv1.AddToScheme(crScheme); err != nil {
+		return err
+	}
+
 	return nil
 }
 
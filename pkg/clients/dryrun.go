@@ -0,0 +1,95 @@
+package clients
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/redact"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DryRunCreate is the dry-run action recorded for object creation.
+	DryRunCreate = "create"
+	// DryRunUpdate is the dry-run action recorded for object update.
+	DryRunUpdate = "update"
+	// DryRunDelete is the dry-run action recorded for object deletion.
+	DryRunDelete = "delete"
+)
+
+// dryRunState keeps the location and sequence of the recorded dry-run mutations.
+type dryRunState struct {
+	mutex   sync.Mutex
+	planDir string
+	counter int
+}
+
+// EnableDryRun switches the Settings to plan-only mode: builders record their mutations as YAML manifests
+// in planDir instead of applying them.
+func (settings *Settings) EnableDryRun(planDir string) error {
+	if settings == nil {
+		return fmt.Errorf("APIClient cannot be nil")
+	}
+
+	glog.V(100).Infof("Enabling dry-run, mutations are recorded in %s", planDir)
+
+	if err := os.MkdirAll(planDir, 0777); err != nil {
+		return fmt.Errorf("failed to create dry-run plan directory %s: %w", planDir, err)
+	}
+
+	settings.dryRun = &dryRunState{planDir: planDir}
+
+	return nil
+}
+
+// IsDryRun returns true if the Settings is in plan-only mode.
+func (settings *Settings) IsDryRun() bool {
+	return settings != nil && settings.dryRun != nil
+}
+
+// RecordDryRun writes the intended mutation of object as a YAML manifest into the dry-run plan directory.
+func (settings *Settings) RecordDryRun(action string, object runtimeClient.Object) error {
+	if !settings.IsDryRun() {
+		return fmt.Errorf("cannot record %s of %s: dry-run is not enabled", action, object.GetName())
+	}
+
+	kind := "object"
+
+	if settings.Client != nil {
+		if gvk, err := apiutil.GVKForObject(object, settings.Client.Scheme()); err == nil {
+			object = object.DeepCopyObject().(runtimeClient.Object)
+			object.GetObjectKind().SetGroupVersionKind(gvk)
+			kind = gvk.Kind
+		}
+	}
+
+	manifest, err := yaml.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dry-run %s of %s %s: %w", action, kind, object.GetName(), err)
+	}
+
+	settings.dryRun.mutex.Lock()
+	defer settings.dryRun.mutex.Unlock()
+
+	settings.dryRun.counter++
+
+	nameParts := []string{fmt.Sprintf("%03d", settings.dryRun.counter), action, strings.ToLower(kind)}
+	if object.GetNamespace() != "" {
+		nameParts = append(nameParts, object.GetNamespace())
+	}
+
+	nameParts = append(nameParts, object.GetName())
+	planFile := filepath.Join(settings.dryRun.planDir, strings.Join(nameParts, "_")+".yaml")
+
+	glog.V(100).Infof("Dry-run: recording %s of %s %s in %s", action, kind, object.GetName(), planFile)
+
+	content := append([]byte(fmt.Sprintf("# dry-run action: %s\n", action)), manifest...)
+
+	return os.WriteFile(planFile, redact.Bytes(content), 0666)
+}
@@ -0,0 +1,147 @@
+package clients
+
+import (
+	"log"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	k8sFake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8sTesting "k8s.io/client-go/testing"
+	runtimeFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configV1Fake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	machineFake "github.com/openshift/client-go/machine/clientset/versioned/fake"
+	operatorFake "github.com/openshift/client-go/operator/clientset/versioned/fake"
+	securityFake "github.com/openshift/client-go/security/clientset/versioned/fake"
+	olm2 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/scheme"
+	olmv1Fake "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/typed/operators/v1/fake"
+	olmFake "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/typed/operators/v1alpha1/fake"
+	pkgManifestFake "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/client/clientset/versioned/fake"
+)
+
+// dynamicListKinds are the list kinds of the resources read with the dynamic client whose types are not
+// registered in the scheme.
+var dynamicListKinds = map[schema.GroupVersionResource]string{
+	schema.GroupVersionResource{Group: "k8s.cni.cncf.io", Version: "v1",
+		Resource: "network-attachment-definitions"}: "NetworkAttachmentDefinitionList",
+	schema.GroupVersionResource{Group: "whereabouts.cni.cncf.io", Version: "v1alpha1",
+		Resource: "ippools"}: "IPPoolList",
+	schema.GroupVersionResource{Group: "whereabouts.cni.cncf.io", Version: "v1alpha1",
+		Resource: "overlappingrangeipreservations"}: "OverlappingRangeIPReservationList",
+}
+
+// TestClientParams provides the objects used to seed the fake clients returned by GetTestClients.
+type TestClientParams struct {
+	// K8sMockObjects are added to every fake client whose scheme recognizes their kind.
+	K8sMockObjects []runtime.Object
+}
+
+// GetTestClients returns a *Settings whose clients are all backed by in-memory fakes
+// seeded with the given objects. It is intended for unit testing builders without a cluster.
+//
+//nolint:funlen
+func GetTestClients(tcp TestClientParams) *Settings {
+	crScheme := runtime.NewScheme()
+
+	if err := SetScheme(crScheme); err != nil {
+		log.Print("Error to load fake apiClient scheme")
+
+		return nil
+	}
+
+	k8sClientSet := k8sFake.NewSimpleClientset(
+		objectsForScheme(k8sFake.AddToScheme, tcp.K8sMockObjects)...)
+	configClientSet := configV1Fake.NewSimpleClientset(
+		objectsForScheme(configV1Fake.AddToScheme, tcp.K8sMockObjects)...)
+	olmFakeClient := newFakeWithTracker(olm2.AddToScheme,
+		objectsForScheme(olm2.AddToScheme, tcp.K8sMockObjects))
+	pkgManifestClientSet := pkgManifestFake.NewSimpleClientset(
+		objectsForScheme(pkgManifestFake.AddToScheme, tcp.K8sMockObjects)...)
+	securityClientSet := securityFake.NewSimpleClientset(
+		objectsForScheme(securityFake.AddToScheme, tcp.K8sMockObjects)...)
+	operatorClientSet := operatorFake.NewSimpleClientset(
+		objectsForScheme(operatorFake.AddToScheme, tcp.K8sMockObjects)...)
+	machineClientSet := machineFake.NewSimpleClientset(
+		objectsForScheme(machineFake.AddToScheme, tcp.K8sMockObjects)...)
+
+	runtimeObjects := objectsForScheme(SetScheme, tcp.K8sMockObjects)
+
+	clientSet := &Settings{}
+	clientSet.K8sClient = k8sClientSet
+	clientSet.CoreV1Interface = k8sClientSet.CoreV1()
+	clientSet.AppsV1Interface = k8sClientSet.AppsV1()
+	clientSet.ConfigV1Interface = configClientSet.ConfigV1()
+	clientSet.OperatorsV1alpha1Interface = &olmFake.FakeOperatorsV1alpha1{Fake: olmFakeClient}
+	clientSet.OperatorsV1Interface = &olmv1Fake.FakeOperatorsV1{Fake: olmFakeClient}
+	clientSet.PackageManifestInterface = pkgManifestClientSet.OperatorsV1()
+	clientSet.SecurityV1Interface = securityClientSet.SecurityV1()
+	clientSet.OperatorV1alpha1Interface = operatorClientSet.OperatorV1alpha1()
+	clientSet.MachineV1beta1Interface = machineClientSet.MachineV1beta1()
+	clientSet.Interface = dynamicFake.NewSimpleDynamicClientWithCustomListKinds(
+		crScheme, dynamicListKinds, runtimeObjects...)
+	clientSet.Client = runtimeFake.NewClientBuilder().
+		WithScheme(crScheme).
+		WithRuntimeObjects(runtimeObjects...).
+		Build()
+	clientSet.Config = &rest.Config{Host: "fake"}
+
+	return clientSet
+}
+
+// objectsForScheme returns the subset of objects whose kind is registered by addToScheme.
+func objectsForScheme(addToScheme func(*runtime.Scheme) error, objects []runtime.Object) []runtime.Object {
+	clientScheme := runtime.NewScheme()
+
+	if err := addToScheme(clientScheme); err != nil {
+		log.Print("Error to load fake client scheme")
+
+		return nil
+	}
+
+	var recognized []runtime.Object
+
+	for _, object := range objects {
+		if gvks, _, err := clientScheme.ObjectKinds(object); err == nil && len(gvks) > 0 {
+			recognized = append(recognized, object)
+		}
+	}
+
+	return recognized
+}
+
+// newFakeWithTracker returns a *k8sTesting.Fake serving the given objects from an in-memory tracker.
+// It is used for the OLM typed clients, whose generated fake clientset pulls in apiserver dependencies.
+func newFakeWithTracker(addToScheme func(*runtime.Scheme) error, objects []runtime.Object) *k8sTesting.Fake {
+	clientScheme := runtime.NewScheme()
+
+	if err := addToScheme(clientScheme); err != nil {
+		log.Print("Error to load fake client scheme")
+
+		return nil
+	}
+
+	tracker := k8sTesting.NewObjectTracker(clientScheme, serializer.NewCodecFactory(clientScheme).UniversalDecoder())
+
+	for _, object := range objects {
+		if err := tracker.Add(object); err != nil {
+			log.Printf("Error to add object to fake client tracker: %v", err)
+		}
+	}
+
+	fakeClient := &k8sTesting.Fake{}
+	fakeClient.AddReactor("*", "*", k8sTesting.ObjectReaction(tracker))
+	fakeClient.AddWatchReactor("*", func(action k8sTesting.Action) (bool, watch.Interface, error) {
+		watcher, err := tracker.Watch(action.GetResource(), action.GetNamespace())
+		if err != nil {
+			return false, nil, err
+		}
+
+		return true, watcher, nil
+	})
+
+	return fakeClient
+}
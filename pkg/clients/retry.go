@@ -0,0 +1,316 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// RetryPolicy defines how API requests failing with transient errors are retried.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries per request. Zero disables retries.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponentially growing delay between retries.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the RetryPolicy applied to clients created by New.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     5,
+	InitialBackoff: time.Second,
+	MaxBackoff:     30 * time.Second,
+}
+
+// RetryStats holds the number of retried API requests per transient error reason.
+type RetryStats struct {
+	Total    int            `json:"total"`
+	ByReason map[string]int `json:"byReason,omitempty"`
+}
+
+// String returns a human readable summary of the retry stats.
+func (stats RetryStats) String() string {
+	if stats.Total == 0 {
+		return "no retries"
+	}
+
+	reasons := make([]string, 0, len(stats.ByReason))
+	for reason, count := range stats.ByReason {
+		reasons = append(reasons, fmt.Sprintf("%s=%d", reason, count))
+	}
+
+	sort.Strings(reasons)
+
+	return fmt.Sprintf("%d retries (%s)", stats.Total, strings.Join(reasons, ", "))
+}
+
+// retryState keeps the retry policy and stats shared by all round trippers of a Settings.
+type retryState struct {
+	mutex  sync.Mutex
+	policy RetryPolicy
+	stats  RetryStats
+}
+
+// retryRoundTripper is an http.RoundTripper retrying requests that failed with a transient error.
+type retryRoundTripper struct {
+	delegate http.RoundTripper
+	state    *retryState
+}
+
+// SetRetryPolicy replaces the retry policy used by all clients of the Settings.
+func (settings *Settings) SetRetryPolicy(policy RetryPolicy) {
+	if settings == nil || settings.retry == nil {
+		return
+	}
+
+	glog.V(100).Infof("Setting API retry policy: max retries %d, initial backoff %s, max backoff %s",
+		policy.MaxRetries, policy.InitialBackoff, policy.MaxBackoff)
+
+	settings.retry.mutex.Lock()
+	defer settings.retry.mutex.Unlock()
+
+	settings.retry.policy = policy
+}
+
+// GetRetryStats returns the number of API requests retried so far by the clients of the Settings.
+func (settings *Settings) GetRetryStats() RetryStats {
+	stats := RetryStats{ByReason: map[string]int{}}
+
+	if settings == nil || settings.retry == nil {
+		return stats
+	}
+
+	settings.retry.mutex.Lock()
+	defer settings.retry.mutex.Unlock()
+
+	stats.Total = settings.retry.stats.Total
+	for reason, count := range settings.retry.stats.ByReason {
+		stats.ByReason[reason] = count
+	}
+
+	return stats
+}
+
+// RoundTrip implements http.RoundTripper.
+func (transport *retryRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	policy := transport.state.getPolicy()
+	backoff := policy.InitialBackoff
+
+	for attempt := 0; ; attempt++ {
+		response, err := transport.delegate.RoundTrip(request)
+
+		reason := transientReason(request, response, err)
+		if reason == "" || attempt >= policy.MaxRetries || !canRewind(request) {
+			return response, err
+		}
+
+		// The apiserver asks throttled clients to wait at least Retry-After before retrying.
+		delay := backoff
+		if retryAfter := retryAfterDelay(response); retryAfter > delay {
+			delay = retryAfter
+		}
+
+		glog.V(100).Infof("Retrying %s %s after transient error %s (attempt %d of %d, backoff %s)",
+			request.Method, request.URL.Path, reason, attempt+1, policy.MaxRetries, delay)
+
+		transport.state.record(reason)
+
+		if response != nil {
+			_, _ = io.Copy(io.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+
+		select {
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		case <-time.After(delay):
+		}
+
+		if request.GetBody != nil {
+			if request.Body, err = request.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+
+		backoff *= 2
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+func (state *retryState) getPolicy() RetryPolicy {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	return state.policy
+}
+
+func (state *retryState) record(reason string) {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	state.stats.Total++
+	state.stats.ByReason[reason]++
+}
+
+// newRetryState returns a retryState using DefaultRetryPolicy.
+func newRetryState() *retryState {
+	return &retryState{policy: DefaultRetryPolicy, stats: RetryStats{ByReason: map[string]int{}}}
+}
+
+// wrap is a rest.Config WrapTransport function installing a retryRoundTripper in front of delegate.
+func (state *retryState) wrap(delegate http.RoundTripper) http.RoundTripper {
+	return &retryRoundTripper{delegate: delegate, state: state}
+}
+
+// transientReason classifies the outcome of a request and returns the transient error reason,
+// or an empty string if the request must not be retried. A POST, PATCH or DELETE failing after it reached the
+// apiserver may already be applied, so these requests are only replayed when the apiserver throttled them or
+// when the connection could not be established.
+func transientReason(request *http.Request, response *http.Response, err error) string {
+	if request.Context().Err() != nil {
+		return ""
+	}
+
+	if err != nil {
+		reason := TransientErrorReason(err)
+		if reason != "" && !isIdempotent(request) && !isDialError(err) {
+			glog.V(100).Infof("Not retrying %s %s after transient error %s, it may have been applied",
+				request.Method, request.URL.Path, reason)
+
+			return ""
+		}
+
+		return reason
+	}
+
+	if response == nil || response.StatusCode < http.StatusBadRequest {
+		return ""
+	}
+
+	// Watches and long running requests must not be replayed.
+	if request.URL.Query().Get("watch") == "true" {
+		return ""
+	}
+
+	reason := TransientErrorReason(responseError(request, response))
+	if reason != "" && !isIdempotent(request) && response.StatusCode != http.StatusTooManyRequests {
+		glog.V(100).Infof("Not retrying %s %s after transient error %s, it may have been applied",
+			request.Method, request.URL.Path, reason)
+
+		return ""
+	}
+
+	return reason
+}
+
+// isIdempotent reports whether replaying the request cannot apply it twice.
+func isIdempotent(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut:
+		return true
+	}
+
+	return false
+}
+
+// isDialError reports whether err happened while establishing the connection, before the request was sent.
+func isDialError(err error) bool {
+	var opError *net.OpError
+
+	return errors.As(err, &opError) && opError.Op == "dial"
+}
+
+// responseError returns the API error carried by a failed response while keeping its body readable.
+func responseError(request *http.Request, response *http.Response) error {
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err == nil {
+		status := metav1.Status{}
+		if json.Unmarshal(body, &status) == nil && status.Kind == "Status" {
+			return &k8serrors.StatusError{ErrStatus: status}
+		}
+	}
+
+	return k8serrors.NewGenericServerResponse(
+		response.StatusCode, request.Method, schema.GroupResource{}, "", string(body), 0, false)
+}
+
+// TransientErrorReason returns the reason of a transient API error worth retrying, such as
+// apiserver throttling, unavailability, timeouts, or reset connections. It returns an empty
+// string for any other error.
+func TransientErrorReason(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case k8serrors.IsTooManyRequests(err):
+		return "TooManyRequests"
+	case k8serrors.IsServiceUnavailable(err):
+		return "ServiceUnavailable"
+	case k8serrors.IsServerTimeout(err):
+		return "ServerTimeout"
+	case k8serrors.IsTimeout(err):
+		return "Timeout"
+	case k8serrors.IsInternalError(err) && strings.Contains(err.Error(), "etcdserver"):
+		return "EtcdLeaderChange"
+	case utilnet.IsConnectionReset(err):
+		return "ConnectionReset"
+	case utilnet.IsConnectionRefused(err):
+		return "ConnectionRefused"
+	case utilnet.IsProbableEOF(err):
+		return "EOF"
+	}
+
+	return ""
+}
+
+// retryAfterDelay returns the delay requested by the Retry-After header of response, in seconds or as an HTTP
+// date, or zero when the response has no valid Retry-After header.
+func retryAfterDelay(response *http.Response) time.Duration {
+	if response == nil {
+		return 0
+	}
+
+	retryAfter := strings.TrimSpace(response.Header.Get("Retry-After"))
+	if retryAfter == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(retryAfter); err == nil && date.After(time.Now()) {
+		return time.Until(date)
+	}
+
+	return 0
+}
+
+// canRewind reports whether the request body can be replayed.
+func canRewind(request *http.Request) bool {
+	return request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+}
@@ -0,0 +1,226 @@
+package clients
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// scriptedRoundTripper returns the scripted outcomes in order, then 200 OK.
+type scriptedRoundTripper struct {
+	outcomes []func() (*http.Response, error)
+	calls    int
+}
+
+func (transport *scriptedRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	transport.calls++
+
+	if transport.calls <= len(transport.outcomes) {
+		return transport.outcomes[transport.calls-1]()
+	}
+
+	return statusResponse(http.StatusOK, "{}"), nil
+}
+
+func statusResponse(code int, body string) *http.Response {
+	return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}}
+}
+
+func withStatus(code int) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		return statusResponse(code, http.StatusText(code)), nil
+	}
+}
+
+func withError(err error) func() (*http.Response, error) {
+	return func() (*http.Response, error) { return nil, err }
+}
+
+func TestTransientErrorReason(t *testing.T) {
+	testCases := []struct {
+		err            error
+		expectedReason string
+	}{
+		{err: nil, expectedReason: ""},
+		{err: k8serrors.NewTooManyRequests("throttled", 1), expectedReason: "TooManyRequests"},
+		{err: k8serrors.NewServiceUnavailable("unavailable"), expectedReason: "ServiceUnavailable"},
+		{err: k8serrors.NewServerTimeout(schema.GroupResource{}, "get", 1), expectedReason: "ServerTimeout"},
+		{err: k8serrors.NewTimeoutError("timeout", 1), expectedReason: "Timeout"},
+		{err: k8serrors.NewInternalError(errors.New("etcdserver: leader changed")),
+			expectedReason: "EtcdLeaderChange"},
+		{err: k8serrors.NewInternalError(errors.New("webhook failed")), expectedReason: ""},
+		{err: syscall.ECONNRESET, expectedReason: "ConnectionReset"},
+		{err: syscall.ECONNREFUSED, expectedReason: "ConnectionRefused"},
+		{err: io.ErrUnexpectedEOF, expectedReason: "EOF"},
+		{err: k8serrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "gpu-burn"), expectedReason: ""},
+	}
+
+	for _, testCase := range testCases {
+		if reason := TransientErrorReason(testCase.err); reason != testCase.expectedReason {
+			t.Errorf("error %v: expected reason %q, got %q", testCase.err, testCase.expectedReason, reason)
+		}
+	}
+}
+
+func TestRetryRoundTrip(t *testing.T) {
+	dialError := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	readError := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+
+	testCases := []struct {
+		name            string
+		method          string
+		url             string
+		outcomes        []func() (*http.Response, error)
+		expectedCalls   int
+		expectedRetries int
+	}{
+		{name: "GET retried on 503", method: http.MethodGet, url: "/api/v1/pods",
+			outcomes:      []func() (*http.Response, error){withStatus(http.StatusServiceUnavailable)},
+			expectedCalls: 2, expectedRetries: 1},
+		{name: "PUT retried on connection reset", method: http.MethodPut, url: "/api/v1/pods/a",
+			outcomes: []func() (*http.Response, error){withError(readError)}, expectedCalls: 2, expectedRetries: 1},
+		{name: "GET not retried on 404", method: http.MethodGet, url: "/api/v1/pods/a",
+			outcomes:      []func() (*http.Response, error){withStatus(http.StatusNotFound)},
+			expectedCalls: 1, expectedRetries: 0},
+		{name: "watch not retried", method: http.MethodGet, url: "/api/v1/pods?watch=true",
+			outcomes:      []func() (*http.Response, error){withStatus(http.StatusServiceUnavailable)},
+			expectedCalls: 1, expectedRetries: 0},
+		{name: "POST not retried on 503", method: http.MethodPost, url: "/api/v1/pods",
+			outcomes:      []func() (*http.Response, error){withStatus(http.StatusServiceUnavailable)},
+			expectedCalls: 1, expectedRetries: 0},
+		{name: "POST not retried on connection reset", method: http.MethodPost, url: "/api/v1/pods",
+			outcomes: []func() (*http.Response, error){withError(readError)}, expectedCalls: 1, expectedRetries: 0},
+		{name: "POST retried on 429", method: http.MethodPost, url: "/api/v1/pods",
+			outcomes:      []func() (*http.Response, error){withStatus(http.StatusTooManyRequests)},
+			expectedCalls: 2, expectedRetries: 1},
+		{name: "DELETE retried on dial error", method: http.MethodDelete, url: "/api/v1/pods/a",
+			outcomes: []func() (*http.Response, error){withError(dialError)}, expectedCalls: 2, expectedRetries: 1},
+		{name: "PATCH not retried on timeout", method: http.MethodPatch, url: "/api/v1/pods/a",
+			outcomes:      []func() (*http.Response, error){withStatus(http.StatusGatewayTimeout)},
+			expectedCalls: 1, expectedRetries: 0},
+		{name: "GET gives up after max retries", method: http.MethodGet, url: "/api/v1/pods",
+			outcomes: []func() (*http.Response, error){withStatus(http.StatusServiceUnavailable),
+				withStatus(http.StatusServiceUnavailable), withStatus(http.StatusServiceUnavailable)},
+			expectedCalls: 3, expectedRetries: 2},
+	}
+
+	for _, testCase := range testCases {
+		delegate := &scriptedRoundTripper{outcomes: testCase.outcomes}
+		state := newRetryState()
+		state.policy = RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+		request, err := http.NewRequest(testCase.method, "https://apiserver"+testCase.url, http.NoBody)
+		if err != nil {
+			t.Fatalf("%s: %v", testCase.name, err)
+		}
+
+		response, _ := state.wrap(delegate).RoundTrip(request)
+		if response != nil {
+			_ = response.Body.Close()
+		}
+
+		if delegate.calls != testCase.expectedCalls {
+			t.Errorf("%s: expected %d calls, got %d", testCase.name, testCase.expectedCalls, delegate.calls)
+		}
+
+		if state.stats.Total != testCase.expectedRetries {
+			t.Errorf("%s: expected %d retries, got %d", testCase.name, testCase.expectedRetries, state.stats.Total)
+		}
+	}
+}
+
+func TestRetryRoundTripRetryAfter(t *testing.T) {
+	throttled := func() (*http.Response, error) {
+		response := statusResponse(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests))
+		response.Header.Set("Retry-After", "1")
+
+		return response, nil
+	}
+
+	delegate := &scriptedRoundTripper{outcomes: []func() (*http.Response, error){throttled}}
+	state := newRetryState()
+	state.policy = RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	request, err := http.NewRequest(http.MethodGet, "https://apiserver/api/v1/pods", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := time.Now()
+
+	response, err := state.wrap(delegate).RoundTrip(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_ = response.Body.Close()
+
+	if delegate.calls != 2 {
+		t.Errorf("expected 2 calls, got %d", delegate.calls)
+	}
+
+	if elapsed := time.Since(started); elapsed < time.Second {
+		t.Errorf("expected the retry to wait for Retry-After 1s instead of the backoff, waited %s", elapsed)
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	testCases := []struct {
+		name          string
+		retryAfter    string
+		expectedDelay time.Duration
+	}{
+		{name: "no header", retryAfter: "", expectedDelay: 0},
+		{name: "seconds", retryAfter: "3", expectedDelay: 3 * time.Second},
+		{name: "negative seconds", retryAfter: "-1", expectedDelay: 0},
+		{name: "invalid value", retryAfter: "soon", expectedDelay: 0},
+		{name: "past date", retryAfter: "Wed, 21 Oct 2015 07:28:00 GMT", expectedDelay: 0},
+	}
+
+	for _, testCase := range testCases {
+		response := statusResponse(http.StatusTooManyRequests, "")
+		if testCase.retryAfter != "" {
+			response.Header.Set("Retry-After", testCase.retryAfter)
+		}
+
+		if delay := retryAfterDelay(response); delay != testCase.expectedDelay {
+			t.Errorf("%s: expected delay %s, got %s", testCase.name, testCase.expectedDelay, delay)
+		}
+	}
+
+	response := statusResponse(http.StatusServiceUnavailable, "")
+	response.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+
+	if delay := retryAfterDelay(response); delay <= 50*time.Second || delay > time.Minute {
+		t.Errorf("date: expected a delay of about 1m, got %s", delay)
+	}
+
+	if delay := retryAfterDelay(nil); delay != 0 {
+		t.Errorf("nil response: expected no delay, got %s", delay)
+	}
+}
+
+func TestRetryStatsString(t *testing.T) {
+	testCases := []struct {
+		stats    RetryStats
+		expected string
+	}{
+		{stats: RetryStats{}, expected: "no retries"},
+		{stats: RetryStats{Total: 3, ByReason: map[string]int{"Timeout": 1, "EOF": 2}},
+			expected: "3 retries (EOF=2, Timeout=1)"},
+	}
+
+	for _, testCase := range testCases {
+		if got := testCase.stats.String(); got != testCase.expected {
+			t.Errorf("expected %q, got %q", testCase.expected, got)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package configmap
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testConfigMapName      = "gpu-burn-entrypoint"
+	testConfigMapNamespace = "test-gpu-burn"
+)
+
+func TestWithData(t *testing.T) {
+	testCases := []struct {
+		data          map[string]string
+		expectedError string
+	}{
+		{data: map[string]string{"entrypoint.sh": "gpu_burn 300"}, expectedError: ""},
+		{data: map[string]string{}, expectedError: "'data' cannot be empty"},
+		{data: nil, expectedError: "'data' cannot be empty"},
+	}
+
+	for _, testCase := range testCases {
+		builder := NewBuilder(clients.GetTestClients(clients.TestClientParams{}), testConfigMapName,
+			testConfigMapNamespace).WithData(testCase.data)
+
+		if builder.errorMsg != testCase.expectedError {
+			t.Errorf("data %v: expected error %q, got %q", testCase.data, testCase.expectedError, builder.errorMsg)
+		}
+	}
+}
+
+func TestCreateAndDelete(t *testing.T) {
+	testCases := []struct {
+		existing bool
+	}{
+		{existing: false},
+		{existing: true},
+	}
+
+	for _, testCase := range testCases {
+		var objects []runtime.Object
+		if testCase.existing {
+			objects = append(objects, buildConfigMap())
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: objects})
+
+		builder, err := NewBuilder(testSettings, testConfigMapName, testConfigMapNamespace).
+			WithData(map[string]string{"entrypoint.sh": "gpu_burn 300"}).
+			Create()
+		if err != nil {
+			t.Fatalf("existing %t: unexpected create error %v", testCase.existing, err)
+		}
+
+		if !builder.Exists() {
+			t.Errorf("existing %t: configmap not found after create", testCase.existing)
+		}
+
+		if err := builder.Delete(); err != nil {
+			t.Errorf("existing %t: unexpected delete error %v", testCase.existing, err)
+		}
+
+		if builder.Exists() {
+			t.Errorf("existing %t: configmap still found after delete", testCase.existing)
+		}
+	}
+}
+
+func TestPull(t *testing.T) {
+	testCases := []struct {
+		name          string
+		nsname        string
+		existing      bool
+		expectedError bool
+	}{
+		{name: testConfigMapName, nsname: testConfigMapNamespace, existing: true, expectedError: false},
+		{name: testConfigMapName, nsname: testConfigMapNamespace, existing: false, expectedError: true},
+		{name: "", nsname: testConfigMapNamespace, existing: false, expectedError: true},
+		{name: testConfigMapName, nsname: "", existing: false, expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		var objects []runtime.Object
+		if testCase.existing {
+			objects = append(objects, buildConfigMap())
+		}
+
+		builder, err := Pull(clients.GetTestClients(clients.TestClientParams{K8sMockObjects: objects}),
+			testCase.name, testCase.nsname)
+		if (err != nil) != testCase.expectedError {
+			t.Fatalf("name %q, namespace %q: unexpected pull error %v", testCase.name, testCase.nsname, err)
+		}
+
+		if !testCase.expectedError && builder.Object.Data["entrypoint.sh"] == "" {
+			t.Errorf("expected the data of the pulled configmap, got %v", builder.Object.Data)
+		}
+	}
+}
+
+func buildConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: testConfigMapName, Namespace: testConfigMapNamespace},
+		Data:       map[string]string{"entrypoint.sh": "gpu_burn 300"},
+	}
+}
@@ -463,6 +463,12 @@ func (builder *Builder) CreateAndWaitUntilReady(timeout time.Duration) (*Builder
 
 // IsReady periodically checks if deployment is in ready status.
 func (builder *Builder) IsReady(timeout time.Duration) bool {
+	return builder.IsReadyWithContext(context.TODO(), timeout)
+}
+
+// IsReadyWithContext periodically checks if deployment is in ready status.
+// The wait stops as soon as ctx is cancelled.
+func (builder *Builder) IsReadyWithContext(ctx context.Context, timeout time.Duration) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -475,10 +481,10 @@ func (builder *Builder) IsReady(timeout time.Duration) bool {
 	}
 
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			var err error
 			builder.Object, err = builder.apiClient.Deployments(builder.Definition.Namespace).Get(
-				context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+				ctx, builder.Definition.Name, metav1.GetOptions{})
 
 			if err != nil {
 				return false, err
@@ -496,6 +502,12 @@ func (builder *Builder) IsReady(timeout time.Duration) bool {
 
 // DeleteAndWait deletes a deployment and waits until it is removed from the cluster.
 func (builder *Builder) DeleteAndWait(timeout time.Duration) error {
+	return builder.DeleteAndWaitWithContext(context.TODO(), timeout)
+}
+
+// DeleteAndWaitWithContext deletes a deployment and waits until it is removed from the cluster.
+// The wait stops as soon as ctx is cancelled.
+func (builder *Builder) DeleteAndWaitWithContext(ctx context.Context, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -509,9 +521,9 @@ func (builder *Builder) DeleteAndWait(timeout time.Duration) error {
 
 	// Polls the deployment every second until it's removed.
 	return wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			_, err := builder.apiClient.Deployments(builder.Definition.Namespace).Get(
-				context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+				ctx, builder.Definition.Name, metav1.GetOptions{})
 			if k8serrors.IsNotFound(err) {
 				return true, nil
 			}
@@ -539,6 +551,14 @@ func (builder *Builder) Exists() bool {
 // WaitUntilCondition waits for the duration of the defined timeout or until the
 // deployment gets to a specific condition.
 func (builder *Builder) WaitUntilCondition(condition appsv1.DeploymentConditionType, timeout time.Duration) error {
+	return builder.WaitUntilConditionWithContext(context.TODO(), condition, timeout)
+}
+
+// WaitUntilConditionWithContext waits for the duration of the defined timeout or until the
+// deployment gets to a specific condition.
+// The wait stops as soon as ctx is cancelled.
+func (builder *Builder) WaitUntilConditionWithContext(
+	ctx context.Context, condition appsv1.DeploymentConditionType, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -551,9 +571,9 @@ func (builder *Builder) WaitUntilCondition(condition appsv1.DeploymentConditionT
 	}
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			updateDeployment, err := builder.apiClient.Deployments(builder.Definition.Namespace).Get(
-				context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+				ctx, builder.Definition.Name, metav1.GetOptions{})
 			if err != nil {
 				return false, nil
 			}
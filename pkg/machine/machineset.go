@@ -175,6 +175,12 @@ func (builder *SetBuilder) Create() (*SetBuilder, error) {
 
 	glog.V(100).Infof("Creating the MachineSet %s", builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunCreate, builder.Definition)
+	}
+
 	var err error
 	if !builder.Exists() {
 		builder.Object, err = builder.apiClient.MachineSets(builder.Definition.Namespace).Create(
@@ -193,6 +199,12 @@ func (builder *SetBuilder) Delete() error {
 	glog.V(100).Infof("Deleting the MachineSet object %s",
 		builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = nil
+
+		return builder.apiClient.RecordDryRun(clients.DryRunDelete, builder.Definition)
+	}
+
 	if !builder.Exists() {
 		return fmt.Errorf("machineSet cannot be deleted because it does not exist")
 	}
@@ -210,11 +222,20 @@ func (builder *SetBuilder) Delete() error {
 // WaitForMachineSetReady waits until MachineSet first replica is Ready.
 func WaitForMachineSetReady(
 	apiClient *clients.Settings,
+	namespace,
+	machineSetName string,
+	timeout time.Duration) error {
+	return WaitForMachineSetReadyWithContext(context.TODO(), apiClient, namespace, machineSetName, timeout)
+}
+
+// WaitForMachineSetReadyWithContext waits until MachineSet first replica is Ready.
+// The wait stops as soon as ctx is cancelled.
+func WaitForMachineSetReadyWithContext(ctx context.Context, apiClient *clients.Settings,
 	namespace,
 	machineSetName string,
 	timeout time.Duration) error {
 	return wait.PollUntilContextTimeout(
-		context.TODO(), 30*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, 30*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			machineSetPulled, err := PullSet(apiClient, namespace, machineSetName)
 
 			if err != nil {
@@ -123,6 +123,12 @@ func (builder *Builder) Create() (*Builder, error) {
 
 	glog.V(100).Infof("Creating namespace %s", builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunCreate, builder.Definition)
+	}
+
 	var err error
 	if !builder.Exists() {
 		builder.Object, err = builder.apiClient.Namespaces().Create(
@@ -140,6 +146,12 @@ func (builder *Builder) Update() (*Builder, error) {
 
 	glog.V(100).Infof("Updating the namespace %s with the namespace definition in the builder", builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunUpdate, builder.Definition)
+	}
+
 	var err error
 	builder.Object, err = builder.apiClient.Namespaces().Update(
 		context.TODO(), builder.Definition, metav1.UpdateOptions{})
@@ -155,6 +167,12 @@ func (builder *Builder) Delete() error {
 
 	glog.V(100).Infof("Deleting namespace %s", builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = nil
+
+		return builder.apiClient.RecordDryRun(clients.DryRunDelete, builder.Definition)
+	}
+
 	if !builder.Exists() {
 		return nil
 	}
@@ -172,6 +190,12 @@ func (builder *Builder) Delete() error {
 
 // DeleteAndWait deletes a namespace and waits until it's removed from the cluster.
 func (builder *Builder) DeleteAndWait(timeout time.Duration) error {
+	return builder.DeleteAndWaitWithContext(context.TODO(), timeout)
+}
+
+// DeleteAndWaitWithContext deletes a namespace and waits until it's removed from the cluster.
+// The wait stops as soon as ctx is cancelled.
+func (builder *Builder) DeleteAndWaitWithContext(ctx context.Context, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -182,9 +206,13 @@ func (builder *Builder) DeleteAndWait(timeout time.Duration) error {
 		return err
 	}
 
+	if builder.apiClient.IsDryRun() {
+		return nil
+	}
+
 	return wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-			_, err := builder.apiClient.Namespaces().Get(context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			_, err := builder.apiClient.Namespaces().Get(ctx, builder.Definition.Name, metav1.GetOptions{})
 			if k8serrors.IsNotFound(err) {
 				return true, nil
 			}
@@ -236,6 +264,13 @@ func Pull(apiClient *clients.Settings, nsname string) (*Builder, error) {
 
 // CleanObjects removes given objects from the namespace.
 func (builder *Builder) CleanObjects(cleanTimeout time.Duration, objects ...schema.GroupVersionResource) error {
+	return builder.CleanObjectsWithContext(context.TODO(), cleanTimeout, objects...)
+}
+
+// CleanObjectsWithContext removes given objects from the namespace.
+// The wait stops as soon as ctx is cancelled.
+func (builder *Builder) CleanObjectsWithContext(
+	ctx context.Context, cleanTimeout time.Duration, objects ...schema.GroupVersionResource) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -257,7 +292,7 @@ func (builder *Builder) CleanObjects(cleanTimeout time.Duration, objects ...sche
 			resource.Resource, builder.Definition.Name)
 
 		err := builder.apiClient.Resource(resource).Namespace(builder.Definition.Name).DeleteCollection(
-			context.TODO(), metav1.DeleteOptions{
+			ctx, metav1.DeleteOptions{
 				GracePeriodSeconds: ptr.To(int64(0)),
 			}, metav1.ListOptions{})
 
@@ -269,9 +304,9 @@ func (builder *Builder) CleanObjects(cleanTimeout time.Duration, objects ...sche
 		}
 
 		err = wait.PollUntilContextTimeout(
-			context.TODO(), 3*time.Second, cleanTimeout, true, func(ctx context.Context) (bool, error) {
+			ctx, 3*time.Second, cleanTimeout, true, func(ctx context.Context) (bool, error) {
 				objList, err := builder.apiClient.Resource(resource).Namespace(builder.Definition.Name).List(
-					context.TODO(), metav1.ListOptions{})
+					ctx, metav1.ListOptions{})
 
 				if err != nil || len(objList.Items) > 1 {
 					// avoid timeout due to default automatically created openshift
@@ -0,0 +1,132 @@
+package namespace
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNewBuilder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		expectedError string
+	}{
+		{name: "test-namespace", expectedError: ""},
+		{name: "", expectedError: "namespace 'name' cannot be empty"},
+	}
+
+	for _, testCase := range testCases {
+		builder := NewBuilder(clients.GetTestClients(clients.TestClientParams{}), testCase.name)
+
+		if builder.errorMsg != testCase.expectedError {
+			t.Errorf("name %q: expected error %q, got %q", testCase.name, testCase.expectedError, builder.errorMsg)
+		}
+
+		if builder.Definition.Name != testCase.name {
+			t.Errorf("expected definition name %q, got %q", testCase.name, builder.Definition.Name)
+		}
+	}
+}
+
+func TestWithLabel(t *testing.T) {
+	testCases := []struct {
+		key           string
+		value         string
+		expectedError string
+	}{
+		{key: "openshift.io/cluster-monitoring", value: "true", expectedError: ""},
+		{key: "pod-security.kubernetes.io/enforce", value: "", expectedError: ""},
+		{key: "", value: "true", expectedError: "'key' cannot be empty"},
+	}
+
+	for _, testCase := range testCases {
+		builder := NewBuilder(clients.GetTestClients(clients.TestClientParams{}), "test-namespace").
+			WithLabel(testCase.key, testCase.value)
+
+		if builder.errorMsg != testCase.expectedError {
+			t.Errorf("key %q: expected error %q, got %q", testCase.key, testCase.expectedError, builder.errorMsg)
+		}
+
+		if testCase.expectedError == "" && builder.Definition.Labels[testCase.key] != testCase.value {
+			t.Errorf("expected label %s=%s, got labels %v", testCase.key, testCase.value,
+				builder.Definition.Labels)
+		}
+	}
+}
+
+func TestCreateAndDelete(t *testing.T) {
+	testCases := []struct {
+		name          string
+		existing      bool
+		expectedError bool
+	}{
+		{name: "test-namespace", existing: false, expectedError: false},
+		{name: "test-namespace", existing: true, expectedError: false},
+		{name: "", existing: false, expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		var objects []runtime.Object
+		if testCase.existing {
+			objects = append(objects, buildNamespace(testCase.name))
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: objects})
+
+		builder, err := NewBuilder(testSettings, testCase.name).Create()
+		if (err != nil) != testCase.expectedError {
+			t.Fatalf("name %q: unexpected create error %v", testCase.name, err)
+		}
+
+		if testCase.expectedError {
+			continue
+		}
+
+		if !builder.Exists() {
+			t.Errorf("namespace %s not found after create", testCase.name)
+		}
+
+		if err := builder.Delete(); err != nil {
+			t.Errorf("unexpected delete error %v", err)
+		}
+
+		if builder.Exists() {
+			t.Errorf("namespace %s still found after delete", testCase.name)
+		}
+	}
+}
+
+func TestPull(t *testing.T) {
+	testCases := []struct {
+		name          string
+		existing      bool
+		expectedError bool
+	}{
+		{name: "test-namespace", existing: true, expectedError: false},
+		{name: "test-namespace", existing: false, expectedError: true},
+		{name: "", existing: false, expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		var objects []runtime.Object
+		if testCase.existing {
+			objects = append(objects, buildNamespace(testCase.name))
+		}
+
+		builder, err := Pull(clients.GetTestClients(clients.TestClientParams{K8sMockObjects: objects}), testCase.name)
+		if (err != nil) != testCase.expectedError {
+			t.Fatalf("name %q, existing %t: unexpected pull error %v", testCase.name, testCase.existing, err)
+		}
+
+		if !testCase.expectedError && builder.Object.Name != testCase.name {
+			t.Errorf("expected pulled namespace %s, got %s", testCase.name, builder.Object.Name)
+		}
+	}
+}
+
+func buildNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
@@ -13,6 +13,8 @@ const (
 	OperatorDeploymentName              = "nfd-controller-manager"
 	Package                             = "nfd"
 	CRName                              = "nfd-instance"
+	NodeLabelPrefix                     = "feature.node.kubernetes.io/"
+	UninstallReportFile                 = "nfd-uninstall.report"
 
 	NFDOperatorCheckInterval = 30 * time.Second
 	NFDOperatorTimeout       = 5 * time.Minute
@@ -370,6 +370,19 @@ func NFDCRDeleteAndWait(apiClient *clients.Settings, nfdCRName string, nfdCRName
 		})
 }
 
+// UninstallNFD uninstalls the NFD operator with its NodeFeatureDiscovery instances, CRDs and namespace, and
+// reports the objects left on the cluster, including the NFD node labels.
+func UninstallNFD(apiClient *clients.Settings, pollInterval, timeout time.Duration) (*olm.UninstallReport, error) {
+	glog.V(gpuparams.GpuLogLevel).Infof("Uninstalling NFD operator from namespace '%s'", nfdOperatorNamespace)
+
+	return olm.NewOperatorUninstallBuilder(apiClient, nfdPackage, nfdSubscriptionName, nfdOperatorNamespace).
+		WithCRDs().
+		WithNamespace().
+		WithNodeLabelPrefixes(NodeLabelPrefix).
+		WithTimeout(pollInterval, timeout).
+		Uninstall()
+}
+
 // DeleteNFDNamespace creates and labels NFD namespace.
 func DeleteNFDNamespace(apiClient *clients.Settings) error {
 	glog.V(gpuparams.GpuLogLevel).Infof("Deleting NFD namespace '%s'", nfdOperatorNamespace)
@@ -480,9 +493,6 @@ func CreateNFDDeployment(apiClient *clients.Settings, catalogSource, operatorDep
 	err := CreateNFDSubscription(apiClient, catalogSource)
 	Expect(err).ToNot(HaveOccurred(), "error creating NFD Subscription: %v", err)
 
-	glog.V(glog.Level(logLevel)).Info("Sleeping for 2 minutes to allow the NFD Operator deployment to stabilize")
-	time.Sleep(2 * time.Minute)
-
 	glog.V(glog.Level(logLevel)).Infof("Waiting up to %v for NFD Operator deployment to be fully created", timeout)
 	nfdDeploymentCreated := nvidiagpuwait.DeploymentCreated(apiClient, operatorDeploymentName, operatorNamespace, checkInterval, timeout)
 	Expect(nfdDeploymentCreated).ToNot(BeFalse(), "timed out waiting for NFD operator deployment")
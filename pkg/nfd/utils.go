@@ -12,8 +12,8 @@ import (
 	_ "github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
 	_ "github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
 	_ "github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
 	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/logging"
@@ -66,12 +66,13 @@ func EnsureNFDIsInstalled(apiClient *clients.Settings, Nfd *CustomConfig, ocpVer
 				Expect(createdNFDCustomCatalogSourceBuilder).ToNot(BeNil(), "Failed to "+
 					" create custom NFD catalogsource '%s'", Nfd.CustomCatalogSource)
 
-				By(fmt.Sprintf("Sleep for %s to allow the NFD custom catalogsource to be created", nvidiagpu.SleepDuration.String()))
-				time.Sleep(nvidiagpu.SleepDuration)
+				glog.V(level).Infof("Wait up to %s for custom NFD catalogsource '%s' to be ready", timeouts.Get(timeouts.CatalogSourceReadyTimeout).String(), createdNFDCustomCatalogSourceBuilder.Definition.Name)
 
-				glog.V(level).Infof("Wait up to %s for custom NFD catalogsource '%s' to be ready", nvidiagpu.WaitDuration.String(), createdNFDCustomCatalogSourceBuilder.Definition.Name)
-
-				Expect(createdNFDCustomCatalogSourceBuilder.IsReady(nvidiagpu.WaitDuration)).NotTo(BeFalse())
+				_, err = createdNFDCustomCatalogSourceBuilder.WaitUntilReady(
+					timeouts.Get(timeouts.CatalogSourceReadyTimeout), Package)
+				Expect(err).ToNot(HaveOccurred(), "custom NFD catalogsource '%s' with index image '%s' from "+
+					"the NFD fallback catalogsource index image variable is not usable: %v", Nfd.CustomCatalogSource,
+					Nfd.CustomCatalogSourceIndexImage, err)
 
 				nfdPkgManifestBuilderByCustomCatalog, err := olm.PullPackageManifestByCatalogWithTimeout(inittools.APIClient,
 					Package, CatalogSourceNamespace, Nfd.CustomCatalogSource, 30*time.Second, 5*time.Minute)
@@ -84,6 +84,14 @@ func ListExternalIPv4Networks(apiClient *clients.Settings, options ...v1.ListOpt
 
 // WaitForAllNodesAreReady waits for all nodes to be Ready for a time duration up to the timeout.
 func WaitForAllNodesAreReady(apiClient *clients.Settings,
+	timeout time.Duration,
+	options ...v1.ListOptions) (bool, error) {
+	return WaitForAllNodesAreReadyWithContext(context.TODO(), apiClient, timeout, options...)
+}
+
+// WaitForAllNodesAreReadyWithContext waits for all nodes to be Ready for a time duration up to the timeout.
+// The wait stops as soon as ctx is cancelled.
+func WaitForAllNodesAreReadyWithContext(ctx context.Context, apiClient *clients.Settings,
 	timeout time.Duration,
 	options ...v1.ListOptions) (bool, error) {
 	glog.V(100).Infof("Waiting for all nodes to be in the Ready state for up to a duration of %v",
@@ -97,7 +105,7 @@ func WaitForAllNodesAreReady(apiClient *clients.Settings,
 	}
 
 	err = wait.PollUntilContextTimeout(
-		context.TODO(), backoff, timeout, true, func(ctx context.Context) (done bool, err error) {
+		ctx, backoff, timeout, true, func(ctx context.Context) (done bool, err error) {
 			for _, node := range nodesList {
 				ready, err := node.IsReady()
 				if err != nil {
@@ -132,6 +140,14 @@ func WaitForAllNodesAreReady(apiClient *clients.Settings,
 
 // WaitForAllNodesToReboot waits for all nodes to start and finish reboot up to the timeout.
 func WaitForAllNodesToReboot(apiClient *clients.Settings,
+	globalRebootTimeout time.Duration,
+	options ...v1.ListOptions) (bool, error) {
+	return WaitForAllNodesToRebootWithContext(context.TODO(), apiClient, globalRebootTimeout, options...)
+}
+
+// WaitForAllNodesToRebootWithContext waits for all nodes to start and finish reboot up to the timeout.
+// The wait stops as soon as ctx is cancelled.
+func WaitForAllNodesToRebootWithContext(ctx context.Context, apiClient *clients.Settings,
 	globalRebootTimeout time.Duration,
 	options ...v1.ListOptions) (bool, error) {
 	glog.V(100).Infof("Waiting for all nodes in the list to reboot and return to the Ready condition")
@@ -147,7 +163,7 @@ func WaitForAllNodesToReboot(apiClient *clients.Settings,
 	readyNodes := []string{}
 	rebootedNodes := []string{}
 	err = wait.PollUntilContextTimeout(
-		context.TODO(), backoff, globalRebootTimeout, true, func(ctx context.Context) (done bool, err error) {
+		ctx, backoff, globalRebootTimeout, true, func(ctx context.Context) (done bool, err error) {
 			for _, node := range nodesList {
 				if !slices.Contains(readyNodes, node.Object.Name) {
 					ready, err := node.IsReady()
@@ -327,12 +327,19 @@ func (builder *Builder) IsReady() (bool, error) {
 // WaitUntilConditionTrue waits for timeout duration or until node gets to a specific status.
 func (builder *Builder) WaitUntilConditionTrue(
 	conditionType corev1.NodeConditionType, timeout time.Duration) error {
+	return builder.WaitUntilConditionTrueWithContext(context.TODO(), conditionType, timeout)
+}
+
+// WaitUntilConditionTrueWithContext waits for timeout duration or until node gets to a specific status.
+// The wait stops as soon as ctx is cancelled.
+func (builder *Builder) WaitUntilConditionTrueWithContext(
+	ctx context.Context, conditionType corev1.NodeConditionType, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
 
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			if !builder.Exists() {
 				return false, fmt.Errorf("node %s object doesn't exist", builder.Definition.Name)
 			}
@@ -358,12 +365,19 @@ func (builder *Builder) WaitUntilConditionTrue(
 // WaitUntilConditionUnknown waits for timeout duration or until node change specific status.
 func (builder *Builder) WaitUntilConditionUnknown(
 	conditionType corev1.NodeConditionType, timeout time.Duration) error {
+	return builder.WaitUntilConditionUnknownWithContext(context.TODO(), conditionType, timeout)
+}
+
+// WaitUntilConditionUnknownWithContext waits for timeout duration or until node change specific status.
+// The wait stops as soon as ctx is cancelled.
+func (builder *Builder) WaitUntilConditionUnknownWithContext(
+	ctx context.Context, conditionType corev1.NodeConditionType, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
 
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			if !builder.Exists() {
 				return false, fmt.Errorf("node %s object doesn't exist", builder.Definition.Name)
 			}
@@ -391,11 +405,21 @@ func (builder *Builder) WaitUntilReady(timeout time.Duration) error {
 	return builder.WaitUntilConditionTrue(corev1.NodeReady, timeout)
 }
 
+// WaitUntilReadyWithContext waits for timeout duration, until node is Ready or until ctx is cancelled.
+func (builder *Builder) WaitUntilReadyWithContext(ctx context.Context, timeout time.Duration) error {
+	return builder.WaitUntilConditionTrueWithContext(ctx, corev1.NodeReady, timeout)
+}
+
 // WaitUntilNotReady waits for timeout duration or until node is NotReady.
 func (builder *Builder) WaitUntilNotReady(timeout time.Duration) error {
 	return builder.WaitUntilConditionUnknown(corev1.NodeReady, timeout)
 }
 
+// WaitUntilNotReadyWithContext waits for timeout duration, until node is NotReady or until ctx is cancelled.
+func (builder *Builder) WaitUntilNotReadyWithContext(ctx context.Context, timeout time.Duration) error {
+	return builder.WaitUntilConditionUnknownWithContext(ctx, corev1.NodeReady, timeout)
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *Builder) validate() (bool, error) {
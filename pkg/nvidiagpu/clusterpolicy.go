@@ -35,9 +35,11 @@ func NewBuilderFromObjectString(apiClient *clients.Settings, almExample string)
 
 	clusterPolicy, err := getClusterPolicyFromAlmExample(almExample)
 
-	glog.V(100).Infof(
-		"Initializing new Builder structure from almExample string with clusterPolicy name: %s",
-		clusterPolicy.Name)
+	if clusterPolicy != nil {
+		glog.V(100).Infof(
+			"Initializing new Builder structure from almExample string with clusterPolicy name: %s",
+			clusterPolicy.Name)
+	}
 
 	builder := Builder{
 		apiClient:  apiClient,
@@ -60,6 +62,39 @@ func NewBuilderFromObjectString(apiClient *clients.Settings, almExample string)
 	return &builder
 }
 
+// NewBuilderFromSpec creates a Builder object for a ClusterPolicy with the given spec, e.g. a spec recorded
+// before an operator upgrade.
+func NewBuilderFromSpec(apiClient *clients.Settings, name string, spec *nvidiagpuv1.ClusterPolicySpec) *Builder {
+	glog.V(100).Infof("Initializing new Builder structure from spec with clusterPolicy name: %s", name)
+
+	builder := Builder{
+		apiClient: apiClient,
+		Definition: &nvidiagpuv1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("ClusterPolicy name is empty")
+
+		builder.errorMsg = "ClusterPolicy 'name' cannot be empty"
+	}
+
+	if spec == nil {
+		glog.V(100).Infof("The ClusterPolicy spec is nil")
+
+		builder.errorMsg = "ClusterPolicy 'spec' cannot be nil"
+
+		return &builder
+	}
+
+	builder.Definition.Spec = *spec.DeepCopy()
+
+	return &builder
+}
+
 // Get returns clusterPolicy object if found.
 func (builder *Builder) Get() (*nvidiagpuv1.ClusterPolicy, error) {
 	if valid, err := builder.validate(); !valid {
@@ -139,6 +174,12 @@ func (builder *Builder) Delete() (*Builder, error) {
 
 	glog.V(100).Infof("Deleting ClusterPolicy %s", builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = nil
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunDelete, builder.Definition)
+	}
+
 	if !builder.Exists() {
 		return builder, fmt.Errorf("clusterpolicy cannot be deleted because it does not exist")
 	}
@@ -162,6 +203,12 @@ func (builder *Builder) Create() (*Builder, error) {
 
 	glog.V(100).Infof("Creating the ClusterPolicy %s", builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunCreate, builder.Definition)
+	}
+
 	var err error
 	if !builder.Exists() {
 		err = builder.apiClient.Create(context.TODO(), builder.Definition)
@@ -182,6 +229,12 @@ func (builder *Builder) Update(force bool) (*Builder, error) {
 
 	glog.V(100).Infof("Updating the ClusterPolicy object named:  %s", builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunUpdate, builder.Definition)
+	}
+
 	err := builder.apiClient.Update(context.TODO(), builder.Definition)
 
 	if err != nil {
@@ -0,0 +1,110 @@
+package nvidiagpu
+
+import (
+	"testing"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"k8s.io/utils/ptr"
+)
+
+const testAlmExample = `[
+  {
+    "apiVersion": "nvidia.com/v1",
+    "kind": "ClusterPolicy",
+    "metadata": {"name": "gpu-cluster-policy"},
+    "spec": {"driver": {"enabled": true}, "dcgmExporter": {"enabled": false}}
+  }
+]`
+
+func TestNewBuilderFromObjectString(t *testing.T) {
+	testCases := []struct {
+		almExample    string
+		expectedError bool
+	}{
+		{almExample: testAlmExample, expectedError: false},
+		{almExample: "", expectedError: true},
+		{almExample: "not json", expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		builder := NewBuilderFromObjectString(clients.GetTestClients(clients.TestClientParams{}), testCase.almExample)
+
+		if (builder.errorMsg != "") != testCase.expectedError {
+			t.Errorf("alm-examples %q: unexpected error message %q", testCase.almExample, builder.errorMsg)
+		}
+
+		if !testCase.expectedError && builder.Definition.Name != ClusterPolicyName {
+			t.Errorf("expected ClusterPolicy %s, got %s", ClusterPolicyName, builder.Definition.Name)
+		}
+	}
+}
+
+func TestNewBuilderFromSpec(t *testing.T) {
+	spec := &nvidiagpuv1.ClusterPolicySpec{Driver: nvidiagpuv1.DriverSpec{Enabled: ptr.To(true)}}
+
+	testCases := []struct {
+		name          string
+		spec          *nvidiagpuv1.ClusterPolicySpec
+		expectedError string
+	}{
+		{name: ClusterPolicyName, spec: spec, expectedError: ""},
+		{name: "", spec: spec, expectedError: "ClusterPolicy 'name' cannot be empty"},
+		{name: ClusterPolicyName, spec: nil, expectedError: "ClusterPolicy 'spec' cannot be nil"},
+	}
+
+	for _, testCase := range testCases {
+		builder := NewBuilderFromSpec(clients.GetTestClients(clients.TestClientParams{}), testCase.name, testCase.spec)
+
+		if builder.errorMsg != testCase.expectedError {
+			t.Errorf("expected error %q, got %q", testCase.expectedError, builder.errorMsg)
+		}
+	}
+
+	builder := NewBuilderFromSpec(clients.GetTestClients(clients.TestClientParams{}), ClusterPolicyName, spec)
+	*spec.Driver.Enabled = false
+
+	if !*builder.Definition.Spec.Driver.Enabled {
+		t.Errorf("expected the builder to keep a copy of the spec")
+	}
+}
+
+func TestCreatePullDelete(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+	if _, err := Pull(testSettings, ClusterPolicyName); err == nil {
+		t.Fatalf("expected error pulling missing ClusterPolicy")
+	}
+
+	builder, err := NewBuilderFromObjectString(testSettings, testAlmExample).Create()
+	if err != nil {
+		t.Fatalf("unexpected create error %v", err)
+	}
+
+	pulledBuilder, err := Pull(testSettings, ClusterPolicyName)
+	if err != nil {
+		t.Fatalf("unexpected pull error %v", err)
+	}
+
+	if !*pulledBuilder.Object.Spec.Driver.Enabled {
+		t.Errorf("expected the driver of the pulled ClusterPolicy to be enabled")
+	}
+
+	pulledBuilder.Definition.Spec.DCGMExporter.Enabled = ptr.To(true)
+
+	if _, err := pulledBuilder.Update(false); err != nil {
+		t.Fatalf("unexpected update error %v", err)
+	}
+
+	if updated, err := builder.Get(); err != nil || !*updated.Spec.DCGMExporter.Enabled {
+		t.Errorf("expected updated dcgmExporter, got %v", err)
+	}
+
+	if _, err := builder.Delete(); err != nil {
+		t.Fatalf("unexpected delete error %v", err)
+	}
+
+	if _, err := builder.Delete(); err == nil {
+		t.Errorf("expected error deleting missing ClusterPolicy")
+	}
+}
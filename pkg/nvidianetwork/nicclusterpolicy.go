@@ -153,6 +153,12 @@ func (builder *NicClusterPolicyBuilder) Delete() (*NicClusterPolicyBuilder, erro
 
 	glog.V(100).Infof("Deleting NicClusterPolicy %s", builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = nil
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunDelete, builder.Definition)
+	}
+
 	if !builder.Exists() {
 		return builder, fmt.Errorf("nicclusterpolicy cannot be deleted because it does not exist")
 	}
@@ -176,6 +182,12 @@ func (builder *NicClusterPolicyBuilder) Create() (*NicClusterPolicyBuilder, erro
 
 	glog.V(100).Infof("Creating the NicClusterPolicy %s", builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunCreate, builder.Definition)
+	}
+
 	var err error
 	if !builder.Exists() {
 		err = builder.apiClient.Create(context.TODO(), builder.Definition)
@@ -199,6 +211,12 @@ func (builder *NicClusterPolicyBuilder) Update(force bool) (*NicClusterPolicyBui
 
 	glog.V(100).Infof("Updating the NicClusterPolicy object named:  %s", builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunUpdate, builder.Definition)
+	}
+
 	err := builder.apiClient.Update(context.TODO(), builder.Definition)
 
 	if err != nil {
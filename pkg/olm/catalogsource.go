@@ -154,6 +154,12 @@ func (builder *CatalogSourceBuilder) Create() (*CatalogSourceBuilder, error) {
 	glog.V(100).Infof("Creating the catalogsource %s in namespace %s",
 		builder.Definition.Name, builder.Definition.Namespace)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunCreate, builder.Definition)
+	}
+
 	var err error
 	if !builder.Exists() {
 		builder.Object, err = builder.apiClient.CatalogSources(builder.Definition.Namespace).Create(context.TODO(),
@@ -190,6 +196,12 @@ func (builder *CatalogSourceBuilder) Delete() error {
 	glog.V(100).Infof("Deleting catalogsource %s in namespace %s", builder.Definition.Name,
 		builder.Definition.Namespace)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = nil
+
+		return builder.apiClient.RecordDryRun(clients.DryRunDelete, builder.Definition)
+	}
+
 	if !builder.Exists() {
 		return nil
 	}
@@ -208,6 +220,12 @@ func (builder *CatalogSourceBuilder) Delete() error {
 
 // IsReady periodically checks if catalogsource is in Ready state.
 func (builder *CatalogSourceBuilder) IsReady(timeout time.Duration) bool {
+	return builder.IsReadyWithContext(context.TODO(), timeout)
+}
+
+// IsReadyWithContext periodically checks if catalogsource is in Ready state.
+// The wait stops as soon as ctx is cancelled.
+func (builder *CatalogSourceBuilder) IsReadyWithContext(ctx context.Context, timeout time.Duration) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -220,10 +238,10 @@ func (builder *CatalogSourceBuilder) IsReady(timeout time.Duration) bool {
 	}
 
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			var err error
 			builder.Object, err = builder.apiClient.CatalogSources(builder.Definition.Namespace).Get(
-				context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+				ctx, builder.Definition.Name, metav1.GetOptions{})
 
 			if err != nil {
 				return false, err
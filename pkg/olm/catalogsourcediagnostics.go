@@ -0,0 +1,426 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// catalogSourcePollInterval is the interval between two diagnostics of a CatalogSource being waited for.
+	catalogSourcePollInterval = 5 * time.Second
+	// catalogSourcePackagesGracePeriod is how long a READY CatalogSource may not serve the expected packages,
+	// while the package server syncs the catalog.
+	catalogSourcePackagesGracePeriod = 2 * time.Minute
+	// catalogSourcePodLabel is the label set by OLM on the registry pods of a CatalogSource.
+	catalogSourcePodLabel = "olm.catalogSource"
+	// catalogSourceReadyState is the gRPC connection state of a CatalogSource whose registry is serving.
+	catalogSourceReadyState = "READY"
+)
+
+// FatalRegistryContainerReasons are the waiting reasons of a registry container that do not resolve without
+// fixing the registry, e.g. its image. ErrImagePull is retried by the kubelet, and only becomes
+// ImagePullBackOff when the pull keeps failing.
+var FatalRegistryContainerReasons = []string{
+	"ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull", "CrashLoopBackOff",
+}
+
+// imagePullReasons are the waiting reasons of a container whose image cannot be pulled.
+var imagePullReasons = []string{"ImagePullBackOff", "ErrImagePull", "InvalidImageName", "ErrImageNeverPull"}
+
+// CatalogSourceConnectionState is a gRPC connection state of a CatalogSource, as observed at ObservedAt.
+type CatalogSourceConnectionState struct {
+	State       string
+	Address     string
+	LastConnect time.Time
+	ObservedAt  time.Time
+}
+
+// CatalogSourceRegistryPod is the state of a registry pod serving a CatalogSource.
+type CatalogSourceRegistryPod struct {
+	Name  string
+	Node  string
+	Phase corev1.PodPhase
+	Ready bool
+	// Reason and Message of the first registry container that is not ready, empty when every container is ready.
+	Reason  string
+	Message string
+	// Restarts of the registry containers.
+	Restarts int32
+}
+
+// IsFatal reports whether the registry pod cannot become ready without fixing the registry: it failed, or one
+// of its containers waits for one of FatalRegistryContainerReasons.
+func (pod CatalogSourceRegistryPod) IsFatal() bool {
+	return pod.Phase == corev1.PodFailed || slices.Contains(FatalRegistryContainerReasons, pod.Reason)
+}
+
+// String returns the state of the registry pod.
+func (pod CatalogSourceRegistryPod) String() string {
+	state := fmt.Sprintf("pod %s on node '%s' is %s", pod.Name, pod.Node, pod.Phase)
+
+	if pod.Ready {
+		state += " and ready"
+	}
+
+	if pod.Reason != "" {
+		state += fmt.Sprintf(" (%s: %s)", pod.Reason, pod.Message)
+	}
+
+	if pod.Restarts > 0 {
+		state += fmt.Sprintf(", %d restarts", pod.Restarts)
+	}
+
+	return state
+}
+
+// CatalogSourceDiagnostics is the health of a CatalogSource: the history of its gRPC connection states, its
+// registry pods, the image pull errors of these pods and the packages it serves.
+type CatalogSourceDiagnostics struct {
+	Name      string
+	Namespace string
+	Image     string
+	// ConnectionStates are the distinct gRPC connection states observed, oldest first.
+	ConnectionStates []CatalogSourceConnectionState
+	RegistryPods     []CatalogSourceRegistryPod
+	ImagePullErrors  []string
+	// Packages are the names of the PackageManifests served by the CatalogSource.
+	Packages []string
+}
+
+// State returns the last observed gRPC connection state of the CatalogSource, empty when none was reported.
+func (diagnostics *CatalogSourceDiagnostics) State() string {
+	if len(diagnostics.ConnectionStates) == 0 {
+		return ""
+	}
+
+	return diagnostics.ConnectionStates[len(diagnostics.ConnectionStates)-1].State
+}
+
+// IsReady reports whether the CatalogSource is READY and serves every package of packages.
+func (diagnostics *CatalogSourceDiagnostics) IsReady(packages ...string) bool {
+	return diagnostics.State() == catalogSourceReadyState && len(diagnostics.MissingPackages(packages...)) == 0
+}
+
+// MissingPackages returns the packages of packages that the CatalogSource does not serve.
+func (diagnostics *CatalogSourceDiagnostics) MissingPackages(packages ...string) []string {
+	var missing []string
+
+	for _, packageName := range packages {
+		if !slices.Contains(diagnostics.Packages, packageName) {
+			missing = append(missing, packageName)
+		}
+	}
+
+	return missing
+}
+
+// Failure returns an error describing why the CatalogSource cannot become ready without being fixed, nil when
+// it may still become ready: its index image cannot be pulled, or its registry crashes or failed.
+func (diagnostics *CatalogSourceDiagnostics) Failure() error {
+	for _, pod := range diagnostics.RegistryPods {
+		if !pod.IsFatal() {
+			continue
+		}
+
+		if slices.Contains(imagePullReasons, pod.Reason) {
+			return fmt.Errorf("index image '%s' cannot be pulled: %s; check the index image reference and the "+
+				"cluster pull secret", diagnostics.Image, strings.Join(diagnostics.ImagePullErrors, "; "))
+		}
+
+		return fmt.Errorf("registry %s; check that '%s' is a valid index image", pod, diagnostics.Image)
+	}
+
+	return nil
+}
+
+// String returns the diagnostics of the CatalogSource.
+func (diagnostics *CatalogSourceDiagnostics) String() string {
+	var message strings.Builder
+
+	fmt.Fprintf(&message, "CatalogSource %s in namespace %s with image '%s'", diagnostics.Name,
+		diagnostics.Namespace, diagnostics.Image)
+
+	var states []string
+	for _, state := range diagnostics.ConnectionStates {
+		states = append(states, state.State)
+	}
+
+	if len(states) == 0 {
+		message.WriteString(", no connection state reported")
+	} else {
+		fmt.Fprintf(&message, ", connection states %s", strings.Join(states, " -> "))
+	}
+
+	if len(diagnostics.RegistryPods) == 0 {
+		message.WriteString(", no registry pod")
+	}
+
+	for _, pod := range diagnostics.RegistryPods {
+		fmt.Fprintf(&message, ", registry %s", pod)
+	}
+
+	fmt.Fprintf(&message, ", serving packages %v", diagnostics.Packages)
+
+	return message.String()
+}
+
+// record adds the current connection state of the CatalogSource to the history when it changed.
+func (diagnostics *CatalogSourceDiagnostics) record(state CatalogSourceConnectionState) {
+	if len(diagnostics.ConnectionStates) != 0 {
+		last := diagnostics.ConnectionStates[len(diagnostics.ConnectionStates)-1]
+		if last.State == state.State && last.Address == state.Address {
+			return
+		}
+	}
+
+	glog.V(100).Infof("CatalogSource %s connection state is now '%s'", diagnostics.Name, state.State)
+
+	diagnostics.ConnectionStates = append(diagnostics.ConnectionStates, state)
+}
+
+// CatalogSourceNotReadyError is returned when a CatalogSource did not become ready, with its diagnostics.
+type CatalogSourceNotReadyError struct {
+	Diagnostics *CatalogSourceDiagnostics
+	Err         error
+}
+
+// Error returns the reason the CatalogSource is not ready, with its diagnostics.
+func (catalogSourceError *CatalogSourceNotReadyError) Error() string {
+	return fmt.Sprintf("CatalogSource %s in namespace %s is not ready: %v (%s)",
+		catalogSourceError.Diagnostics.Name, catalogSourceError.Diagnostics.Namespace, catalogSourceError.Err,
+		catalogSourceError.Diagnostics)
+}
+
+// Unwrap returns the reason the CatalogSource is not ready.
+func (catalogSourceError *CatalogSourceNotReadyError) Unwrap() error {
+	return catalogSourceError.Err
+}
+
+// Diagnose returns the current diagnostics of the CatalogSource.
+func (builder *CatalogSourceBuilder) Diagnose(ctx context.Context) (*CatalogSourceDiagnostics, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	diagnostics := &CatalogSourceDiagnostics{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+		Image:     builder.Definition.Spec.Image,
+	}
+
+	return diagnostics, builder.diagnose(ctx, diagnostics)
+}
+
+// diagnose updates diagnostics with the current state of the CatalogSource.
+func (builder *CatalogSourceBuilder) diagnose(ctx context.Context, diagnostics *CatalogSourceDiagnostics) error {
+	catalogSource, err := builder.apiClient.CatalogSources(builder.Definition.Namespace).Get(ctx,
+		builder.Definition.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get catalogsource %s in namespace %s: %w", builder.Definition.Name,
+			builder.Definition.Namespace, err)
+	}
+
+	builder.Object = catalogSource
+
+	if catalogSource.Spec.Image != "" {
+		diagnostics.Image = catalogSource.Spec.Image
+	}
+
+	if connectionState := catalogSource.Status.GRPCConnectionState; connectionState != nil {
+		diagnostics.record(CatalogSourceConnectionState{
+			State:       connectionState.LastObservedState,
+			Address:     connectionState.Address,
+			LastConnect: connectionState.LastConnectTime.Time,
+			ObservedAt:  time.Now(),
+		})
+	}
+
+	// The registry pods and the packages are diagnosed independently, so that a failing registry is reported
+	// even when the packages cannot be listed.
+	var errs []error
+
+	pods, err := builder.apiClient.CoreV1Interface.Pods(builder.Definition.Namespace).List(ctx,
+		metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", catalogSourcePodLabel, builder.Definition.Name)})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to list the registry pods of catalogsource %s: %w",
+			builder.Definition.Name, err))
+	} else {
+		diagnostics.RegistryPods = nil
+		diagnostics.ImagePullErrors = nil
+
+		for index := range pods.Items {
+			registryPod, imagePullErrors := RegistryPodState(&pods.Items[index])
+			diagnostics.RegistryPods = append(diagnostics.RegistryPods, registryPod)
+			diagnostics.ImagePullErrors = append(diagnostics.ImagePullErrors, imagePullErrors...)
+		}
+	}
+
+	packageManifests, err := builder.apiClient.PackageManifestInterface.PackageManifests(
+		builder.Definition.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("catalog=%s", builder.Definition.Name)})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to list the packagemanifests of catalogsource %s: %w",
+			builder.Definition.Name, err))
+	} else {
+		diagnostics.Packages = nil
+
+		for _, packageManifest := range packageManifests.Items {
+			diagnostics.Packages = append(diagnostics.Packages, packageManifest.Name)
+		}
+
+		slices.Sort(diagnostics.Packages)
+	}
+
+	return errors.Join(errs...)
+}
+
+// RegistryPodState returns the state of a registry pod, with the image pull errors of its containers.
+func RegistryPodState(pod *corev1.Pod) (CatalogSourceRegistryPod, []string) {
+	registryPod := CatalogSourceRegistryPod{
+		Name:    pod.Name,
+		Node:    pod.Spec.NodeName,
+		Phase:   pod.Status.Phase,
+		Reason:  pod.Status.Reason,
+		Message: pod.Status.Message,
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			registryPod.Ready = condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	var imagePullErrors []string
+
+	for _, containerStatuses := range [][]corev1.ContainerStatus{
+		pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, containerStatus := range containerStatuses {
+			registryPod.Restarts += containerStatus.RestartCount
+
+			if containerStatus.Ready {
+				continue
+			}
+
+			var reason, message string
+
+			switch {
+			case containerStatus.State.Waiting != nil:
+				reason, message = containerStatus.State.Waiting.Reason, containerStatus.State.Waiting.Message
+			case containerStatus.State.Terminated != nil:
+				reason, message = containerStatus.State.Terminated.Reason, containerStatus.State.Terminated.Message
+			}
+
+			if slices.Contains(imagePullReasons, reason) {
+				imagePullErrors = append(imagePullErrors, fmt.Sprintf("pod %s container %s %s: %s", pod.Name,
+					containerStatus.Name, reason, message))
+			}
+
+			if registryPod.Reason == "" && reason != "" {
+				registryPod.Reason, registryPod.Message = reason, message
+			}
+		}
+	}
+
+	return registryPod, imagePullErrors
+}
+
+// WaitUntilReady waits until the CatalogSource is READY and serves every package of packages. It fails as
+// soon as the registry cannot serve the catalog, e.g. when its index image cannot be pulled. The returned
+// diagnostics include the connection states observed during the wait.
+func (builder *CatalogSourceBuilder) WaitUntilReady(timeout time.Duration,
+	packages ...string) (*CatalogSourceDiagnostics, error) {
+	return builder.WaitUntilReadyWithContext(context.TODO(), timeout, packages...)
+}
+
+// WaitUntilReadyWithContext waits until the CatalogSource is READY and serves every package of packages. It
+// fails as soon as the registry cannot serve the catalog, e.g. when its index image cannot be pulled. The
+// returned diagnostics include the connection states observed during the wait.
+// The wait stops as soon as ctx is cancelled.
+func (builder *CatalogSourceBuilder) WaitUntilReadyWithContext(ctx context.Context, timeout time.Duration,
+	packages ...string) (*CatalogSourceDiagnostics, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	diagnostics := &CatalogSourceDiagnostics{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+		Image:     builder.Definition.Spec.Image,
+	}
+
+	if builder.apiClient.IsDryRun() {
+		glog.V(100).Infof("Dry-run: skipping wait for catalogsource %s", builder.Definition.Name)
+
+		return diagnostics, nil
+	}
+
+	glog.V(100).Infof("Waiting up to %s for catalogsource %s in namespace %s to be ready and serve packages %v",
+		timeout, builder.Definition.Name, builder.Definition.Namespace, packages)
+
+	var (
+		diagnoseErr error
+		readySince  time.Time
+	)
+
+	err := wait.PollUntilContextTimeout(ctx, catalogSourcePollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			diagnoseErr = builder.diagnose(ctx, diagnostics)
+
+			if err := diagnostics.Failure(); err != nil {
+				return false, err
+			}
+
+			if diagnoseErr != nil {
+				glog.V(100).Infof("Failed to diagnose catalogsource %s: %v", builder.Definition.Name, diagnoseErr)
+
+				return false, nil
+			}
+
+			if diagnostics.IsReady(packages...) {
+				return true, nil
+			}
+
+			if diagnostics.State() != catalogSourceReadyState {
+				readySince = time.Time{}
+
+				return false, nil
+			}
+
+			if readySince.IsZero() {
+				readySince = time.Now()
+			}
+
+			if time.Since(readySince) > catalogSourcePackagesGracePeriod {
+				return false, fmt.Errorf("catalog is READY but does not serve packages %v after %s",
+					diagnostics.MissingPackages(packages...), catalogSourcePackagesGracePeriod)
+			}
+
+			return false, nil
+		})
+
+	if err == nil {
+		glog.V(100).Infof("%s is ready", diagnostics)
+
+		return diagnostics, nil
+	}
+
+	if ctx.Err() != nil || wait.Interrupted(err) {
+		err = fmt.Errorf("timed out after %s waiting for connection state READY and packages %v: %w", timeout,
+			diagnostics.MissingPackages(packages...), err)
+
+		if diagnoseErr != nil {
+			err = fmt.Errorf("%w, last diagnostics failed: %w", err, diagnoseErr)
+		}
+	}
+
+	return diagnostics, &CatalogSourceNotReadyError{Diagnostics: diagnostics, Err: err}
+}
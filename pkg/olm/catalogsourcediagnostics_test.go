@@ -0,0 +1,92 @@
+package olm
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRegistryPodState(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		phase                   corev1.PodPhase
+		ready                   bool
+		state                   corev1.ContainerState
+		expectedReason          string
+		expectedFatal           bool
+		expectedImagePullErrors int
+	}{
+		{
+			name:  "ready registry",
+			phase: corev1.PodRunning,
+			ready: true,
+			state: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		},
+		{
+			name:           "registry image being pulled",
+			phase:          corev1.PodPending,
+			state:          corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull"}},
+			expectedReason: "ErrImagePull",
+			// The kubelet retries the pull before backing off.
+			expectedImagePullErrors: 1,
+		},
+		{
+			name:                    "registry image cannot be pulled",
+			phase:                   corev1.PodPending,
+			state:                   corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			expectedReason:          "ImagePullBackOff",
+			expectedFatal:           true,
+			expectedImagePullErrors: 1,
+		},
+		{
+			name:           "crashing registry",
+			phase:          corev1.PodRunning,
+			state:          corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			expectedReason: "CrashLoopBackOff",
+			expectedFatal:  true,
+		},
+		{
+			name:           "failed registry",
+			phase:          corev1.PodFailed,
+			state:          corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error"}},
+			expectedReason: "Error",
+			expectedFatal:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			readyStatus := corev1.ConditionFalse
+			if testCase.ready {
+				readyStatus = corev1.ConditionTrue
+			}
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "registry-pod"},
+				Status: corev1.PodStatus{
+					Phase:      testCase.phase,
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{Name: "registry-server", Ready: testCase.ready, State: testCase.state},
+					},
+				},
+			}
+
+			registryPod, imagePullErrors := RegistryPodState(pod)
+
+			if registryPod.Ready != testCase.ready || registryPod.Reason != testCase.expectedReason {
+				t.Errorf("expected ready %t with reason %q, got %t with %q", testCase.ready,
+					testCase.expectedReason, registryPod.Ready, registryPod.Reason)
+			}
+
+			if registryPod.IsFatal() != testCase.expectedFatal {
+				t.Errorf("expected fatal %t, got %t", testCase.expectedFatal, registryPod.IsFatal())
+			}
+
+			if len(imagePullErrors) != testCase.expectedImagePullErrors {
+				t.Errorf("expected %d image pull errors, got %v", testCase.expectedImagePullErrors, imagePullErrors)
+			}
+		})
+	}
+}
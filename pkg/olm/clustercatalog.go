@@ -0,0 +1,241 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/msg"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm/ocv1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterCatalogBuilder provides a struct for the OLM v1 ClusterCatalog object from the cluster and a
+// ClusterCatalog definition.
+type ClusterCatalogBuilder struct {
+	// ClusterCatalog definition. Used to create ClusterCatalog object with minimum set of required elements.
+	Definition *ocv1.ClusterCatalog
+	// Created ClusterCatalog object on the cluster.
+	Object *ocv1.ClusterCatalog
+	// api client to interact with the cluster.
+	apiClient *clients.Settings
+	// errorMsg is processed before ClusterCatalog object is created.
+	errorMsg string
+}
+
+// NewClusterCatalogBuilder returns a ClusterCatalogBuilder serving the File-Based Catalog image imageRef.
+func NewClusterCatalogBuilder(apiClient *clients.Settings, name, imageRef string) *ClusterCatalogBuilder {
+	glog.V(100).Infof("Initializing new ClusterCatalogBuilder structure with name '%s' and image '%s'", name,
+		imageRef)
+
+	builder := &ClusterCatalogBuilder{
+		apiClient: apiClient,
+		Definition: &ocv1.ClusterCatalog{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: ocv1.ClusterCatalogSpec{
+				Source: ocv1.CatalogSource{
+					Type:  ocv1.SourceTypeImage,
+					Image: &ocv1.ImageSource{Ref: imageRef},
+				},
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the ClusterCatalog is empty")
+
+		builder.errorMsg = "ClusterCatalog 'name' cannot be empty"
+	}
+
+	if imageRef == "" {
+		glog.V(100).Infof("The image of the ClusterCatalog is empty")
+
+		builder.errorMsg = "ClusterCatalog 'imageRef' cannot be empty"
+	}
+
+	return builder
+}
+
+// WithPriority sets the priority of the ClusterCatalog among the catalogs providing the same bundle.
+func (builder *ClusterCatalogBuilder) WithPriority(priority int32) *ClusterCatalogBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining ClusterCatalog builder object with priority: %d", priority)
+
+	builder.Definition.Spec.Priority = priority
+
+	return builder
+}
+
+// WithPollInterval makes catalogd resolve the image tag of the ClusterCatalog again every given minutes.
+func (builder *ClusterCatalogBuilder) WithPollInterval(minutes int) *ClusterCatalogBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining ClusterCatalog builder object with poll interval of %d minutes", minutes)
+
+	if minutes <= 0 {
+		builder.errorMsg = "ClusterCatalog poll interval must be positive"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Source.Image.PollIntervalMinutes = &minutes
+
+	return builder
+}
+
+// PullClusterCatalog loads an existing ClusterCatalog into ClusterCatalogBuilder struct.
+func PullClusterCatalog(apiClient *clients.Settings, name string) (*ClusterCatalogBuilder, error) {
+	glog.V(100).Infof("Pulling existing ClusterCatalog name: %s", name)
+
+	builder := &ClusterCatalogBuilder{
+		apiClient: apiClient,
+		Definition: &ocv1.ClusterCatalog{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("ClusterCatalog name is empty")
+
+		builder.errorMsg = "ClusterCatalog 'name' cannot be empty"
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("ClusterCatalog object %s doesn't exist", name)
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// Get returns the ClusterCatalog object if found.
+func (builder *ClusterCatalogBuilder) Get() (*ocv1.ClusterCatalog, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting ClusterCatalog object %s", builder.Definition.Name)
+
+	clusterCatalog := &ocv1.ClusterCatalog{}
+
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{Name: builder.Definition.Name}, clusterCatalog)
+	if err != nil {
+		glog.V(100).Infof("ClusterCatalog object %s doesn't exist", builder.Definition.Name)
+
+		return nil, err
+	}
+
+	return clusterCatalog, nil
+}
+
+// Exists checks whether the given ClusterCatalog exists.
+func (builder *ClusterCatalogBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if ClusterCatalog %s exists", builder.Definition.Name)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create makes a ClusterCatalog in the cluster and stores the created object in struct.
+func (builder *ClusterCatalogBuilder) Create() (*ClusterCatalogBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the ClusterCatalog %s", builder.Definition.Name)
+
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunCreate, builder.Definition)
+	}
+
+	var err error
+	if !builder.Exists() {
+		err = builder.apiClient.Create(context.TODO(), builder.Definition)
+		if err == nil {
+			builder.Object = builder.Definition
+		}
+	}
+
+	return builder, err
+}
+
+// Delete removes a ClusterCatalog.
+func (builder *ClusterCatalogBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting ClusterCatalog %s", builder.Definition.Name)
+
+	if builder.apiClient.IsDryRun() {
+		builder.Object = nil
+
+		return builder.apiClient.RecordDryRun(clients.DryRunDelete, builder.Definition)
+	}
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Definition)
+	if err != nil {
+		return fmt.Errorf("cannot delete ClusterCatalog: %w", err)
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *ClusterCatalogBuilder) validate() (bool, error) {
+	resourceCRD := "ClusterCatalog"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
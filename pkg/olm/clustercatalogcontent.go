@@ -0,0 +1,244 @@
+package olm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/golang/glog"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// catalogMetasPath serves the catalog blobs filtered by schema and package, when catalogd enables it.
+	catalogMetasPath = "/api/v1/metas"
+	// catalogAllPath serves the whole catalog as a stream of blobs.
+	catalogAllPath = "/api/v1/all"
+
+	bundleSchema            = "olm.bundle"
+	packagePropertyType     = "olm.package"
+	csvMetadataPropertyType = "olm.csv.metadata"
+	bundleObjectType        = "olm.bundle.object"
+	almExamplesAnnotation   = "alm-examples"
+)
+
+// CatalogBundle is a bundle served by a ClusterCatalog.
+type CatalogBundle struct {
+	Name    string
+	Package string
+	Image   string
+	Version string
+	// Annotations of the bundle ClusterServiceVersion, e.g. alm-examples.
+	Annotations map[string]string
+}
+
+// GetAlmExamples returns the alm-examples annotation of the bundle.
+func (bundle *CatalogBundle) GetAlmExamples() (string, error) {
+	if example, ok := bundle.Annotations[almExamplesAnnotation]; ok {
+		return example, nil
+	}
+
+	return "", fmt.Errorf("%s not found in bundle %s of package %s", almExamplesAnnotation, bundle.Name,
+		bundle.Package)
+}
+
+// catalogBlob is a File-Based Catalog blob, only the fields of the olm.bundle schema are decoded.
+type catalogBlob struct {
+	Schema     string `json:"schema"`
+	Name       string `json:"name"`
+	Package    string `json:"package"`
+	Image      string `json:"image"`
+	Properties []struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	} `json:"properties"`
+}
+
+// GetBundles returns the bundles of packageName served by the ClusterCatalog. The content is read from catalogd
+// through the API server service proxy, so no route to the catalogd service is needed.
+func (builder *ClusterCatalogBuilder) GetBundles(ctx context.Context, packageName string) ([]CatalogBundle, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting the bundles of package %s from ClusterCatalog %s", packageName,
+		builder.Definition.Name)
+
+	catalog, err := builder.Get()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get ClusterCatalog %s: %w", builder.Definition.Name, err)
+	}
+
+	if catalog.Status.URLs == nil || catalog.Status.URLs.Base == "" {
+		return nil, fmt.Errorf("ClusterCatalog %s is not served yet", builder.Definition.Name)
+	}
+
+	baseURL, err := url.Parse(catalog.Status.URLs.Base)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the URL of ClusterCatalog %s: %w", builder.Definition.Name, err)
+	}
+
+	// The base URL is https://<service>.<namespace>.svc[:port]/catalogs/<name>.
+	hostParts := strings.Split(baseURL.Hostname(), ".")
+	if len(hostParts) < 2 {
+		return nil, fmt.Errorf("unexpected URL %s of ClusterCatalog %s", baseURL, builder.Definition.Name)
+	}
+
+	port := baseURL.Port()
+	if port == "" {
+		port = "443"
+	}
+
+	services := builder.apiClient.K8sClient.CoreV1().Services(hostParts[1])
+
+	stream, err := services.ProxyGet(baseURL.Scheme, hostParts[0], port, baseURL.Path+catalogMetasPath,
+		map[string]string{"schema": bundleSchema, "package": packageName}).Stream(ctx)
+	if k8serrors.IsNotFound(err) {
+		glog.V(100).Infof("The metas endpoint of ClusterCatalog %s is not enabled, reading the whole catalog",
+			builder.Definition.Name)
+
+		stream, err = services.ProxyGet(baseURL.Scheme, hostParts[0], port, baseURL.Path+catalogAllPath,
+			nil).Stream(ctx)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the content of ClusterCatalog %s: %w", builder.Definition.Name, err)
+	}
+
+	defer stream.Close()
+
+	var bundles []CatalogBundle
+
+	decoder := json.NewDecoder(stream)
+
+	for {
+		blob := catalogBlob{}
+
+		err := decoder.Decode(&blob)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode the content of ClusterCatalog %s: %w", builder.Definition.Name, err)
+		}
+
+		if blob.Schema != bundleSchema || blob.Package != packageName {
+			continue
+		}
+
+		bundle, err := blob.toBundle()
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode bundle %s of ClusterCatalog %s: %w", blob.Name,
+				builder.Definition.Name, err)
+		}
+
+		bundles = append(bundles, bundle)
+	}
+
+	return bundles, nil
+}
+
+// GetBundle returns the bundle bundleName of packageName served by the ClusterCatalog, e.g. the bundle installed
+// by a ClusterExtension.
+func (builder *ClusterCatalogBuilder) GetBundle(
+	ctx context.Context, packageName, bundleName string) (*CatalogBundle, error) {
+	bundles, err := builder.GetBundles(ctx, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range bundles {
+		if bundles[index].Name == bundleName {
+			return &bundles[index], nil
+		}
+	}
+
+	return nil, fmt.Errorf("bundle %s of package %s not found in ClusterCatalog %s", bundleName, packageName,
+		builder.Definition.Name)
+}
+
+// toBundle extracts the version and ClusterServiceVersion annotations from the properties of the blob. Recent
+// catalogs carry the annotations in an olm.csv.metadata property, older ones only embed the whole CSV.
+func (blob *catalogBlob) toBundle() (CatalogBundle, error) {
+	bundle := CatalogBundle{Name: blob.Name, Package: blob.Package, Image: blob.Image}
+
+	for _, property := range blob.Properties {
+		switch property.Type {
+		case packagePropertyType:
+			value := struct {
+				Version string `json:"version"`
+			}{}
+
+			if err := json.Unmarshal(property.Value, &value); err != nil {
+				return bundle, err
+			}
+
+			bundle.Version = value.Version
+		case csvMetadataPropertyType:
+			value := struct {
+				Annotations map[string]string `json:"annotations"`
+			}{}
+
+			if err := json.Unmarshal(property.Value, &value); err != nil {
+				return bundle, err
+			}
+
+			bundle.Annotations = value.Annotations
+		case bundleObjectType:
+			if bundle.Annotations != nil {
+				continue
+			}
+
+			annotations, err := csvAnnotationsFromBundleObject(property.Value)
+			if err != nil {
+				return bundle, err
+			}
+
+			if annotations != nil {
+				bundle.Annotations = annotations
+			}
+		}
+	}
+
+	return bundle, nil
+}
+
+// csvAnnotationsFromBundleObject returns the annotations of a base64 encoded bundle object if it is the
+// ClusterServiceVersion, nil otherwise.
+func csvAnnotationsFromBundleObject(value json.RawMessage) (map[string]string, error) {
+	object := struct {
+		Data string `json:"data"`
+	}{}
+
+	if err := json.Unmarshal(value, &object); err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(object.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}{}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	if manifest.Kind != "ClusterServiceVersion" {
+		return nil, nil
+	}
+
+	return manifest.Metadata.Annotations, nil
+}
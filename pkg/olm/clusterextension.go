@@ -0,0 +1,374 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/msg"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm/ocv1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterExtensionBuilder provides a struct for the OLM v1 ClusterExtension object from the cluster and a
+// ClusterExtension definition.
+type ClusterExtensionBuilder struct {
+	// ClusterExtension definition. Used to create ClusterExtension object with minimum set of required elements.
+	Definition *ocv1.ClusterExtension
+	// Created ClusterExtension object on the cluster.
+	Object *ocv1.ClusterExtension
+	// api client to interact with the cluster.
+	apiClient *clients.Settings
+	// errorMsg is processed before ClusterExtension object is created.
+	errorMsg string
+}
+
+// NewClusterExtensionBuilder returns a ClusterExtensionBuilder installing packageName from the ClusterCatalogs
+// into installNamespace, with the objects of the bundle managed by serviceAccount.
+func NewClusterExtensionBuilder(
+	apiClient *clients.Settings, name, packageName, installNamespace, serviceAccount string) *ClusterExtensionBuilder {
+	glog.V(100).Infof("Initializing new ClusterExtensionBuilder structure with name '%s', package '%s', "+
+		"namespace '%s' and service account '%s'", name, packageName, installNamespace, serviceAccount)
+
+	builder := &ClusterExtensionBuilder{
+		apiClient: apiClient,
+		Definition: &ocv1.ClusterExtension{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: ocv1.ClusterExtensionSpec{
+				Namespace:      installNamespace,
+				ServiceAccount: ocv1.ServiceAccountReference{Name: serviceAccount},
+				Source: ocv1.SourceConfig{
+					SourceType: ocv1.SourceTypeCatalog,
+					Catalog:    &ocv1.CatalogFilter{PackageName: packageName},
+				},
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the ClusterExtension is empty")
+
+		builder.errorMsg = "ClusterExtension 'name' cannot be empty"
+	}
+
+	if packageName == "" {
+		glog.V(100).Infof("The package of the ClusterExtension is empty")
+
+		builder.errorMsg = "ClusterExtension 'packageName' cannot be empty"
+	}
+
+	if installNamespace == "" {
+		glog.V(100).Infof("The install namespace of the ClusterExtension is empty")
+
+		builder.errorMsg = "ClusterExtension 'installNamespace' cannot be empty"
+	}
+
+	if serviceAccount == "" {
+		glog.V(100).Infof("The service account of the ClusterExtension is empty")
+
+		builder.errorMsg = "ClusterExtension 'serviceAccount' cannot be empty"
+	}
+
+	return builder
+}
+
+// WithChannels restricts the bundles of the ClusterExtension to the given channels.
+func (builder *ClusterExtensionBuilder) WithChannels(channels ...string) *ClusterExtensionBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining ClusterExtension builder object with channels: %v", channels)
+
+	builder.Definition.Spec.Source.Catalog.Channels = channels
+
+	return builder
+}
+
+// WithVersion pins the bundles of the ClusterExtension to a version or semver range, e.g. "24.6.2",
+// ">=24.6.0 <24.9.0" or "24.6.x". The range is validated by operator-controller on creation.
+func (builder *ClusterExtensionBuilder) WithVersion(versionRange string) *ClusterExtensionBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining ClusterExtension builder object with version range: %s", versionRange)
+
+	builder.Definition.Spec.Source.Catalog.Version = versionRange
+
+	return builder
+}
+
+// WithCatalogs restricts the bundles of the ClusterExtension to the given ClusterCatalogs.
+func (builder *ClusterExtensionBuilder) WithCatalogs(catalogs ...string) *ClusterExtensionBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining ClusterExtension builder object with catalogs: %v", catalogs)
+
+	if len(catalogs) == 0 {
+		builder.Definition.Spec.Source.Catalog.Selector = nil
+
+		return builder
+	}
+
+	builder.Definition.Spec.Source.Catalog.Selector = &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      ocv1.CatalogNameLabel,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   catalogs,
+		}},
+	}
+
+	return builder
+}
+
+// WithUpgradeConstraintPolicy sets whether the upgrades follow the catalog upgrade graph (CatalogProvided) or
+// may go to any version of the range, downgrades included (SelfCertified).
+func (builder *ClusterExtensionBuilder) WithUpgradeConstraintPolicy(policy string) *ClusterExtensionBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining ClusterExtension builder object with upgrade constraint policy: %s", policy)
+
+	if policy != ocv1.UpgradeConstraintPolicyCatalogProvided && policy != ocv1.UpgradeConstraintPolicySelfCertified {
+		builder.errorMsg = fmt.Sprintf("invalid ClusterExtension upgrade constraint policy %q", policy)
+
+		return builder
+	}
+
+	builder.Definition.Spec.Source.Catalog.UpgradeConstraintPolicy = policy
+
+	return builder
+}
+
+// WithWatchNamespace sets the namespace the operator watches. It is required by the bundles supporting only the
+// OwnNamespace or SingleNamespace install modes.
+func (builder *ClusterExtensionBuilder) WithWatchNamespace(watchNamespace string) *ClusterExtensionBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining ClusterExtension builder object with watch namespace: %s", watchNamespace)
+
+	inline, err := json.Marshal(map[string]string{"watchNamespace": watchNamespace})
+	if err != nil {
+		builder.errorMsg = fmt.Sprintf("cannot marshal ClusterExtension configuration: %v", err)
+
+		return builder
+	}
+
+	builder.Definition.Spec.Config = &ocv1.ClusterExtensionConfig{
+		ConfigType: ocv1.ConfigTypeInline,
+		Inline:     &apiextensionsv1.JSON{Raw: inline},
+	}
+
+	return builder
+}
+
+// PullClusterExtension loads an existing ClusterExtension into ClusterExtensionBuilder struct.
+func PullClusterExtension(apiClient *clients.Settings, name string) (*ClusterExtensionBuilder, error) {
+	glog.V(100).Infof("Pulling existing ClusterExtension name: %s", name)
+
+	builder := &ClusterExtensionBuilder{
+		apiClient: apiClient,
+		Definition: &ocv1.ClusterExtension{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("ClusterExtension name is empty")
+
+		builder.errorMsg = "ClusterExtension 'name' cannot be empty"
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("ClusterExtension object %s doesn't exist", name)
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// Get returns the ClusterExtension object if found.
+func (builder *ClusterExtensionBuilder) Get() (*ocv1.ClusterExtension, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting ClusterExtension object %s", builder.Definition.Name)
+
+	clusterExtension := &ocv1.ClusterExtension{}
+
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{Name: builder.Definition.Name}, clusterExtension)
+	if err != nil {
+		glog.V(100).Infof("ClusterExtension object %s doesn't exist", builder.Definition.Name)
+
+		return nil, err
+	}
+
+	return clusterExtension, nil
+}
+
+// Exists checks whether the given ClusterExtension exists.
+func (builder *ClusterExtensionBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if ClusterExtension %s exists", builder.Definition.Name)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create makes a ClusterExtension in the cluster and stores the created object in struct.
+func (builder *ClusterExtensionBuilder) Create() (*ClusterExtensionBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the ClusterExtension %s", builder.Definition.Name)
+
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunCreate, builder.Definition)
+	}
+
+	var err error
+	if !builder.Exists() {
+		err = builder.apiClient.Create(context.TODO(), builder.Definition)
+		if err == nil {
+			builder.Object = builder.Definition
+		}
+	}
+
+	return builder, err
+}
+
+// Delete removes a ClusterExtension. operator-controller then uninstalls the objects of its bundle.
+func (builder *ClusterExtensionBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting ClusterExtension %s", builder.Definition.Name)
+
+	if builder.apiClient.IsDryRun() {
+		builder.Object = nil
+
+		return builder.apiClient.RecordDryRun(clients.DryRunDelete, builder.Definition)
+	}
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Definition)
+	if err != nil {
+		return fmt.Errorf("cannot delete ClusterExtension: %w", err)
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// DeleteAndWait removes a ClusterExtension and waits until operator-controller finished uninstalling its bundle
+// and the ClusterExtension is gone.
+func (builder *ClusterExtensionBuilder) DeleteAndWait(pollInterval, timeout time.Duration) error {
+	return builder.DeleteAndWaitWithContext(context.TODO(), pollInterval, timeout)
+}
+
+// DeleteAndWaitWithContext removes a ClusterExtension and waits until operator-controller finished uninstalling
+// its bundle and the ClusterExtension is gone.
+// The wait stops as soon as ctx is cancelled.
+func (builder *ClusterExtensionBuilder) DeleteAndWaitWithContext(
+	ctx context.Context, pollInterval, timeout time.Duration) error {
+	if err := builder.Delete(); err != nil {
+		return err
+	}
+
+	if builder.apiClient.IsDryRun() {
+		return nil
+	}
+
+	glog.V(100).Infof("Waiting for ClusterExtension %s to be deleted", builder.Definition.Name)
+
+	return wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		err := builder.apiClient.Get(ctx, goclient.ObjectKey{Name: builder.Definition.Name}, &ocv1.ClusterExtension{})
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+
+		return false, nil
+	})
+}
+
+// GetInstalledBundle returns the bundle installed by the ClusterExtension, nil if none is installed yet.
+func (builder *ClusterExtensionBuilder) GetInstalledBundle() (*ocv1.BundleMetadata, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	clusterExtension, err := builder.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	if clusterExtension.Status.Install == nil {
+		return nil, nil
+	}
+
+	return &clusterExtension.Status.Install.Bundle, nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *ClusterExtensionBuilder) validate() (bool, error) {
+	resourceCRD := "ClusterExtension"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
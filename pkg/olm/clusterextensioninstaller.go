@@ -0,0 +1,210 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// clusterAdminRole is the ClusterRole bound to the installer service account by
+// CreateClusterExtensionInstallerWithClusterAdmin.
+const clusterAdminRole = "cluster-admin"
+
+// ClusterExtensionInstallerRules returns the rules operator-controller needs to install and uninstall the bundle
+// of the ClusterExtension clusterExtensionName: its CRDs, its workloads and the RBAC of the operator. The bind
+// and escalate verbs let the installer grant the operator the permissions of its CSV without holding them.
+// Bundles shipping other kinds of objects need additional rules.
+func ClusterExtensionInstallerRules(clusterExtensionName string) []rbacv1.PolicyRule {
+	manage := []string{"create", "delete", "get", "list", "patch", "update", "watch"}
+
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{"olm.operatorframework.io"},
+			Resources:     []string{"clusterextensions/finalizers"},
+			ResourceNames: []string{clusterExtensionName},
+			Verbs:         []string{"update"},
+		},
+		{
+			APIGroups: []string{"apiextensions.k8s.io"},
+			Resources: []string{"customresourcedefinitions"},
+			Verbs:     manage,
+		},
+		{
+			APIGroups: []string{rbacv1.GroupName},
+			Resources: []string{"clusterroles", "clusterrolebindings", "roles", "rolebindings"},
+			Verbs:     append([]string{"bind", "escalate"}, manage...),
+		},
+		{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps", "secrets", "serviceaccounts", "services"},
+			Verbs:     manage,
+		},
+		{
+			APIGroups: []string{"apps"},
+			Resources: []string{"deployments"},
+			Verbs:     manage,
+		},
+		{
+			APIGroups: []string{"admissionregistration.k8s.io"},
+			Resources: []string{"mutatingwebhookconfigurations", "validatingwebhookconfigurations"},
+			Verbs:     manage,
+		},
+		{
+			APIGroups: []string{"monitoring.coreos.com"},
+			Resources: []string{"prometheusrules", "servicemonitors"},
+			Verbs:     manage,
+		},
+	}
+}
+
+// CreateClusterExtensionInstaller creates the service account a ClusterExtension installs its bundle with, and a
+// ClusterRole holding rules bound to it. Existing objects are kept, so it can be called again for the same service
+// account.
+func CreateClusterExtensionInstaller(apiClient *clients.Settings, serviceAccount, nsname string,
+	rules []rbacv1.PolicyRule) error {
+	if len(rules) == 0 {
+		return fmt.Errorf("ClusterExtension installer 'rules' cannot be empty")
+	}
+
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterExtensionInstallerName(serviceAccount, nsname)},
+		Rules:      rules,
+	}
+
+	return createClusterExtensionInstaller(apiClient, serviceAccount, nsname, clusterRole)
+}
+
+// CreateClusterExtensionInstallerWithClusterAdmin creates the service account a ClusterExtension installs its
+// bundle with, and binds it to cluster-admin. It is meant for bundles whose objects are not covered by a scoped
+// ClusterRole.
+func CreateClusterExtensionInstallerWithClusterAdmin(apiClient *clients.Settings, serviceAccount,
+	nsname string) error {
+	return createClusterExtensionInstaller(apiClient, serviceAccount, nsname, nil)
+}
+
+// createClusterExtensionInstaller creates the service account and binds it to clusterRole, created first, or to
+// cluster-admin when clusterRole is nil.
+func createClusterExtensionInstaller(apiClient *clients.Settings, serviceAccount, nsname string,
+	clusterRole *rbacv1.ClusterRole) error {
+	if apiClient == nil {
+		return fmt.Errorf("cannot create ClusterExtension installer with nil apiClient")
+	}
+
+	if serviceAccount == "" || nsname == "" {
+		return fmt.Errorf("ClusterExtension installer 'serviceAccount' and 'nsname' cannot be empty")
+	}
+
+	roleName := clusterAdminRole
+	if clusterRole != nil {
+		roleName = clusterRole.Name
+	}
+
+	glog.V(100).Infof("Creating ClusterExtension installer service account %s in namespace %s bound to "+
+		"ClusterRole %s", serviceAccount, nsname, roleName)
+
+	account := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceAccount, Namespace: nsname},
+	}
+
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterExtensionInstallerName(serviceAccount, nsname)},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     roleName,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      serviceAccount,
+			Namespace: nsname,
+		}},
+	}
+
+	if apiClient.IsDryRun() {
+		var errs []error
+		if clusterRole != nil {
+			errs = append(errs, apiClient.RecordDryRun(clients.DryRunCreate, clusterRole))
+		}
+
+		return errors.Join(append(errs, apiClient.RecordDryRun(clients.DryRunCreate, account),
+			apiClient.RecordDryRun(clients.DryRunCreate, binding))...)
+	}
+
+	_, err := apiClient.K8sClient.CoreV1().ServiceAccounts(nsname).Create(context.TODO(), account,
+		metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("cannot create service account %s in namespace %s: %w", serviceAccount, nsname, err)
+	}
+
+	if clusterRole != nil {
+		_, err = apiClient.K8sClient.RbacV1().ClusterRoles().Create(context.TODO(), clusterRole,
+			metav1.CreateOptions{})
+		if err != nil && !k8serrors.IsAlreadyExists(err) {
+			return fmt.Errorf("cannot create ClusterRole %s: %w", clusterRole.Name, err)
+		}
+	}
+
+	_, err = apiClient.K8sClient.RbacV1().ClusterRoleBindings().Create(context.TODO(), binding,
+		metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("cannot create ClusterRoleBinding %s: %w", binding.Name, err)
+	}
+
+	return nil
+}
+
+// DeleteClusterExtensionInstaller removes the service account, ClusterRoleBinding and ClusterRole created by
+// CreateClusterExtensionInstaller or CreateClusterExtensionInstallerWithClusterAdmin. It must only be called
+// once the ClusterExtension is deleted, since operator-controller uninstalls the bundle with the service account.
+func DeleteClusterExtensionInstaller(apiClient *clients.Settings, serviceAccount, nsname string) error {
+	if apiClient == nil {
+		return fmt.Errorf("cannot delete ClusterExtension installer with nil apiClient")
+	}
+
+	glog.V(100).Infof("Deleting ClusterExtension installer service account %s in namespace %s", serviceAccount,
+		nsname)
+
+	name := clusterExtensionInstallerName(serviceAccount, nsname)
+
+	if apiClient.IsDryRun() {
+		return errors.Join(
+			apiClient.RecordDryRun(clients.DryRunDelete, &rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: name}}),
+			apiClient.RecordDryRun(clients.DryRunDelete, &rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: name}}),
+			apiClient.RecordDryRun(clients.DryRunDelete, &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: serviceAccount, Namespace: nsname}}))
+	}
+
+	err := apiClient.K8sClient.RbacV1().ClusterRoleBindings().Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("cannot delete ClusterRoleBinding %s: %w", name, err)
+	}
+
+	// The ClusterRole is not created when the installer is bound to cluster-admin.
+	err = apiClient.K8sClient.RbacV1().ClusterRoles().Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("cannot delete ClusterRole %s: %w", name, err)
+	}
+
+	err = apiClient.K8sClient.CoreV1().ServiceAccounts(nsname).Delete(context.TODO(), serviceAccount,
+		metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("cannot delete service account %s in namespace %s: %w", serviceAccount, nsname, err)
+	}
+
+	return nil
+}
+
+// clusterExtensionInstallerName returns the name of the ClusterRole and ClusterRoleBinding of the installer
+// service account, unique per namespace.
+func clusterExtensionInstallerName(serviceAccount, nsname string) string {
+	return fmt.Sprintf("%s-%s-installer", nsname, serviceAccount)
+}
@@ -0,0 +1,96 @@
+package olm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testInstallerServiceAccount = "gpu-operator-installer"
+	testInstallerName           = "nvidia-gpu-operator-gpu-operator-installer-installer"
+)
+
+func TestCreateClusterExtensionInstaller(t *testing.T) {
+	testCases := []struct {
+		name          string
+		clusterAdmin  bool
+		rules         []rbacv1.PolicyRule
+		expectedRole  string
+		expectedError bool
+	}{
+		{
+			name:         "scoped ClusterRole",
+			rules:        ClusterExtensionInstallerRules("gpu-operator"),
+			expectedRole: testInstallerName,
+		},
+		{
+			name:         "cluster-admin",
+			clusterAdmin: true,
+			expectedRole: clusterAdminRole,
+		},
+		{
+			name:          "no rules",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient := clients.GetTestClients(clients.TestClientParams{})
+
+			var err error
+			if testCase.clusterAdmin {
+				err = CreateClusterExtensionInstallerWithClusterAdmin(apiClient, testInstallerServiceAccount,
+					testSubscriptionNamespace)
+			} else {
+				err = CreateClusterExtensionInstaller(apiClient, testInstallerServiceAccount,
+					testSubscriptionNamespace, testCase.rules)
+			}
+
+			if testCase.expectedError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			binding, err := apiClient.K8sClient.RbacV1().ClusterRoleBindings().Get(context.TODO(),
+				testInstallerName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if binding.RoleRef.Name != testCase.expectedRole {
+				t.Errorf("expected the installer to be bound to %s, got %s", testCase.expectedRole,
+					binding.RoleRef.Name)
+			}
+
+			_, err = apiClient.K8sClient.RbacV1().ClusterRoles().Get(context.TODO(), testInstallerName,
+				metav1.GetOptions{})
+			if testCase.clusterAdmin != k8serrors.IsNotFound(err) {
+				t.Errorf("expected the installer ClusterRole to exist %t, got %v", !testCase.clusterAdmin, err)
+			}
+
+			if err := DeleteClusterExtensionInstaller(apiClient, testInstallerServiceAccount,
+				testSubscriptionNamespace); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, err = apiClient.K8sClient.RbacV1().ClusterRoleBindings().Get(context.TODO(), testInstallerName,
+				metav1.GetOptions{})
+			if !k8serrors.IsNotFound(err) {
+				t.Errorf("expected the installer ClusterRoleBinding to be deleted, got %v", err)
+			}
+		})
+	}
+}
@@ -130,6 +130,45 @@ func (builder *InstallPlanBuilder) Update() (*InstallPlanBuilder, error) {
 	return builder, err
 }
 
+// PullInstallPlan loads an existing installplan into the InstallPlanBuilder struct.
+func PullInstallPlan(apiClient *clients.Settings, name, nsname string) (*InstallPlanBuilder, error) {
+	glog.V(100).Infof("Pulling existing installplan %s in namespace %s", name, nsname)
+
+	builder := NewInstallPlanBuilder(apiClient, name, nsname)
+
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("installplan object %s does not exist in namespace %s", name, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// Approve approves the installplan, so OLM executes it.
+func (builder *InstallPlanBuilder) Approve() (*InstallPlanBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Approving installplan %s in namespace %s", builder.Definition.Name,
+		builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("installplan %s cannot be approved because it does not exist",
+			builder.Definition.Name)
+	}
+
+	builder.Definition = builder.Object
+	builder.Definition.Spec.Approved = true
+
+	return builder.Update()
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *InstallPlanBuilder) validate() (bool, error) {
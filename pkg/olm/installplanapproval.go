@@ -0,0 +1,135 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang/glog"
+	operatorsV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/wait"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApproveInstallPlanForCSV approves the pending InstallPlan of a Subscription with Manual approval that installs
+// targetCSV, then waits until the InstallPlan is Complete. An empty targetCSV selects the current CSV of the
+// Subscription, i.e. the next CSV resolved by OLM. The InstallPlan is not approved when its
+// ClusterServiceVersionNames do not include targetCSV. The timeout applies to both the lookup of the pending
+// InstallPlan and its completion. A *wait.InstallPlanFailedError is returned when the InstallPlan fails.
+func ApproveInstallPlanForCSV(ctx context.Context, apiClient *clients.Settings, subscriptionName,
+	subscriptionNamespace, targetCSV string, timeout time.Duration) (*InstallPlanBuilder, error) {
+	if apiClient.IsDryRun() {
+		glog.V(100).Infof("Dry-run: skipping approval of the installplan of subscription %s", subscriptionName)
+
+		return nil, nil
+	}
+
+	subscription, err := PullSubscription(apiClient, subscriptionName, subscriptionNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Object.Spec.InstallPlanApproval != operatorsV1alpha1.ApprovalManual {
+		return nil, fmt.Errorf("subscription %s in namespace %s does not have Manual installplan approval",
+			subscriptionName, subscriptionNamespace)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var installPlan *InstallPlanBuilder
+
+	err = wait.ForObjects(ctx, apiClient, subscriptionNamespace, nil,
+		func(installPlans []*operatorsV1alpha1.InstallPlan) (bool, error) {
+			installPlan, err = findPendingInstallPlan(ctx, apiClient, subscriptionName, subscriptionNamespace,
+				targetCSV, installPlans)
+
+			return installPlan != nil, err
+		}, timeouts.Get(timeouts.InstallPlanCompleteCheckInterval), timeout)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the pending installplan of subscription %s for CSV '%s': %w",
+			subscriptionName, targetCSV, err)
+	}
+
+	glog.V(100).Infof("Approving installplan %s of subscription %s with CSVs %v", installPlan.Definition.Name,
+		subscriptionName, installPlan.Definition.Spec.ClusterServiceVersionNames)
+
+	installPlan, err = installPlan.Approve()
+	if err != nil {
+		return installPlan, fmt.Errorf("failed to approve installplan %s: %w", installPlan.Definition.Name, err)
+	}
+
+	remaining := timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining = time.Until(deadline)
+	}
+
+	return installPlan, wait.InstallPlanCompleteWithContext(ctx, apiClient, installPlan.Definition.Name,
+		installPlan.Definition.Namespace, 0, remaining)
+}
+
+// findPendingInstallPlan returns the InstallPlan of the Subscription awaiting approval among installPlans, nil
+// when OLM did not create it yet. An error is returned when the pending InstallPlan does not install targetCSV.
+func findPendingInstallPlan(ctx context.Context, apiClient *clients.Settings, subscriptionName,
+	subscriptionNamespace, targetCSV string, installPlans []*operatorsV1alpha1.InstallPlan) (*InstallPlanBuilder,
+	error) {
+	subscription, err := apiClient.Subscriptions(subscriptionNamespace).Get(ctx, subscriptionName,
+		metav1.GetOptions{})
+	if err != nil {
+		glog.V(100).Infof("Failed to get subscription %s: %v", subscriptionName, err)
+
+		return nil, nil
+	}
+
+	if targetCSV == "" {
+		targetCSV = subscription.Status.CurrentCSV
+		if targetCSV == "" || targetCSV == subscription.Status.InstalledCSV {
+			glog.V(100).Infof("Subscription %s has not resolved a new CSV yet", subscriptionName)
+
+			return nil, nil
+		}
+	}
+
+	for _, installPlan := range installPlans {
+		if installPlan.Spec.Approved || installPlan.Spec.Approval != operatorsV1alpha1.ApprovalManual ||
+			installPlan.Status.Phase != operatorsV1alpha1.InstallPlanPhaseRequiresApproval ||
+			!isInstallPlanOfSubscription(installPlan, subscription) {
+			continue
+		}
+
+		if !slices.Contains(installPlan.Spec.ClusterServiceVersionNames, targetCSV) {
+			return nil, fmt.Errorf("pending installplan %s installs CSVs %v instead of '%s'", installPlan.Name,
+				installPlan.Spec.ClusterServiceVersionNames, targetCSV)
+		}
+
+		return &InstallPlanBuilder{
+			apiClient:  apiClient,
+			Definition: installPlan,
+			Object:     installPlan,
+		}, nil
+	}
+
+	glog.V(100).Infof("No installplan of subscription %s is awaiting approval yet", subscriptionName)
+
+	return nil, nil
+}
+
+// isInstallPlanOfSubscription reports whether installPlan was created for subscription.
+func isInstallPlanOfSubscription(installPlan *operatorsV1alpha1.InstallPlan,
+	subscription *operatorsV1alpha1.Subscription) bool {
+	if subscription.Status.InstallPlanRef != nil && subscription.Status.InstallPlanRef.Name == installPlan.Name {
+		return true
+	}
+
+	for _, owner := range installPlan.OwnerReferences {
+		if owner.Kind == operatorsV1alpha1.SubscriptionKind && owner.Name == subscription.Name {
+			return true
+		}
+	}
+
+	return false
+}
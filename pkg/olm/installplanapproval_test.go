@@ -0,0 +1,155 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	operatorsV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/wait"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	testInstallPlanName = "install-abcde"
+	testTargetCSV       = "gpu-operator-certified.v25.3.0"
+)
+
+var installPlansResource = schema.GroupVersionResource{
+	Group: "operators.coreos.com", Version: "v1alpha1", Resource: "installplans"}
+
+func TestApproveInstallPlanForCSV(t *testing.T) {
+	testCases := []struct {
+		name          string
+		approval      operatorsV1alpha1.Approval
+		planCSVs      []string
+		resultPhase   operatorsV1alpha1.InstallPlanPhase
+		expectedError string
+	}{
+		{
+			name:        "approved and complete",
+			approval:    operatorsV1alpha1.ApprovalManual,
+			planCSVs:    []string{testTargetCSV},
+			resultPhase: operatorsV1alpha1.InstallPlanPhaseComplete,
+		},
+		{
+			name:          "approved and failed",
+			approval:      operatorsV1alpha1.ApprovalManual,
+			planCSVs:      []string{testTargetCSV},
+			resultPhase:   operatorsV1alpha1.InstallPlanPhaseFailed,
+			expectedError: "InstallPlan install-abcde in namespace nvidia-gpu-operator failed",
+		},
+		{
+			name:          "other csv pending",
+			approval:      operatorsV1alpha1.ApprovalManual,
+			planCSVs:      []string{"gpu-operator-certified.v25.10.0"},
+			expectedError: "instead of 'gpu-operator-certified.v25.3.0'",
+		},
+		{
+			name:          "automatic approval",
+			approval:      operatorsV1alpha1.ApprovalAutomatic,
+			planCSVs:      []string{testTargetCSV},
+			expectedError: "does not have Manual installplan approval",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+				buildApprovalSubscription(testCase.approval),
+				buildPendingInstallPlan(testCase.planCSVs),
+			}})
+
+			if testCase.resultPhase != "" {
+				go completeWhenApproved(t, apiClient, testCase.resultPhase)
+			}
+
+			installPlan, err := ApproveInstallPlanForCSV(context.TODO(), apiClient, testSubscriptionName,
+				testSubscriptionNamespace, testTargetCSV, 2*time.Second)
+
+			if testCase.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if !installPlan.Object.Spec.Approved {
+					t.Error("expected the installplan to be approved")
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Errorf("expected error containing %q, got %v", testCase.expectedError, err)
+			}
+
+			var installPlanError *wait.InstallPlanFailedError
+			if testCase.resultPhase == operatorsV1alpha1.InstallPlanPhaseFailed && !errors.As(err, &installPlanError) {
+				t.Errorf("expected an InstallPlanFailedError, got %T", err)
+			}
+		})
+	}
+}
+
+func buildApprovalSubscription(approval operatorsV1alpha1.Approval) *operatorsV1alpha1.Subscription {
+	return &operatorsV1alpha1.Subscription{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "operators.coreos.com/v1alpha1", Kind: "Subscription"},
+		ObjectMeta: metav1.ObjectMeta{Name: testSubscriptionName, Namespace: testSubscriptionNamespace},
+		Spec: &operatorsV1alpha1.SubscriptionSpec{
+			Package:             testPackage,
+			InstallPlanApproval: approval,
+		},
+		Status: operatorsV1alpha1.SubscriptionStatus{
+			CurrentCSV:     testTargetCSV,
+			InstallPlanRef: &corev1.ObjectReference{Name: testInstallPlanName, Namespace: testSubscriptionNamespace},
+		},
+	}
+}
+
+func buildPendingInstallPlan(csvs []string) *operatorsV1alpha1.InstallPlan {
+	return &operatorsV1alpha1.InstallPlan{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "operators.coreos.com/v1alpha1", Kind: "InstallPlan"},
+		ObjectMeta: metav1.ObjectMeta{Name: testInstallPlanName, Namespace: testSubscriptionNamespace},
+		Spec: operatorsV1alpha1.InstallPlanSpec{
+			ClusterServiceVersionNames: csvs,
+			Approval:                   operatorsV1alpha1.ApprovalManual,
+		},
+		Status: operatorsV1alpha1.InstallPlanStatus{Phase: operatorsV1alpha1.InstallPlanPhaseRequiresApproval},
+	}
+}
+
+// completeWhenApproved acts as OLM: once the InstallPlan is approved, it moves the InstallPlan watched by the
+// waiters to phase.
+func completeWhenApproved(t *testing.T, apiClient *clients.Settings, phase operatorsV1alpha1.InstallPlanPhase) {
+	for range 100 {
+		installPlan, err := apiClient.InstallPlans(testSubscriptionNamespace).Get(context.TODO(),
+			testInstallPlanName, metav1.GetOptions{})
+		if err == nil && installPlan.Spec.Approved {
+			installPlan.TypeMeta = metav1.TypeMeta{APIVersion: "operators.coreos.com/v1alpha1", Kind: "InstallPlan"}
+			installPlan.Status.Phase = phase
+
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(installPlan)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+
+				return
+			}
+
+			if _, err := apiClient.Resource(installPlansResource).Namespace(testSubscriptionNamespace).Update(
+				context.TODO(), &unstructured.Unstructured{Object: content}, metav1.UpdateOptions{}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
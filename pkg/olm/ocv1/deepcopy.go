@@ -0,0 +1,225 @@
+package ocv1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies the receiver into out.
+func (in *ClusterCatalog) DeepCopyInto(out *ClusterCatalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a deep copy of the ClusterCatalog.
+func (in *ClusterCatalog) DeepCopy() *ClusterCatalog {
+	if in == nil {
+		return nil
+	}
+
+	out := new(ClusterCatalog)
+	in.DeepCopyInto(out)
+
+	return out
+}
+
+// DeepCopyObject returns a deep copy of the ClusterCatalog as a runtime.Object.
+func (in *ClusterCatalog) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ClusterCatalogList) DeepCopyInto(out *ClusterCatalogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+
+	if in.Items != nil {
+		out.Items = make([]ClusterCatalog, len(in.Items))
+		for index := range in.Items {
+			in.Items[index].DeepCopyInto(&out.Items[index])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the ClusterCatalogList.
+func (in *ClusterCatalogList) DeepCopy() *ClusterCatalogList {
+	if in == nil {
+		return nil
+	}
+
+	out := new(ClusterCatalogList)
+	in.DeepCopyInto(out)
+
+	return out
+}
+
+// DeepCopyObject returns a deep copy of the ClusterCatalogList as a runtime.Object.
+func (in *ClusterCatalogList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ClusterCatalogSpec) DeepCopyInto(out *ClusterCatalogSpec) {
+	*out = *in
+
+	if in.Source.Image != nil {
+		out.Source.Image = new(ImageSource)
+		*out.Source.Image = *in.Source.Image
+
+		if in.Source.Image.PollIntervalMinutes != nil {
+			out.Source.Image.PollIntervalMinutes = new(int)
+			*out.Source.Image.PollIntervalMinutes = *in.Source.Image.PollIntervalMinutes
+		}
+	}
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ClusterCatalogStatus) DeepCopyInto(out *ClusterCatalogStatus) {
+	*out = *in
+	out.Conditions = deepCopyConditions(in.Conditions)
+
+	if in.ResolvedSource != nil {
+		out.ResolvedSource = new(ResolvedCatalogSource)
+		*out.ResolvedSource = *in.ResolvedSource
+
+		if in.ResolvedSource.Image != nil {
+			out.ResolvedSource.Image = new(ResolvedImageSource)
+			*out.ResolvedSource.Image = *in.ResolvedSource.Image
+		}
+	}
+
+	if in.URLs != nil {
+		out.URLs = new(ClusterCatalogURLs)
+		*out.URLs = *in.URLs
+	}
+
+	if in.LastUnpacked != nil {
+		out.LastUnpacked = in.LastUnpacked.DeepCopy()
+	}
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ClusterExtension) DeepCopyInto(out *ClusterExtension) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy returns a deep copy of the ClusterExtension.
+func (in *ClusterExtension) DeepCopy() *ClusterExtension {
+	if in == nil {
+		return nil
+	}
+
+	out := new(ClusterExtension)
+	in.DeepCopyInto(out)
+
+	return out
+}
+
+// DeepCopyObject returns a deep copy of the ClusterExtension as a runtime.Object.
+func (in *ClusterExtension) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ClusterExtensionList) DeepCopyInto(out *ClusterExtensionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+
+	if in.Items != nil {
+		out.Items = make([]ClusterExtension, len(in.Items))
+		for index := range in.Items {
+			in.Items[index].DeepCopyInto(&out.Items[index])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the ClusterExtensionList.
+func (in *ClusterExtensionList) DeepCopy() *ClusterExtensionList {
+	if in == nil {
+		return nil
+	}
+
+	out := new(ClusterExtensionList)
+	in.DeepCopyInto(out)
+
+	return out
+}
+
+// DeepCopyObject returns a deep copy of the ClusterExtensionList as a runtime.Object.
+func (in *ClusterExtensionList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ClusterExtensionSpec) DeepCopyInto(out *ClusterExtensionSpec) {
+	*out = *in
+
+	if in.Source.Catalog != nil {
+		out.Source.Catalog = new(CatalogFilter)
+		*out.Source.Catalog = *in.Source.Catalog
+
+		if in.Source.Catalog.Channels != nil {
+			out.Source.Catalog.Channels = append([]string(nil), in.Source.Catalog.Channels...)
+		}
+
+		if in.Source.Catalog.Selector != nil {
+			out.Source.Catalog.Selector = in.Source.Catalog.Selector.DeepCopy()
+		}
+	}
+
+	if in.Install != nil {
+		out.Install = new(ClusterExtensionInstall)
+
+		if in.Install.Preflight != nil {
+			out.Install.Preflight = new(PreflightConfig)
+
+			if in.Install.Preflight.CRDUpgradeSafety != nil {
+				out.Install.Preflight.CRDUpgradeSafety = new(CRDUpgradeSafetyPreflightConfig)
+				*out.Install.Preflight.CRDUpgradeSafety = *in.Install.Preflight.CRDUpgradeSafety
+			}
+		}
+	}
+
+	if in.Config != nil {
+		out.Config = new(ClusterExtensionConfig)
+		out.Config.ConfigType = in.Config.ConfigType
+
+		if in.Config.Inline != nil {
+			out.Config.Inline = in.Config.Inline.DeepCopy()
+		}
+	}
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *ClusterExtensionStatus) DeepCopyInto(out *ClusterExtensionStatus) {
+	*out = *in
+	out.Conditions = deepCopyConditions(in.Conditions)
+
+	if in.Install != nil {
+		out.Install = new(ClusterExtensionInstallStatus)
+		*out.Install = *in.Install
+	}
+}
+
+// deepCopyConditions returns a deep copy of conditions.
+func deepCopyConditions(conditions []metav1.Condition) []metav1.Condition {
+	if conditions == nil {
+		return nil
+	}
+
+	copied := make([]metav1.Condition, len(conditions))
+	for index := range conditions {
+		conditions[index].DeepCopyInto(&copied[index])
+	}
+
+	return copied
+}
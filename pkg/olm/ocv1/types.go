@@ -0,0 +1,214 @@
+// Package ocv1 holds the subset of the OLM v1 API (olm.operatorframework.io/v1) used by the test suites: the
+// ClusterCatalog and ClusterExtension kinds served by catalogd and operator-controller.
+package ocv1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// GroupName is the API group of OLM v1.
+	GroupName = "olm.operatorframework.io"
+
+	// TypeServing is the ClusterCatalog condition reporting that its content is served.
+	TypeServing = "Serving"
+	// TypeInstalled is the ClusterExtension condition reporting that its bundle is installed.
+	TypeInstalled = "Installed"
+	// TypeProgressing is the condition reporting that the object is being reconciled.
+	TypeProgressing = "Progressing"
+
+	// ReasonSucceeded is the reason of a Progressing condition whose last reconciliation succeeded.
+	ReasonSucceeded = "Succeeded"
+	// ReasonRetrying is the reason of a Progressing condition whose reconciliation failed and is retried.
+	ReasonRetrying = "Retrying"
+	// ReasonBlocked is the reason of a Progressing condition whose reconciliation cannot succeed without a
+	// change of the object.
+	ReasonBlocked = "Blocked"
+
+	// SourceTypeImage is the source type of a ClusterCatalog served from a catalog image.
+	SourceTypeImage = "Image"
+	// SourceTypeCatalog is the source type of a ClusterExtension installed from the ClusterCatalogs.
+	SourceTypeCatalog = "Catalog"
+
+	// UpgradeConstraintPolicyCatalogProvided only allows the upgrades of the catalog upgrade graph.
+	UpgradeConstraintPolicyCatalogProvided = "CatalogProvided"
+	// UpgradeConstraintPolicySelfCertified allows any upgrade, downgrade or sidegrade.
+	UpgradeConstraintPolicySelfCertified = "SelfCertified"
+
+	// ConfigTypeInline is the type of a ClusterExtension configuration given inline.
+	ConfigTypeInline = "Inline"
+
+	// CatalogNameLabel is the label set by catalogd on every ClusterCatalog with its name.
+	CatalogNameLabel = "olm.operatorframework.io/metadata.name"
+)
+
+var (
+	// SchemeGroupVersion is the group version of the OLM v1 API.
+	SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1"}
+	// SchemeBuilder registers the OLM v1 kinds.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	// AddToScheme adds the OLM v1 kinds to a scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&ClusterCatalog{}, &ClusterCatalogList{}, &ClusterExtension{}, &ClusterExtensionList{})
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+
+	return nil
+}
+
+// ClusterCatalog makes the content of a File-Based Catalog image available to OLM v1.
+type ClusterCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterCatalogSpec   `json:"spec"`
+	Status ClusterCatalogStatus `json:"status,omitempty"`
+}
+
+// ClusterCatalogList is a list of ClusterCatalogs.
+type ClusterCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterCatalog `json:"items"`
+}
+
+// ClusterCatalogSpec is the desired state of a ClusterCatalog.
+type ClusterCatalogSpec struct {
+	Source CatalogSource `json:"source"`
+	// Priority orders the catalogs providing the same bundle, the highest first.
+	Priority int32 `json:"priority,omitempty"`
+	// AvailabilityMode is Available or Unavailable.
+	AvailabilityMode string `json:"availabilityMode,omitempty"`
+}
+
+// CatalogSource is the source of the content of a ClusterCatalog.
+type CatalogSource struct {
+	Type  string       `json:"type"`
+	Image *ImageSource `json:"image,omitempty"`
+}
+
+// ImageSource is a catalog image.
+type ImageSource struct {
+	Ref string `json:"ref"`
+	// PollIntervalMinutes is the interval at which a tag reference is resolved again, unset to never poll.
+	PollIntervalMinutes *int `json:"pollIntervalMinutes,omitempty"`
+}
+
+// ClusterCatalogStatus is the observed state of a ClusterCatalog.
+type ClusterCatalogStatus struct {
+	Conditions     []metav1.Condition     `json:"conditions,omitempty"`
+	ResolvedSource *ResolvedCatalogSource `json:"resolvedSource,omitempty"`
+	URLs           *ClusterCatalogURLs    `json:"urls,omitempty"`
+	LastUnpacked   *metav1.Time           `json:"lastUnpacked,omitempty"`
+}
+
+// ResolvedCatalogSource is the source the content of a ClusterCatalog was unpacked from.
+type ResolvedCatalogSource struct {
+	Type  string               `json:"type"`
+	Image *ResolvedImageSource `json:"image,omitempty"`
+}
+
+// ResolvedImageSource is the digest reference of the unpacked catalog image.
+type ResolvedImageSource struct {
+	Ref string `json:"ref"`
+}
+
+// ClusterCatalogURLs are the URLs the content of a ClusterCatalog is served at.
+type ClusterCatalogURLs struct {
+	Base string `json:"base"`
+}
+
+// ClusterExtension installs a package of the ClusterCatalogs.
+type ClusterExtension struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterExtensionSpec   `json:"spec"`
+	Status ClusterExtensionStatus `json:"status,omitempty"`
+}
+
+// ClusterExtensionList is a list of ClusterExtensions.
+type ClusterExtensionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterExtension `json:"items"`
+}
+
+// ClusterExtensionSpec is the desired state of a ClusterExtension.
+type ClusterExtensionSpec struct {
+	// Namespace is the namespace the namespaced objects of the bundle are installed in.
+	Namespace      string                   `json:"namespace"`
+	ServiceAccount ServiceAccountReference  `json:"serviceAccount"`
+	Source         SourceConfig             `json:"source"`
+	Install        *ClusterExtensionInstall `json:"install,omitempty"`
+	Config         *ClusterExtensionConfig  `json:"config,omitempty"`
+}
+
+// ServiceAccountReference is the service account the objects of the bundle are managed with.
+type ServiceAccountReference struct {
+	Name string `json:"name"`
+}
+
+// SourceConfig is the source of the bundle of a ClusterExtension.
+type SourceConfig struct {
+	SourceType string         `json:"sourceType"`
+	Catalog    *CatalogFilter `json:"catalog,omitempty"`
+}
+
+// CatalogFilter selects the bundle of a ClusterExtension in the ClusterCatalogs.
+type CatalogFilter struct {
+	PackageName string `json:"packageName"`
+	// Version is a semver range, e.g. ">=24.6.0 <24.7.0" or "24.6.x".
+	Version  string                `json:"version,omitempty"`
+	Channels []string              `json:"channels,omitempty"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// UpgradeConstraintPolicy is CatalogProvided or SelfCertified.
+	UpgradeConstraintPolicy string `json:"upgradeConstraintPolicy,omitempty"`
+}
+
+// ClusterExtensionInstall configures the install of the bundle.
+type ClusterExtensionInstall struct {
+	Preflight *PreflightConfig `json:"preflight,omitempty"`
+}
+
+// PreflightConfig configures the checks run before the bundle is installed or upgraded.
+type PreflightConfig struct {
+	CRDUpgradeSafety *CRDUpgradeSafetyPreflightConfig `json:"crdUpgradeSafety,omitempty"`
+}
+
+// CRDUpgradeSafetyPreflightConfig configures the check of the CRD upgrades.
+type CRDUpgradeSafetyPreflightConfig struct {
+	// Enforcement is None or Strict.
+	Enforcement string `json:"enforcement"`
+}
+
+// ClusterExtensionConfig configures the bundle, e.g. the namespace the operator watches.
+type ClusterExtensionConfig struct {
+	ConfigType string                `json:"configType"`
+	Inline     *apiextensionsv1.JSON `json:"inline,omitempty"`
+}
+
+// ClusterExtensionStatus is the observed state of a ClusterExtension.
+type ClusterExtensionStatus struct {
+	Conditions []metav1.Condition             `json:"conditions,omitempty"`
+	Install    *ClusterExtensionInstallStatus `json:"install,omitempty"`
+}
+
+// ClusterExtensionInstallStatus is the installed bundle of a ClusterExtension.
+type ClusterExtensionInstallStatus struct {
+	Bundle BundleMetadata `json:"bundle"`
+}
+
+// BundleMetadata is the name and version of a bundle.
+type BundleMetadata struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
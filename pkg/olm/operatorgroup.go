@@ -70,6 +70,12 @@ func (builder *OperatorGroupBuilder) Create() (*OperatorGroupBuilder, error) {
 	glog.V(100).Infof("Creating the OperatorGroup %s",
 		builder.Definition.Name)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunCreate, builder.Definition)
+	}
+
 	var err error
 	if !builder.Exists() {
 		builder.Object, err = builder.apiClient.OperatorGroups(builder.Definition.Namespace).Create(context.TODO(),
@@ -105,6 +111,12 @@ func (builder *OperatorGroupBuilder) Delete() error {
 	glog.V(100).Infof("Deleting OperatorGroup %s in namespace %s", builder.Definition.Name,
 		builder.Definition.Namespace)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = nil
+
+		return builder.apiClient.RecordDryRun(clients.DryRunDelete, builder.Definition)
+	}
+
 	if !builder.Exists() {
 		return nil
 	}
@@ -130,6 +142,12 @@ func (builder *OperatorGroupBuilder) Update() (*OperatorGroupBuilder, error) {
 	glog.V(100).Infof("Updating OperatorGroup %s in namespace %s",
 		builder.Definition.Name, builder.Definition.Namespace)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunUpdate, builder.Definition)
+	}
+
 	var err error
 	builder.Object, err = builder.apiClient.OperatorGroups(builder.Definition.Namespace).Update(
 		context.TODO(), builder.Definition, metav1.UpdateOptions{})
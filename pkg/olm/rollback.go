@@ -0,0 +1,126 @@
+package olm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VersionChangeDirection is the direction of a change of the installed version of an operator.
+type VersionChangeDirection string
+
+const (
+	// DirectionUpgrade moves the operator from its pre-upgrade CSV to a newer one.
+	DirectionUpgrade VersionChangeDirection = "upgrade"
+	// DirectionRollback moves the operator from its upgraded CSV back to the pre-upgrade one.
+	DirectionRollback VersionChangeDirection = "rollback"
+)
+
+// RollbackStep is a step of an upgrade and rollback scenario, with its error message if it failed.
+type RollbackStep struct {
+	Direction VersionChangeDirection
+	Name      string
+	Error     string
+}
+
+// RollbackStepError is returned by RollbackReport.Record for a failed step, so the failure tells whether the
+// upgrade or the rollback broke the operator.
+type RollbackStepError struct {
+	Direction VersionChangeDirection
+	Step      string
+	From      string
+	To        string
+	Err       error
+}
+
+// Error returns the direction, versions and step of the failure.
+func (stepError *RollbackStepError) Error() string {
+	return fmt.Sprintf("%s from %s to %s failed at step '%s': %v", stepError.Direction, stepError.From,
+		stepError.To, stepError.Step, stepError.Err)
+}
+
+// Unwrap returns the error of the failed step.
+func (stepError *RollbackStepError) Unwrap() error {
+	return stepError.Err
+}
+
+// RollbackReport records an operator upgrade followed by a rollback to the pre-upgrade CSV: the CSVs and
+// channels involved, and the outcome of every step in both directions.
+type RollbackReport struct {
+	Package                string
+	CatalogSource          string
+	CatalogSourceNamespace string
+	PreUpgradeChannel      string
+	PreUpgradeCSV          string
+	UpgradeChannel         string
+	UpgradedCSV            string
+	RolledBackCSV          string
+	Steps                  []RollbackStep
+}
+
+// Record appends the outcome of a step in the given direction. A nil err records a successful step and
+// returns nil, otherwise a *RollbackStepError wrapping err is returned.
+func (report *RollbackReport) Record(direction VersionChangeDirection, step string, err error) error {
+	if err == nil {
+		report.Steps = append(report.Steps, RollbackStep{Direction: direction, Name: step})
+
+		return nil
+	}
+
+	report.Steps = append(report.Steps, RollbackStep{Direction: direction, Name: step, Error: err.Error()})
+
+	from, to := report.PreUpgradeCSV, report.upgradeTarget()
+	if direction == DirectionRollback {
+		from, to = to, from
+	}
+
+	return &RollbackStepError{Direction: direction, Step: step, From: from, To: to, Err: err}
+}
+
+// Failed returns the direction of the first failed step, if any.
+func (report *RollbackReport) Failed() (VersionChangeDirection, bool) {
+	for _, step := range report.Steps {
+		if step.Error != "" {
+			return step.Direction, true
+		}
+	}
+
+	return "", false
+}
+
+// String returns the versions of the scenario and the outcome of every step.
+func (report *RollbackReport) String() string {
+	var message strings.Builder
+
+	fmt.Fprintf(&message, "Upgrade and rollback of package %s from CatalogSource %s/%s\n", report.Package,
+		report.CatalogSourceNamespace, report.CatalogSource)
+	fmt.Fprintf(&message, "Pre-upgrade CSV %s in channel %s\n", report.PreUpgradeCSV, report.PreUpgradeChannel)
+	fmt.Fprintf(&message, "Upgraded CSV %s in channel %s\n", report.UpgradedCSV, report.UpgradeChannel)
+	fmt.Fprintf(&message, "Rolled back CSV %s\n", report.RolledBackCSV)
+
+	if direction, failed := report.Failed(); failed {
+		fmt.Fprintf(&message, "Result: %s failed\n", direction)
+	} else {
+		fmt.Fprintf(&message, "Result: no failed step\n")
+	}
+
+	fmt.Fprintf(&message, "Steps:\n")
+
+	for _, step := range report.Steps {
+		if step.Error == "" {
+			fmt.Fprintf(&message, "  %s: %s: ok\n", step.Direction, step.Name)
+		} else {
+			fmt.Fprintf(&message, "  %s: %s: failed: %s\n", step.Direction, step.Name, step.Error)
+		}
+	}
+
+	return message.String()
+}
+
+// upgradeTarget returns the upgraded CSV, or the upgrade channel when the upgrade did not install a CSV.
+func (report *RollbackReport) upgradeTarget() string {
+	if report.UpgradedCSV != "" {
+		return report.UpgradedCSV
+	}
+
+	return "channel " + report.UpgradeChannel
+}
@@ -0,0 +1,168 @@
+package olm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRollbackReportRecord(t *testing.T) {
+	testCases := []struct {
+		name          string
+		upgradedCSV   string
+		direction     VersionChangeDirection
+		err           error
+		expectedError string
+	}{
+		{
+			name:        "successful step",
+			upgradedCSV: "gpu-operator-certified.v25.3.0",
+			direction:   DirectionUpgrade,
+		},
+		{
+			name:        "failed upgrade step",
+			upgradedCSV: "gpu-operator-certified.v25.3.0",
+			direction:   DirectionUpgrade,
+			err:         errors.New("timed out"),
+			expectedError: "upgrade from gpu-operator-certified.v24.9.2 to gpu-operator-certified.v25.3.0 failed " +
+				"at step 'wait for CSV': timed out",
+		},
+		{
+			name:          "failed upgrade step before a CSV is installed",
+			direction:     DirectionUpgrade,
+			err:           errors.New("timed out"),
+			expectedError: "upgrade from gpu-operator-certified.v24.9.2 to channel v25.3 failed at step 'wait for CSV'",
+		},
+		{
+			name:          "failed rollback step",
+			upgradedCSV:   "gpu-operator-certified.v25.3.0",
+			direction:     DirectionRollback,
+			err:           errors.New("timed out"),
+			expectedError: "rollback from gpu-operator-certified.v25.3.0 to gpu-operator-certified.v24.9.2 failed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			report := buildRollbackReport(testCase.upgradedCSV)
+
+			err := report.Record(testCase.direction, "wait for CSV", testCase.err)
+
+			if len(report.Steps) != 1 || report.Steps[0].Direction != testCase.direction {
+				t.Fatalf("expected one %s step, got %v", testCase.direction, report.Steps)
+			}
+
+			if testCase.expectedError == "" {
+				if err != nil || report.Steps[0].Error != "" {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			var stepError *RollbackStepError
+			if !errors.As(err, &stepError) || !errors.Is(err, testCase.err) {
+				t.Fatalf("expected a RollbackStepError wrapping the step error, got %v", err)
+			}
+
+			if !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Errorf("expected error containing %q, got %q", testCase.expectedError, err.Error())
+			}
+
+			if report.Steps[0].Error != testCase.err.Error() {
+				t.Errorf("expected the step error to be recorded, got %q", report.Steps[0].Error)
+			}
+		})
+	}
+}
+
+func TestRollbackReportFailed(t *testing.T) {
+	testCases := []struct {
+		name              string
+		steps             []RollbackStep
+		expectedFailed    bool
+		expectedDirection VersionChangeDirection
+	}{
+		{
+			name: "no failed step",
+			steps: []RollbackStep{
+				{Direction: DirectionUpgrade, Name: "wait for CSV"},
+				{Direction: DirectionRollback, Name: "wait for pre-upgrade CSV"},
+			},
+		},
+		{
+			name: "failed upgrade",
+			steps: []RollbackStep{
+				{Direction: DirectionUpgrade, Name: "wait for CSV", Error: "timed out"},
+				{Direction: DirectionRollback, Name: "wait for pre-upgrade CSV", Error: "timed out"},
+			},
+			expectedFailed:    true,
+			expectedDirection: DirectionUpgrade,
+		},
+		{
+			name: "failed rollback",
+			steps: []RollbackStep{
+				{Direction: DirectionUpgrade, Name: "wait for CSV"},
+				{Direction: DirectionRollback, Name: "wait for pre-upgrade CSV", Error: "timed out"},
+			},
+			expectedFailed:    true,
+			expectedDirection: DirectionRollback,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			report := buildRollbackReport("gpu-operator-certified.v25.3.0")
+			report.Steps = testCase.steps
+
+			direction, failed := report.Failed()
+
+			if failed != testCase.expectedFailed || direction != testCase.expectedDirection {
+				t.Errorf("expected failed %t in direction %q, got %t %q", testCase.expectedFailed,
+					testCase.expectedDirection, failed, direction)
+			}
+
+			expectedResult := "Result: no failed step"
+			if testCase.expectedFailed {
+				expectedResult = "Result: " + string(testCase.expectedDirection) + " failed"
+			}
+
+			if !strings.Contains(report.String(), expectedResult) {
+				t.Errorf("expected %q in the report, got %q", expectedResult, report.String())
+			}
+		})
+	}
+}
+
+func TestRollbackReportString(t *testing.T) {
+	report := buildRollbackReport("gpu-operator-certified.v25.3.0")
+	report.RolledBackCSV = "gpu-operator-certified.v24.9.2"
+
+	_ = report.Record(DirectionUpgrade, "wait for CSV", nil)
+	_ = report.Record(DirectionRollback, "approve InstallPlan", errors.New("installplan failed"))
+
+	for _, expected := range []string{
+		"package gpu-operator-certified from CatalogSource openshift-marketplace/certified-operators",
+		"Pre-upgrade CSV gpu-operator-certified.v24.9.2 in channel v24.9",
+		"Upgraded CSV gpu-operator-certified.v25.3.0 in channel v25.3",
+		"Rolled back CSV gpu-operator-certified.v24.9.2",
+		"  upgrade: wait for CSV: ok",
+		"  rollback: approve InstallPlan: failed: installplan failed",
+	} {
+		if !strings.Contains(report.String(), expected) {
+			t.Errorf("expected %q in the report, got %q", expected, report.String())
+		}
+	}
+}
+
+func buildRollbackReport(upgradedCSV string) *RollbackReport {
+	return &RollbackReport{
+		Package:                testPackage,
+		CatalogSource:          testCatalog,
+		CatalogSourceNamespace: testCatalogNamespace,
+		PreUpgradeChannel:      "v24.9",
+		PreUpgradeCSV:          "gpu-operator-certified.v24.9.2",
+		UpgradeChannel:         "v25.3",
+		UpgradedCSV:            upgradedCSV,
+	}
+}
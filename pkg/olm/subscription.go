@@ -159,6 +159,12 @@ func (builder *SubscriptionBuilder) Create() (*SubscriptionBuilder, error) {
 	glog.V(100).Infof("Creating the Subscription %s in namespace %s",
 		builder.Definition.Name, builder.Definition.Namespace)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunCreate, builder.Definition)
+	}
+
 	var err error
 	if !builder.Exists() {
 		builder.Object, err = builder.apiClient.Subscriptions(builder.Definition.Namespace).Create(context.TODO(),
@@ -194,6 +200,12 @@ func (builder *SubscriptionBuilder) Delete() error {
 	glog.V(100).Infof("Deleting Subscription %s in namespace %s", builder.Definition.Name,
 		builder.Definition.Namespace)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = nil
+
+		return builder.apiClient.RecordDryRun(clients.DryRunDelete, builder.Definition)
+	}
+
 	if !builder.Exists() {
 		return nil
 	}
@@ -219,6 +231,12 @@ func (builder *SubscriptionBuilder) Update() (*SubscriptionBuilder, error) {
 	glog.V(100).Infof("Updating Subscription %s in namespace %s",
 		builder.Definition.Name, builder.Definition.Namespace)
 
+	if builder.apiClient.IsDryRun() {
+		builder.Object = builder.Definition
+
+		return builder, builder.apiClient.RecordDryRun(clients.DryRunUpdate, builder.Definition)
+	}
+
 	if !builder.Exists() {
 		return nil, fmt.Errorf("subscription named %s in namespace %s doesn't exist",
 			builder.Definition.Name, builder.Definition.Namespace)
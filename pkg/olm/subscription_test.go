@@ -0,0 +1,164 @@
+package olm
+
+import (
+	"testing"
+
+	operatorsV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testSubscriptionName      = "gpu-subscription"
+	testSubscriptionNamespace = "nvidia-gpu-operator"
+	testPackage               = "gpu-operator-certified"
+)
+
+func TestNewSubscriptionBuilder(t *testing.T) {
+	testCases := []struct {
+		subName                string
+		subNamespace           string
+		catalogSource          string
+		catalogSourceNamespace string
+		packageName            string
+		expectedError          string
+	}{
+		{testSubscriptionName, testSubscriptionNamespace, "certified-operators", "openshift-marketplace",
+			testPackage, ""},
+		{"", testSubscriptionNamespace, "certified-operators", "openshift-marketplace", testPackage,
+			"Subscription 'subName' cannot be empty"},
+		{testSubscriptionName, "", "certified-operators", "openshift-marketplace", testPackage,
+			"Subscription 'subNamespace' cannot be empty"},
+		{testSubscriptionName, testSubscriptionNamespace, "", "openshift-marketplace", testPackage,
+			"Subscription 'catalogSource' cannot be empty"},
+		{testSubscriptionName, testSubscriptionNamespace, "certified-operators", "", testPackage,
+			"Subscription 'catalogSourceNamespace' cannot be empty"},
+		{testSubscriptionName, testSubscriptionNamespace, "certified-operators", "openshift-marketplace", "",
+			"Subscription 'packageName' cannot be empty"},
+	}
+
+	for _, testCase := range testCases {
+		builder := NewSubscriptionBuilder(clients.GetTestClients(clients.TestClientParams{}), testCase.subName,
+			testCase.subNamespace, testCase.catalogSource, testCase.catalogSourceNamespace, testCase.packageName)
+
+		if builder.errorMsg != testCase.expectedError {
+			t.Errorf("expected error %q, got %q", testCase.expectedError, builder.errorMsg)
+		}
+	}
+}
+
+func TestSubscriptionWithOptions(t *testing.T) {
+	testCases := []struct {
+		channel       string
+		startingCSV   string
+		approval      operatorsV1alpha1.Approval
+		expectedError string
+	}{
+		{"v24.9", "gpu-operator-certified.v24.9.2", operatorsV1alpha1.ApprovalManual, ""},
+		{"v24.9", "gpu-operator-certified.v24.9.2", operatorsV1alpha1.ApprovalAutomatic, ""},
+		{"", "gpu-operator-certified.v24.9.2", operatorsV1alpha1.ApprovalManual,
+			"can not redefine subscription with empty channel"},
+		{"v24.9", "", operatorsV1alpha1.ApprovalManual, "can not redefine subscription with empty startingCSV"},
+		{"v24.9", "gpu-operator-certified.v24.9.2", "Never",
+			"Subscription 'installPlanApproval' must be either \"Automatic\" or \"Manual\""},
+	}
+
+	for _, testCase := range testCases {
+		builder := NewSubscriptionBuilder(clients.GetTestClients(clients.TestClientParams{}), testSubscriptionName,
+			testSubscriptionNamespace, "certified-operators", "openshift-marketplace", testPackage).
+			WithChannel(testCase.channel).
+			WithStartingCSV(testCase.startingCSV).
+			WithInstallPlanApproval(testCase.approval)
+
+		if builder.errorMsg != testCase.expectedError {
+			t.Errorf("expected error %q, got %q", testCase.expectedError, builder.errorMsg)
+		}
+
+		if testCase.expectedError != "" {
+			continue
+		}
+
+		spec := builder.Definition.Spec
+		if spec.Channel != testCase.channel || spec.StartingCSV != testCase.startingCSV ||
+			spec.InstallPlanApproval != testCase.approval {
+			t.Errorf("unexpected subscription spec %+v", spec)
+		}
+	}
+}
+
+func TestSubscriptionCreateUpdateDelete(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+	builder, err := NewSubscriptionBuilder(testSettings, testSubscriptionName, testSubscriptionNamespace,
+		"certified-operators", "openshift-marketplace", testPackage).WithChannel("v24.6").Create()
+	if err != nil {
+		t.Fatalf("unexpected create error %v", err)
+	}
+
+	pulledBuilder, err := PullSubscription(testSettings, testSubscriptionName, testSubscriptionNamespace)
+	if err != nil {
+		t.Fatalf("unexpected pull error %v", err)
+	}
+
+	pulledBuilder.Definition.Spec.Channel = "v24.9"
+
+	if _, err := pulledBuilder.Update(); err != nil {
+		t.Fatalf("unexpected update error %v", err)
+	}
+
+	if !builder.Exists() || builder.Object.Spec.Channel != "v24.9" {
+		t.Errorf("expected updated channel v24.9, got %+v", builder.Object)
+	}
+
+	if err := builder.Delete(); err != nil {
+		t.Fatalf("unexpected delete error %v", err)
+	}
+
+	if _, err := PullSubscription(testSettings, testSubscriptionName, testSubscriptionNamespace); err == nil {
+		t.Errorf("expected error pulling deleted subscription")
+	}
+
+	if _, err := pulledBuilder.Update(); err == nil {
+		t.Errorf("expected error updating deleted subscription")
+	}
+}
+
+func TestOperatorGroupCreate(t *testing.T) {
+	testCases := []struct {
+		groupName     string
+		nsName        string
+		existing      bool
+		expectedError string
+	}{
+		{groupName: "gpu-og", nsName: testSubscriptionNamespace, existing: false, expectedError: ""},
+		{groupName: "gpu-og", nsName: testSubscriptionNamespace, existing: true, expectedError: ""},
+		{groupName: "", nsName: testSubscriptionNamespace, expectedError: "OperatorGroup 'groupName' cannot be empty"},
+		{groupName: "gpu-og", nsName: "", expectedError: "OperatorGroup 'Namespace' cannot be empty"},
+	}
+
+	for _, testCase := range testCases {
+		var objects []runtime.Object
+		if testCase.existing {
+			objects = append(objects, NewOperatorGroupBuilder(nil, testCase.groupName, testCase.nsName).Definition)
+		}
+
+		builder, err := NewOperatorGroupBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: objects}), testCase.groupName, testCase.nsName).Create()
+
+		if testCase.expectedError != "" {
+			if err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("existing %t: unexpected create error %v", testCase.existing, err)
+		}
+
+		if !builder.Exists() {
+			t.Errorf("existing %t: operatorgroup not found after create", testCase.existing)
+		}
+	}
+}
@@ -0,0 +1,859 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm/ocv1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// olmOwnerLabel is the label set by OLM on the cluster scoped objects it creates for a CSV.
+	olmOwnerLabel = "olm.owner"
+	// clusterExtensionOwnerKindLabel and clusterExtensionOwnerNameLabel are the labels set by operator-controller
+	// on the objects of the bundle installed by a ClusterExtension.
+	clusterExtensionOwnerKindLabel = "olm.operatorframework.io/owner-kind"
+	clusterExtensionOwnerNameLabel = "olm.operatorframework.io/owner-name"
+	// defaultUninstallPollInterval and defaultUninstallTimeout bound every deletion of the uninstall.
+	defaultUninstallPollInterval = 10 * time.Second
+	defaultUninstallTimeout      = 5 * time.Minute
+)
+
+// crdResource is the resource of the CustomResourceDefinitions.
+var crdResource = schema.GroupVersionResource{
+	Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// UninstallLeftover is an object left on the cluster after an operator was uninstalled.
+type UninstallLeftover struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// Reason describes why the object is attributed to the operator.
+	Reason string
+}
+
+// String returns the kind, name and namespace of the leftover object.
+func (leftover UninstallLeftover) String() string {
+	if leftover.Namespace == "" {
+		return fmt.Sprintf("%s %s (%s)", leftover.Kind, leftover.Name, leftover.Reason)
+	}
+
+	return fmt.Sprintf("%s %s/%s (%s)", leftover.Kind, leftover.Namespace, leftover.Name, leftover.Reason)
+}
+
+// UninstallReport is the outcome of an operator uninstall: the objects deleted, in deletion order, and the
+// objects still found on the cluster afterwards.
+type UninstallReport struct {
+	Package   string
+	Namespace string
+	// ClusterExtension is the ClusterExtension the operator was installed with, empty for a Subscription.
+	ClusterExtension string
+	CSVs             []string
+	Deleted          []string
+	Leftovers        []UninstallLeftover
+}
+
+// String returns the deleted and leftover objects of the uninstall.
+func (report *UninstallReport) String() string {
+	var message strings.Builder
+
+	if report.ClusterExtension != "" {
+		fmt.Fprintf(&message, "Uninstall of package %s in namespace %s, ClusterExtension %s\n", report.Package,
+			report.Namespace, report.ClusterExtension)
+	} else {
+		fmt.Fprintf(&message, "Uninstall of package %s in namespace %s, CSVs %v\n", report.Package,
+			report.Namespace, report.CSVs)
+	}
+
+	fmt.Fprintf(&message, "Deleted %d objects:\n", len(report.Deleted))
+
+	for _, deleted := range report.Deleted {
+		fmt.Fprintf(&message, "  %s\n", deleted)
+	}
+
+	fmt.Fprintf(&message, "Leftover %d objects:\n", len(report.Leftovers))
+
+	for _, leftover := range report.Leftovers {
+		fmt.Fprintf(&message, "  %s\n", leftover)
+	}
+
+	return message.String()
+}
+
+// OperatorUninstallBuilder provides a struct to uninstall an operator installed by OLM: its operand CRs, its
+// Subscription, CSVs, InstallPlans and OperatorGroup, or its ClusterExtension, and optionally its CRDs and
+// namespace, then to report the objects left on the cluster.
+type OperatorUninstallBuilder struct {
+	// Package, Subscription or ClusterExtension, and Namespace of the operator.
+	Package          string
+	Subscription     string
+	ClusterExtension string
+	Namespace        string
+	// api client to interact with the cluster.
+	apiClient         *clients.Settings
+	deleteCRDs        bool
+	deleteNamespace   bool
+	nodeLabelPrefixes []string
+	pollInterval      time.Duration
+	timeout           time.Duration
+	// errorMsg is processed before the operator is uninstalled.
+	errorMsg string
+}
+
+// NewOperatorUninstallBuilder returns an OperatorUninstallBuilder for the operator of package subscribed by
+// subscriptionName in nsname.
+func NewOperatorUninstallBuilder(apiClient *clients.Settings, packageName, subscriptionName,
+	nsname string) *OperatorUninstallBuilder {
+	glog.V(100).Infof("Initializing new OperatorUninstallBuilder structure with the following params: %s, %s, %s",
+		packageName, subscriptionName, nsname)
+
+	builder := &OperatorUninstallBuilder{
+		Package:      packageName,
+		Subscription: subscriptionName,
+		Namespace:    nsname,
+		apiClient:    apiClient,
+		pollInterval: defaultUninstallPollInterval,
+		timeout:      defaultUninstallTimeout,
+	}
+
+	if packageName == "" {
+		glog.V(100).Infof("The package of the OperatorUninstallBuilder is empty")
+
+		builder.errorMsg = "operator uninstall 'packageName' cannot be empty"
+	}
+
+	if subscriptionName == "" {
+		glog.V(100).Infof("The subscription of the OperatorUninstallBuilder is empty")
+
+		builder.errorMsg = "operator uninstall 'subscriptionName' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the OperatorUninstallBuilder is empty")
+
+		builder.errorMsg = "operator uninstall 'nsname' cannot be empty"
+	}
+
+	return builder
+}
+
+// NewClusterExtensionUninstallBuilder returns an OperatorUninstallBuilder for the operator of package installed
+// by the ClusterExtension clusterExtensionName in nsname.
+func NewClusterExtensionUninstallBuilder(apiClient *clients.Settings, packageName, clusterExtensionName,
+	nsname string) *OperatorUninstallBuilder {
+	glog.V(100).Infof("Initializing new ClusterExtension OperatorUninstallBuilder structure with the following "+
+		"params: %s, %s, %s", packageName, clusterExtensionName, nsname)
+
+	builder := &OperatorUninstallBuilder{
+		Package:          packageName,
+		ClusterExtension: clusterExtensionName,
+		Namespace:        nsname,
+		apiClient:        apiClient,
+		pollInterval:     defaultUninstallPollInterval,
+		timeout:          defaultUninstallTimeout,
+	}
+
+	if packageName == "" {
+		glog.V(100).Infof("The package of the OperatorUninstallBuilder is empty")
+
+		builder.errorMsg = "operator uninstall 'packageName' cannot be empty"
+	}
+
+	if clusterExtensionName == "" {
+		glog.V(100).Infof("The ClusterExtension of the OperatorUninstallBuilder is empty")
+
+		builder.errorMsg = "operator uninstall 'clusterExtensionName' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the OperatorUninstallBuilder is empty")
+
+		builder.errorMsg = "operator uninstall 'nsname' cannot be empty"
+	}
+
+	return builder
+}
+
+// WithCRDs also deletes the CRDs owned by the CSVs, or installed by the ClusterExtension, of the operator.
+func (builder *OperatorUninstallBuilder) WithCRDs() *OperatorUninstallBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining OperatorUninstallBuilder to delete the CRDs of package %s", builder.Package)
+
+	builder.deleteCRDs = true
+
+	return builder
+}
+
+// WithNamespace also deletes the namespace of the operator.
+func (builder *OperatorUninstallBuilder) WithNamespace() *OperatorUninstallBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining OperatorUninstallBuilder to delete namespace %s", builder.Namespace)
+
+	builder.deleteNamespace = true
+
+	return builder
+}
+
+// WithNodeLabelPrefixes reports the node labels starting with one of prefixes as leftovers, e.g. the labels
+// set by the operands of the operator.
+func (builder *OperatorUninstallBuilder) WithNodeLabelPrefixes(prefixes ...string) *OperatorUninstallBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining OperatorUninstallBuilder with node label prefixes %v", prefixes)
+
+	if len(prefixes) == 0 {
+		builder.errorMsg = "can not define operator uninstall with empty node label prefixes"
+
+		return builder
+	}
+
+	builder.nodeLabelPrefixes = append(builder.nodeLabelPrefixes, prefixes...)
+
+	return builder
+}
+
+// WithTimeout sets the poll interval and the timeout of every deletion of the uninstall.
+func (builder *OperatorUninstallBuilder) WithTimeout(pollInterval, timeout time.Duration) *OperatorUninstallBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining OperatorUninstallBuilder with poll interval %s and timeout %s", pollInterval,
+		timeout)
+
+	if pollInterval <= 0 || timeout <= 0 {
+		builder.errorMsg = "can not define operator uninstall with non positive poll interval or timeout"
+
+		return builder
+	}
+
+	builder.pollInterval = pollInterval
+	builder.timeout = timeout
+
+	return builder
+}
+
+// Uninstall removes the operator and reports the objects left on the cluster.
+func (builder *OperatorUninstallBuilder) Uninstall() (*UninstallReport, error) {
+	return builder.UninstallWithContext(context.TODO())
+}
+
+// UninstallWithContext removes the operand CRs of the operator first, while the operator can still process
+// their finalizers, then its Subscription, CSVs, InstallPlans and OperatorGroup, or its ClusterExtension, then
+// its CRDs and namespace when requested. Every deletion is awaited. The objects left on the cluster are then
+// reported: operand CRs, OLM objects and workloads in the namespace, and the cluster scoped objects labeled for
+// the operator, its CSVs or its ClusterExtension. The returned error joins the failed deletions; leftovers are
+// only reported.
+// The uninstall stops as soon as ctx is cancelled.
+func (builder *OperatorUninstallBuilder) UninstallWithContext(ctx context.Context) (*UninstallReport, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	report := &UninstallReport{Package: builder.Package, Namespace: builder.Namespace,
+		ClusterExtension: builder.ClusterExtension}
+
+	if builder.apiClient.IsDryRun() {
+		glog.V(100).Infof("Dry-run: skipping uninstall of package %s", builder.Package)
+
+		return report, nil
+	}
+
+	glog.V(100).Infof("Uninstalling package %s from namespace %s", builder.Package, builder.Namespace)
+
+	var (
+		csvs      []oplmV1alpha1.ClusterServiceVersion
+		ownedCRDs []oplmV1alpha1.CRDDescription
+		err       error
+	)
+
+	if builder.ClusterExtension != "" {
+		ownedCRDs, err = builder.clusterExtensionCRDs(ctx)
+	} else {
+		csvs, err = builder.csvs(ctx)
+		ownedCRDs = ownedCRDsOf(csvs)
+	}
+
+	if err != nil {
+		return report, err
+	}
+
+	for _, csv := range csvs {
+		report.CSVs = append(report.CSVs, csv.Name)
+	}
+
+	var errs []error
+
+	for _, crd := range ownedCRDs {
+		errs = append(errs, builder.deleteOperands(ctx, report, crd))
+	}
+
+	if builder.ClusterExtension != "" {
+		errs = append(errs, builder.deleteAndWait(ctx, report, "ClusterExtension "+builder.ClusterExtension,
+			func(ctx context.Context) error {
+				return builder.apiClient.Delete(ctx, &ocv1.ClusterExtension{
+					ObjectMeta: metav1.ObjectMeta{Name: builder.ClusterExtension}})
+			},
+			func(ctx context.Context) error {
+				return builder.apiClient.Get(ctx, goclient.ObjectKey{Name: builder.ClusterExtension},
+					&ocv1.ClusterExtension{})
+			}))
+	} else {
+		errs = append(errs, builder.deleteOLMObjects(ctx, report, csvs))
+	}
+
+	if builder.deleteCRDs {
+		for _, crd := range ownedCRDs {
+			errs = append(errs, builder.deleteAndWait(ctx, report, "CustomResourceDefinition "+crd.Name,
+				func(ctx context.Context) error {
+					return builder.apiClient.Resource(crdResource).Delete(ctx, crd.Name, metav1.DeleteOptions{})
+				},
+				func(ctx context.Context) error {
+					_, err := builder.apiClient.Resource(crdResource).Get(ctx, crd.Name, metav1.GetOptions{})
+
+					return err
+				}))
+		}
+	}
+
+	if builder.deleteNamespace {
+		errs = append(errs, builder.deleteAndWait(ctx, report, "Namespace "+builder.Namespace,
+			func(ctx context.Context) error {
+				return builder.apiClient.Namespaces().Delete(ctx, builder.Namespace, metav1.DeleteOptions{})
+			},
+			func(ctx context.Context) error {
+				_, err := builder.apiClient.Namespaces().Get(ctx, builder.Namespace, metav1.GetOptions{})
+
+				return err
+			}))
+	}
+
+	errs = append(errs, builder.findLeftovers(ctx, report, ownedCRDs))
+
+	glog.V(100).Infof("%s", report)
+
+	return report, errors.Join(errs...)
+}
+
+// csvs returns the CSVs of the operator: the CSVs labeled for the package in the namespace, and the CSVs of
+// the Subscription.
+func (builder *OperatorUninstallBuilder) csvs(ctx context.Context) ([]oplmV1alpha1.ClusterServiceVersion, error) {
+	csvList, err := builder.apiClient.ClusterServiceVersions(builder.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CSVs in namespace %s: %w", builder.Namespace, err)
+	}
+
+	var subscriptionCSVs []string
+
+	subscription, err := builder.apiClient.Subscriptions(builder.Namespace).Get(ctx, builder.Subscription,
+		metav1.GetOptions{})
+	if err == nil {
+		subscriptionCSVs = []string{subscription.Status.InstalledCSV, subscription.Status.CurrentCSV}
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get subscription %s: %w", builder.Subscription, err)
+	}
+
+	var csvs []oplmV1alpha1.ClusterServiceVersion
+
+	for _, csv := range csvList.Items {
+		if csv.Status.Reason == oplmV1alpha1.CSVReasonCopied {
+			continue
+		}
+
+		if _, labeled := csv.Labels[builder.packageLabel()]; labeled || slices.Contains(subscriptionCSVs, csv.Name) {
+			csvs = append(csvs, csv)
+		}
+	}
+
+	return csvs, nil
+}
+
+// packageLabel is the label set by OLM on the objects of the operator.
+func (builder *OperatorUninstallBuilder) packageLabel() string {
+	return fmt.Sprintf("operators.coreos.com/%s.%s", builder.Package, builder.Namespace)
+}
+
+// ownedCRDsOf returns the CRDs owned by csvs, without duplicates.
+func ownedCRDsOf(csvs []oplmV1alpha1.ClusterServiceVersion) []oplmV1alpha1.CRDDescription {
+	var crds []oplmV1alpha1.CRDDescription
+
+	for _, csv := range csvs {
+		for _, crd := range csv.Spec.CustomResourceDefinitions.Owned {
+			if !slices.ContainsFunc(crds, func(other oplmV1alpha1.CRDDescription) bool {
+				return other.Name == crd.Name
+			}) {
+				crds = append(crds, crd)
+			}
+		}
+	}
+
+	return crds
+}
+
+// clusterExtensionSelector returns the label selector of the objects installed by the ClusterExtension.
+func (builder *OperatorUninstallBuilder) clusterExtensionSelector() string {
+	return labels.Set{
+		clusterExtensionOwnerKindLabel: "ClusterExtension",
+		clusterExtensionOwnerNameLabel: builder.ClusterExtension,
+	}.String()
+}
+
+// clusterExtensionCRDs returns the CRDs installed by the ClusterExtension, with their storage version.
+func (builder *OperatorUninstallBuilder) clusterExtensionCRDs(
+	ctx context.Context) ([]oplmV1alpha1.CRDDescription, error) {
+	crdList, err := builder.apiClient.Resource(crdResource).List(ctx,
+		metav1.ListOptions{LabelSelector: builder.clusterExtensionSelector()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CRDs of ClusterExtension %s: %w", builder.ClusterExtension, err)
+	}
+
+	var crds []oplmV1alpha1.CRDDescription
+
+	for _, crd := range crdList.Items {
+		kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+		versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+
+		for _, version := range versions {
+			version, _ := version.(map[string]interface{})
+			if storage, _ := version["storage"].(bool); storage {
+				name, _ := version["name"].(string)
+				crds = append(crds, oplmV1alpha1.CRDDescription{Name: crd.GetName(), Version: name, Kind: kind})
+			}
+		}
+	}
+
+	return crds, nil
+}
+
+// crdGroupVersionResource returns the resource served by the owned CRD.
+func crdGroupVersionResource(crd oplmV1alpha1.CRDDescription) schema.GroupVersionResource {
+	resource, group, _ := strings.Cut(crd.Name, ".")
+
+	return schema.GroupVersionResource{Group: group, Version: crd.Version, Resource: resource}
+}
+
+// deleteOperands deletes every CR of the owned CRD, in every namespace, and waits until they are gone.
+func (builder *OperatorUninstallBuilder) deleteOperands(ctx context.Context, report *UninstallReport,
+	crd oplmV1alpha1.CRDDescription) error {
+	resource := builder.apiClient.Resource(crdGroupVersionResource(crd))
+
+	operands, err := resource.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to list %s operands: %w", crd.Kind, err)
+	}
+
+	var errs []error
+
+	for _, operand := range operands.Items {
+		description := fmt.Sprintf("%s %s", crd.Kind, operand.GetName())
+		if operand.GetNamespace() != "" {
+			description = fmt.Sprintf("%s %s/%s", crd.Kind, operand.GetNamespace(), operand.GetName())
+		}
+
+		errs = append(errs, builder.deleteAndWait(ctx, report, description,
+			func(ctx context.Context) error {
+				return resource.Namespace(operand.GetNamespace()).Delete(ctx, operand.GetName(),
+					metav1.DeleteOptions{})
+			},
+			func(ctx context.Context) error {
+				_, err := resource.Namespace(operand.GetNamespace()).Get(ctx, operand.GetName(), metav1.GetOptions{})
+
+				return err
+			}))
+	}
+
+	return errors.Join(errs...)
+}
+
+// deleteOLMObjects deletes the Subscription, the CSVs, the InstallPlans and, when no other Subscription is
+// left in the namespace, the OperatorGroups of the operator.
+func (builder *OperatorUninstallBuilder) deleteOLMObjects(ctx context.Context, report *UninstallReport,
+	csvs []oplmV1alpha1.ClusterServiceVersion) error {
+	subscriptions := builder.apiClient.Subscriptions(builder.Namespace)
+	csvClient := builder.apiClient.ClusterServiceVersions(builder.Namespace)
+	installPlans := builder.apiClient.InstallPlans(builder.Namespace)
+	operatorGroups := builder.apiClient.OperatorGroups(builder.Namespace)
+
+	errs := []error{builder.deleteAndWait(ctx, report, "Subscription "+builder.Subscription,
+		func(ctx context.Context) error {
+			return subscriptions.Delete(ctx, builder.Subscription, metav1.DeleteOptions{})
+		},
+		func(ctx context.Context) error {
+			_, err := subscriptions.Get(ctx, builder.Subscription, metav1.GetOptions{})
+
+			return err
+		})}
+
+	for _, csv := range csvs {
+		errs = append(errs, builder.deleteAndWait(ctx, report, "ClusterServiceVersion "+csv.Name,
+			func(ctx context.Context) error {
+				return csvClient.Delete(ctx, csv.Name, metav1.DeleteOptions{})
+			},
+			func(ctx context.Context) error {
+				_, err := csvClient.Get(ctx, csv.Name, metav1.GetOptions{})
+
+				return err
+			}))
+	}
+
+	installPlanList, err := installPlans.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("failed to list installplans: %w", err))...)
+	}
+
+	for _, installPlan := range installPlanList.Items {
+		if !builder.isInstallPlanOfOperator(&installPlan, report.CSVs) {
+			continue
+		}
+
+		errs = append(errs, builder.deleteAndWait(ctx, report, "InstallPlan "+installPlan.Name,
+			func(ctx context.Context) error {
+				return installPlans.Delete(ctx, installPlan.Name, metav1.DeleteOptions{})
+			},
+			func(ctx context.Context) error {
+				_, err := installPlans.Get(ctx, installPlan.Name, metav1.GetOptions{})
+
+				return err
+			}))
+	}
+
+	subscriptionList, err := subscriptions.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("failed to list subscriptions: %w", err))...)
+	}
+
+	if len(subscriptionList.Items) != 0 {
+		glog.V(100).Infof("Keeping the OperatorGroups of namespace %s, used by %d other subscriptions",
+			builder.Namespace, len(subscriptionList.Items))
+
+		return errors.Join(errs...)
+	}
+
+	operatorGroupList, err := operatorGroups.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("failed to list operatorgroups: %w", err))...)
+	}
+
+	for _, operatorGroup := range operatorGroupList.Items {
+		errs = append(errs, builder.deleteAndWait(ctx, report, "OperatorGroup "+operatorGroup.Name,
+			func(ctx context.Context) error {
+				return operatorGroups.Delete(ctx, operatorGroup.Name, metav1.DeleteOptions{})
+			},
+			func(ctx context.Context) error {
+				_, err := operatorGroups.Get(ctx, operatorGroup.Name, metav1.GetOptions{})
+
+				return err
+			}))
+	}
+
+	return errors.Join(errs...)
+}
+
+// isInstallPlanOfOperator reports whether installPlan installs one of csvs or belongs to the Subscription.
+func (builder *OperatorUninstallBuilder) isInstallPlanOfOperator(installPlan *oplmV1alpha1.InstallPlan,
+	csvs []string) bool {
+	for _, csv := range installPlan.Spec.ClusterServiceVersionNames {
+		if slices.Contains(csvs, csv) {
+			return true
+		}
+	}
+
+	for _, owner := range installPlan.OwnerReferences {
+		if owner.Kind == oplmV1alpha1.SubscriptionKind && owner.Name == builder.Subscription {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deleteAndWait deletes an object and waits until get returns NotFound. An object already deleted is not
+// reported.
+func (builder *OperatorUninstallBuilder) deleteAndWait(ctx context.Context, report *UninstallReport,
+	description string, deleteObject, get func(ctx context.Context) error) error {
+	glog.V(100).Infof("Deleting %s", description)
+
+	if err := deleteObject(ctx); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete %s: %w", description, err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, builder.pollInterval, builder.timeout, true,
+		func(ctx context.Context) (bool, error) {
+			err := get(ctx)
+			if k8serrors.IsNotFound(err) {
+				return true, nil
+			}
+
+			glog.V(100).Infof("Waiting for %s to be deleted: %v", description, err)
+
+			return false, nil
+		})
+	if err != nil {
+		return fmt.Errorf("timed out waiting for %s to be deleted: %w", description, err)
+	}
+
+	report.Deleted = append(report.Deleted, description)
+
+	return nil
+}
+
+// findLeftovers adds to report the objects of the operator still found on the cluster.
+func (builder *OperatorUninstallBuilder) findLeftovers(ctx context.Context, report *UninstallReport,
+	ownedCRDs []oplmV1alpha1.CRDDescription) error {
+	var errs []error
+
+	addLeftover := func(kind, name, namespace, reason string) {
+		report.Leftovers = append(report.Leftovers, UninstallLeftover{
+			Kind: kind, Name: name, Namespace: namespace, Reason: reason})
+	}
+
+	for _, crd := range ownedCRDs {
+		operands, err := builder.apiClient.Resource(crdGroupVersionResource(crd)).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				errs = append(errs, fmt.Errorf("failed to list %s operands: %w", crd.Kind, err))
+			}
+
+			continue
+		}
+
+		for _, operand := range operands.Items {
+			addLeftover(crd.Kind, operand.GetName(), operand.GetNamespace(), "operand CR")
+		}
+
+		if !builder.deleteCRDs {
+			continue
+		}
+
+		_, err = builder.apiClient.Resource(crdResource).Get(ctx, crd.Name, metav1.GetOptions{})
+		if err == nil {
+			addLeftover("CustomResourceDefinition", crd.Name, "", "owned by the CSV")
+		} else if !k8serrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to get customresourcedefinition %s: %w", crd.Name, err))
+		}
+	}
+
+	errs = append(errs, builder.findNamespaceLeftovers(ctx, addLeftover))
+
+	if builder.ClusterExtension != "" {
+		err := builder.apiClient.Get(ctx, goclient.ObjectKey{Name: builder.ClusterExtension},
+			&ocv1.ClusterExtension{})
+		if err == nil {
+			addLeftover("ClusterExtension", builder.ClusterExtension, "", "operator ClusterExtension")
+		} else if !k8serrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to get ClusterExtension %s: %w", builder.ClusterExtension, err))
+		}
+	}
+
+	rbac := builder.apiClient.K8sClient.RbacV1()
+	admission := builder.apiClient.K8sClient.AdmissionregistrationV1()
+
+	for _, selector := range builder.leftoverSelectors(report) {
+		options := metav1.ListOptions{LabelSelector: selector}
+		reason := "labeled " + selector
+
+		if clusterRoles, err := rbac.ClusterRoles().List(ctx, options); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list clusterroles: %w", err))
+		} else {
+			for _, clusterRole := range clusterRoles.Items {
+				addLeftover("ClusterRole", clusterRole.Name, "", reason)
+			}
+		}
+
+		if clusterRoleBindings, err := rbac.ClusterRoleBindings().List(ctx, options); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list clusterrolebindings: %w", err))
+		} else {
+			for _, clusterRoleBinding := range clusterRoleBindings.Items {
+				addLeftover("ClusterRoleBinding", clusterRoleBinding.Name, "", reason)
+			}
+		}
+
+		if webhooks, err := admission.ValidatingWebhookConfigurations().List(ctx, options); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list validatingwebhookconfigurations: %w", err))
+		} else {
+			for _, webhook := range webhooks.Items {
+				addLeftover("ValidatingWebhookConfiguration", webhook.Name, "", reason)
+			}
+		}
+
+		if webhooks, err := admission.MutatingWebhookConfigurations().List(ctx, options); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list mutatingwebhookconfigurations: %w", err))
+		} else {
+			for _, webhook := range webhooks.Items {
+				addLeftover("MutatingWebhookConfiguration", webhook.Name, "", reason)
+			}
+		}
+
+		if !builder.deleteCRDs {
+			continue
+		}
+
+		if crds, err := builder.apiClient.Resource(crdResource).List(ctx, options); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list customresourcedefinitions: %w", err))
+		} else {
+			for _, crd := range crds.Items {
+				if !slices.ContainsFunc(report.Leftovers, func(leftover UninstallLeftover) bool {
+					return leftover.Kind == "CustomResourceDefinition" && leftover.Name == crd.GetName()
+				}) {
+					addLeftover("CustomResourceDefinition", crd.GetName(), "", reason)
+				}
+			}
+		}
+	}
+
+	if len(builder.nodeLabelPrefixes) != 0 {
+		nodes, err := builder.apiClient.CoreV1Interface.Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list nodes: %w", err))
+		} else {
+			for _, node := range nodes.Items {
+				for key := range node.Labels {
+					if slices.ContainsFunc(builder.nodeLabelPrefixes, func(prefix string) bool {
+						return strings.HasPrefix(key, prefix)
+					}) {
+						addLeftover("Node", node.Name, "", "label "+key)
+					}
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(report.Leftovers, func(a, b UninstallLeftover) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return errors.Join(errs...)
+}
+
+// leftoverSelectors returns the label selectors of the cluster scoped objects of the operator: the objects
+// labeled for its ClusterExtension, or for its package and its CSVs.
+func (builder *OperatorUninstallBuilder) leftoverSelectors(report *UninstallReport) []string {
+	if builder.ClusterExtension != "" {
+		return []string{builder.clusterExtensionSelector()}
+	}
+
+	selectors := []string{builder.packageLabel()}
+
+	if ownerSelector, err := labels.NewRequirement(olmOwnerLabel, selection.In, report.CSVs); err == nil &&
+		len(report.CSVs) != 0 {
+		selectors = append(selectors, ownerSelector.String())
+	}
+
+	return selectors
+}
+
+// findNamespaceLeftovers reports the namespace when it was to be deleted, and otherwise the OLM objects of the
+// Subscription and the workloads of the operator left in it.
+func (builder *OperatorUninstallBuilder) findNamespaceLeftovers(ctx context.Context,
+	addLeftover func(kind, name, namespace, reason string)) error {
+	_, err := builder.apiClient.Namespaces().Get(ctx, builder.Namespace, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get namespace %s: %w", builder.Namespace, err)
+	}
+
+	if builder.deleteNamespace {
+		addLeftover("Namespace", builder.Namespace, "", "operator namespace")
+
+		return nil
+	}
+
+	var errs []error
+
+	if builder.ClusterExtension == "" {
+		if subscription, err := builder.apiClient.Subscriptions(builder.Namespace).Get(ctx, builder.Subscription,
+			metav1.GetOptions{}); err == nil {
+			addLeftover("Subscription", subscription.Name, builder.Namespace, "operator subscription")
+		}
+
+		options := metav1.ListOptions{LabelSelector: builder.packageLabel()}
+
+		if csvs, err := builder.apiClient.ClusterServiceVersions(builder.Namespace).List(ctx, options); err != nil {
+			errs = append(errs, fmt.Errorf("failed to list CSVs: %w", err))
+		} else {
+			for _, csv := range csvs.Items {
+				addLeftover("ClusterServiceVersion", csv.Name, builder.Namespace, "labeled "+builder.packageLabel())
+			}
+		}
+	}
+
+	// The operands of an operator are deployed in its namespace, and are all left over once it is uninstalled.
+	if daemonSets, err := builder.apiClient.DaemonSets(builder.Namespace).List(ctx,
+		metav1.ListOptions{}); err != nil {
+		errs = append(errs, fmt.Errorf("failed to list daemonsets: %w", err))
+	} else {
+		for _, daemonSet := range daemonSets.Items {
+			addLeftover("DaemonSet", daemonSet.Name, builder.Namespace, "workload in the operator namespace")
+		}
+	}
+
+	if deployments, err := builder.apiClient.Deployments(builder.Namespace).List(ctx,
+		metav1.ListOptions{}); err != nil {
+		errs = append(errs, fmt.Errorf("failed to list deployments: %w", err))
+	} else {
+		for _, deployment := range deployments.Items {
+			addLeftover("Deployment", deployment.Name, builder.Namespace, "workload in the operator namespace")
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate will check that the builder is properly initialized before accessing any member fields.
+func (builder *OperatorUninstallBuilder) validate() (bool, error) {
+	resourceCRD := "operator uninstall"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
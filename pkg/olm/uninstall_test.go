@@ -0,0 +1,317 @@
+package olm
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	operatorsV1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorsV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm/ocv1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+const (
+	testCSVName = "gpu-operator-certified.v25.3.0"
+	testCRDName = "clusterpolicies.nvidia.com"
+)
+
+var clusterPoliciesResource = schema.GroupVersionResource{
+	Group: "nvidia.com", Version: "v1", Resource: "clusterpolicies"}
+
+func TestNewOperatorUninstallBuilder(t *testing.T) {
+	testCases := []struct {
+		packageName      string
+		subscriptionName string
+		nsname           string
+		expectedError    string
+	}{
+		{testPackage, testSubscriptionName, testSubscriptionNamespace, ""},
+		{"", testSubscriptionName, testSubscriptionNamespace, "operator uninstall 'packageName' cannot be empty"},
+		{testPackage, "", testSubscriptionNamespace, "operator uninstall 'subscriptionName' cannot be empty"},
+		{testPackage, testSubscriptionName, "", "operator uninstall 'nsname' cannot be empty"},
+	}
+
+	for _, testCase := range testCases {
+		builder := NewOperatorUninstallBuilder(clients.GetTestClients(clients.TestClientParams{}),
+			testCase.packageName, testCase.subscriptionName, testCase.nsname)
+
+		if builder.errorMsg != testCase.expectedError {
+			t.Errorf("expected error %q, got %q", testCase.expectedError, builder.errorMsg)
+		}
+	}
+}
+
+func TestOperatorUninstallWithOptions(t *testing.T) {
+	testCases := []struct {
+		name          string
+		prefixes      []string
+		pollInterval  time.Duration
+		timeout       time.Duration
+		expectedError string
+	}{
+		{
+			name:         "valid options",
+			prefixes:     []string{"nvidia.com/"},
+			pollInterval: time.Second,
+			timeout:      time.Minute,
+		},
+		{
+			name:          "empty node label prefixes",
+			pollInterval:  time.Second,
+			timeout:       time.Minute,
+			expectedError: "can not define operator uninstall with empty node label prefixes",
+		},
+		{
+			name:          "non positive timeout",
+			prefixes:      []string{"nvidia.com/"},
+			pollInterval:  time.Second,
+			expectedError: "can not define operator uninstall with non positive poll interval or timeout",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder := NewOperatorUninstallBuilder(clients.GetTestClients(clients.TestClientParams{}), testPackage,
+				testSubscriptionName, testSubscriptionNamespace).
+				WithNodeLabelPrefixes(testCase.prefixes...).
+				WithTimeout(testCase.pollInterval, testCase.timeout)
+
+			if builder.errorMsg != testCase.expectedError {
+				t.Errorf("expected error %q, got %q", testCase.expectedError, builder.errorMsg)
+			}
+		})
+	}
+}
+
+func TestOperatorUninstall(t *testing.T) {
+	testCases := []struct {
+		name              string
+		objects           []runtime.Object
+		withCRDs          bool
+		expectedDeleted   []string
+		expectedLeftovers []string
+	}{
+		{
+			name:     "operator with operands and CRDs",
+			objects:  []runtime.Object{buildUninstallSubscription(testSubscriptionName), buildOperatorGroup()},
+			withCRDs: true,
+			expectedDeleted: []string{
+				"ClusterPolicy gpu-cluster-policy",
+				"Subscription gpu-subscription",
+				"ClusterServiceVersion gpu-operator-certified.v25.3.0",
+				"InstallPlan install-abcde",
+				"OperatorGroup gpu-operator-group",
+				"CustomResourceDefinition clusterpolicies.nvidia.com",
+			},
+		},
+		{
+			name: "operator group used by another subscription",
+			objects: []runtime.Object{
+				buildUninstallSubscription(testSubscriptionName),
+				buildUninstallSubscription("other-subscription"),
+				buildOperatorGroup(),
+			},
+			expectedDeleted: []string{
+				"ClusterPolicy gpu-cluster-policy",
+				"Subscription gpu-subscription",
+				"ClusterServiceVersion gpu-operator-certified.v25.3.0",
+				"InstallPlan install-abcde",
+			},
+		},
+		{
+			name: "leftover workloads, RBAC and node labels",
+			objects: []runtime.Object{
+				buildUninstallSubscription(testSubscriptionName),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testSubscriptionNamespace}},
+				&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+					Name: "nvidia-driver-daemonset", Namespace: testSubscriptionNamespace}},
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{
+					Name: "gpu-operator", Labels: map[string]string{olmOwnerLabel: testCSVName}}},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name: "worker-0", Labels: map[string]string{"nvidia.com/gpu.present": "true"}}},
+			},
+			expectedDeleted: []string{
+				"ClusterPolicy gpu-cluster-policy",
+				"Subscription gpu-subscription",
+				"ClusterServiceVersion gpu-operator-certified.v25.3.0",
+				"InstallPlan install-abcde",
+			},
+			expectedLeftovers: []string{
+				"ClusterRole gpu-operator (labeled olm.owner in (gpu-operator-certified.v25.3.0))",
+				"DaemonSet nvidia-gpu-operator/nvidia-driver-daemonset (workload in the operator namespace)",
+				"Node worker-0 (label nvidia.com/gpu.present)",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			objects := append([]runtime.Object{buildUninstallCSV(), buildUninstallInstallPlan()},
+				testCase.objects...)
+			apiClient := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: objects})
+			// The operands and CRDs are served without a scheme, as they are only read through the dynamic client.
+			apiClient.Interface = dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					clusterPoliciesResource: "ClusterPolicyList",
+					crdResource:             "CustomResourceDefinitionList",
+				}, buildUnstructured(clusterPoliciesResource, "ClusterPolicy", "gpu-cluster-policy", nil),
+				buildUnstructured(crdResource, "CustomResourceDefinition", testCRDName,
+					map[string]interface{}{"operators.coreos.com/gpu-operator-certified.nvidia-gpu-operator": ""}))
+
+			builder := NewOperatorUninstallBuilder(apiClient, testPackage, testSubscriptionName,
+				testSubscriptionNamespace).
+				WithNodeLabelPrefixes("nvidia.com/").
+				WithTimeout(10*time.Millisecond, time.Second)
+			if testCase.withCRDs {
+				builder.WithCRDs()
+			}
+
+			report, err := builder.UninstallWithContext(context.TODO())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(report.CSVs) != 1 || report.CSVs[0] != testCSVName {
+				t.Errorf("expected CSVs [%s], got %v", testCSVName, report.CSVs)
+			}
+
+			if strings.Join(report.Deleted, ",") != strings.Join(testCase.expectedDeleted, ",") {
+				t.Errorf("expected deleted %v, got %v", testCase.expectedDeleted, report.Deleted)
+			}
+
+			var leftovers []string
+			for _, leftover := range report.Leftovers {
+				leftovers = append(leftovers, leftover.String())
+			}
+
+			if strings.Join(leftovers, ",") != strings.Join(testCase.expectedLeftovers, ",") {
+				t.Errorf("expected leftovers %v, got %v", testCase.expectedLeftovers, leftovers)
+			}
+		})
+	}
+}
+
+func TestClusterExtensionUninstall(t *testing.T) {
+	const clusterExtensionName = "gpu-operator"
+
+	crd := buildUnstructured(crdResource, "CustomResourceDefinition", testCRDName, map[string]interface{}{
+		clusterExtensionOwnerKindLabel: "ClusterExtension",
+		clusterExtensionOwnerNameLabel: clusterExtensionName,
+	})
+	crd.Object["spec"] = map[string]interface{}{
+		"names": map[string]interface{}{"kind": "ClusterPolicy"},
+		"versions": []interface{}{
+			map[string]interface{}{"name": "v1alpha1", "storage": false},
+			map[string]interface{}{"name": "v1", "storage": true},
+		},
+	}
+
+	apiClient := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+		&ocv1.ClusterExtension{ObjectMeta: metav1.ObjectMeta{Name: clusterExtensionName}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator", Labels: map[string]string{
+			clusterExtensionOwnerKindLabel: "ClusterExtension",
+			clusterExtensionOwnerNameLabel: clusterExtensionName,
+		}}},
+	}})
+	apiClient.Interface = dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			clusterPoliciesResource: "ClusterPolicyList",
+			crdResource:             "CustomResourceDefinitionList",
+		}, buildUnstructured(clusterPoliciesResource, "ClusterPolicy", "gpu-cluster-policy", nil), crd)
+
+	report, err := NewClusterExtensionUninstallBuilder(apiClient, testPackage, clusterExtensionName,
+		testSubscriptionNamespace).
+		WithTimeout(10*time.Millisecond, time.Second).
+		UninstallWithContext(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedDeleted := []string{"ClusterPolicy gpu-cluster-policy", "ClusterExtension gpu-operator"}
+	if strings.Join(report.Deleted, ",") != strings.Join(expectedDeleted, ",") {
+		t.Errorf("expected deleted %v, got %v", expectedDeleted, report.Deleted)
+	}
+
+	// The ClusterRole of the bundle is left, as the fake client does not act as operator-controller.
+	if len(report.Leftovers) != 1 || report.Leftovers[0].Kind != "ClusterRole" {
+		t.Errorf("expected the ClusterRole labeled for the ClusterExtension as leftover, got %v", report.Leftovers)
+	}
+
+	if !strings.Contains(report.String(), "ClusterExtension gpu-operator") {
+		t.Errorf("expected the report to name the ClusterExtension, got %q", report.String())
+	}
+}
+
+func TestOperatorUninstallDryRun(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+		buildUninstallSubscription(testSubscriptionName), buildUninstallCSV()}})
+	if err := apiClient.EnableDryRun(t.TempDir()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := NewOperatorUninstallBuilder(apiClient, testPackage, testSubscriptionName,
+		testSubscriptionNamespace).Uninstall()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report.Deleted) != 0 {
+		t.Errorf("expected nothing deleted in dry-run mode, got %v", report.Deleted)
+	}
+
+	if _, err := apiClient.Subscriptions(testSubscriptionNamespace).Get(context.TODO(), testSubscriptionName,
+		metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the subscription to be kept in dry-run mode, got %v", err)
+	}
+}
+
+func buildUninstallSubscription(name string) *operatorsV1alpha1.Subscription {
+	return &operatorsV1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testSubscriptionNamespace},
+		Spec:       &operatorsV1alpha1.SubscriptionSpec{Package: testPackage},
+		Status:     operatorsV1alpha1.SubscriptionStatus{InstalledCSV: testCSVName, CurrentCSV: testCSVName},
+	}
+}
+
+func buildUninstallCSV() *operatorsV1alpha1.ClusterServiceVersion {
+	return &operatorsV1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: testCSVName, Namespace: testSubscriptionNamespace},
+		Spec: operatorsV1alpha1.ClusterServiceVersionSpec{
+			CustomResourceDefinitions: operatorsV1alpha1.CustomResourceDefinitions{
+				Owned: []operatorsV1alpha1.CRDDescription{{Name: testCRDName, Version: "v1", Kind: "ClusterPolicy"}},
+			},
+		},
+	}
+}
+
+func buildUninstallInstallPlan() *operatorsV1alpha1.InstallPlan {
+	return &operatorsV1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: testInstallPlanName, Namespace: testSubscriptionNamespace},
+		Spec:       operatorsV1alpha1.InstallPlanSpec{ClusterServiceVersionNames: []string{testCSVName}},
+	}
+}
+
+func buildOperatorGroup() *operatorsV1.OperatorGroup {
+	return &operatorsV1.OperatorGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator-group", Namespace: testSubscriptionNamespace},
+	}
+}
+
+func buildUnstructured(resource schema.GroupVersionResource, kind, name string,
+	objectLabels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": resource.GroupVersion().String(),
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "labels": objectLabels},
+	}}
+}
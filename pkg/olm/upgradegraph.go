@@ -0,0 +1,382 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/golang/glog"
+	pkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// skipRangeAnnotation is the CSV annotation listing the versions a CSV can directly upgrade from.
+const skipRangeAnnotation = "olm.skipRange"
+
+// ChannelEntry is a CSV of a PackageManifest channel. Replaces, Skips and SkipRange are the upgrade edges of
+// the CSV, empty when the catalog does not report them.
+type ChannelEntry struct {
+	Name      string
+	Version   semver.Version
+	Replaces  string
+	Skips     []string
+	SkipRange string
+}
+
+// UpgradeChannel is a PackageManifest channel with its entries, sorted from the newest to the oldest version.
+type UpgradeChannel struct {
+	Name       string
+	CurrentCSV string
+	Entries    []ChannelEntry
+}
+
+// UpgradeGraph is the upgrade graph of an operator package, discovered from the channels of its
+// PackageManifest.
+type UpgradeGraph struct {
+	Package        string
+	DefaultChannel string
+	Channels       []UpgradeChannel
+}
+
+// UpgradeHop is a step of an upgrade path: the Subscription is moved to Channel, and OLM upgrades the operator
+// to CSV, the head of the channel.
+type UpgradeHop struct {
+	Channel string
+	CSV     string
+	Version semver.Version
+}
+
+// String returns the channel and CSV of the hop.
+func (hop UpgradeHop) String() string {
+	return fmt.Sprintf("%s (%s)", hop.Channel, hop.CSV)
+}
+
+// packageManifestChannels is the part of a PackageManifest describing its channels. Channel entries are only
+// served by recent package servers, and are not part of the PackageManifest type, so the PackageManifest is
+// read as an unstructured object.
+type packageManifestChannels struct {
+	Status struct {
+		DefaultChannel string `json:"defaultChannel"`
+		Channels       []struct {
+			Name           string `json:"name"`
+			CurrentCSV     string `json:"currentCSV"`
+			CurrentCSVDesc struct {
+				Version     string            `json:"version"`
+				Annotations map[string]string `json:"annotations"`
+			} `json:"currentCSVDesc"`
+			Entries []struct {
+				Name      string   `json:"name"`
+				Version   string   `json:"version"`
+				Replaces  string   `json:"replaces"`
+				Skips     []string `json:"skips"`
+				SkipRange string   `json:"skipRange"`
+			} `json:"entries"`
+		} `json:"channels"`
+	} `json:"status"`
+}
+
+// UpgradeGraph returns the upgrade graph of the PackageManifest, read from its channel entries. A channel
+// without entries is reduced to its current CSV. The entries are read from the PackageManifest of the same
+// CatalogSource as the builder, since several catalogs can serve a package under the same name.
+func (builder *PackageManifestBuilder) UpgradeGraph() (*UpgradeGraph, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	catalog := builder.Definition.Status.CatalogSource
+	if catalog == "" {
+		return nil, fmt.Errorf("PackageManifest %s in namespace %s has no CatalogSource", builder.Definition.Name,
+			builder.Definition.Namespace)
+	}
+
+	glog.V(100).Infof("Reading the upgrade graph of PackageManifest %s in namespace %s from catalog %s",
+		builder.Definition.Name, builder.Definition.Namespace, catalog)
+
+	unstructuredManifests, err := builder.apiClient.Resource(
+		pkgManifestV1.SchemeGroupVersion.WithResource("packagemanifests")).Namespace(
+		builder.Definition.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("catalog=%s", catalog),
+		FieldSelector: fmt.Sprintf("metadata.name=%s", builder.Definition.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PackageManifest %s in namespace %s: %w", builder.Definition.Name,
+			builder.Definition.Namespace, err)
+	}
+
+	// The selectors are checked again, since not every client applies them.
+	manifestIndex := slices.IndexFunc(unstructuredManifests.Items, func(item unstructured.Unstructured) bool {
+		return item.GetName() == builder.Definition.Name && item.GetLabels()["catalog"] == catalog
+	})
+	if manifestIndex < 0 {
+		return nil, fmt.Errorf("PackageManifest %s not found in namespace %s for catalog %s",
+			builder.Definition.Name, builder.Definition.Namespace, catalog)
+	}
+
+	var manifest packageManifestChannels
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(
+		unstructuredManifests.Items[manifestIndex].Object, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to read the channels of PackageManifest %s: %w", builder.Definition.Name, err)
+	}
+
+	graph := &UpgradeGraph{Package: builder.Definition.Name, DefaultChannel: manifest.Status.DefaultChannel}
+
+	for _, manifestChannel := range manifest.Status.Channels {
+		channel := UpgradeChannel{Name: manifestChannel.Name, CurrentCSV: manifestChannel.CurrentCSV}
+
+		for _, manifestEntry := range manifestChannel.Entries {
+			version, err := parseCSVVersion(manifestEntry.Name, manifestEntry.Version)
+			if err != nil {
+				glog.V(100).Infof("Skipping entry %s of channel %s: %v", manifestEntry.Name, channel.Name, err)
+
+				continue
+			}
+
+			channel.Entries = append(channel.Entries, ChannelEntry{
+				Name:      manifestEntry.Name,
+				Version:   version,
+				Replaces:  manifestEntry.Replaces,
+				Skips:     manifestEntry.Skips,
+				SkipRange: manifestEntry.SkipRange,
+			})
+		}
+
+		headIndex := slices.IndexFunc(channel.Entries, func(entry ChannelEntry) bool {
+			return entry.Name == channel.CurrentCSV
+		})
+
+		if headIndex < 0 {
+			version, err := parseCSVVersion(channel.CurrentCSV, manifestChannel.CurrentCSVDesc.Version)
+			if err != nil {
+				glog.V(100).Infof("Skipping channel %s: %v", channel.Name, err)
+
+				continue
+			}
+
+			channel.Entries = append(channel.Entries, ChannelEntry{Name: channel.CurrentCSV, Version: version})
+			headIndex = len(channel.Entries) - 1
+		}
+
+		// The skipRange of the current CSV is always known from its annotations.
+		if channel.Entries[headIndex].SkipRange == "" {
+			channel.Entries[headIndex].SkipRange = manifestChannel.CurrentCSVDesc.Annotations[skipRangeAnnotation]
+		}
+
+		sort.SliceStable(channel.Entries, func(i, j int) bool {
+			return channel.Entries[i].Version.GT(channel.Entries[j].Version)
+		})
+
+		graph.Channels = append(graph.Channels, channel)
+	}
+
+	return graph, nil
+}
+
+// Channel returns the channel of the graph named name, nil when the package has no such channel.
+func (graph *UpgradeGraph) Channel(name string) *UpgradeChannel {
+	for index := range graph.Channels {
+		if graph.Channels[index].Name == name {
+			return &graph.Channels[index]
+		}
+	}
+
+	return nil
+}
+
+// Head returns the entry of the current CSV of the channel.
+func (channel *UpgradeChannel) Head() ChannelEntry {
+	for _, entry := range channel.Entries {
+		if entry.Name == channel.CurrentCSV {
+			return entry
+		}
+	}
+
+	return ChannelEntry{Name: channel.CurrentCSV}
+}
+
+// UpgradesFrom reports whether OLM upgrades an operator installed at from to the head of the channel when its
+// Subscription is moved to the channel: from is an entry of the channel, or an entry of the channel replaces,
+// skips or has a skipRange including from. known is false when the channel reports none of these edges, in
+// which case any older version is assumed to upgrade.
+func (channel *UpgradeChannel) UpgradesFrom(from ChannelEntry) (upgrades, known bool) {
+	head := channel.Head()
+	if from.Name == head.Name || !head.Version.GT(from.Version) {
+		return false, true
+	}
+
+	hasEdges := false
+
+	for _, entry := range channel.Entries {
+		if entry.Name == from.Name || entry.Replaces == from.Name || slices.Contains(entry.Skips, from.Name) {
+			return true, true
+		}
+
+		if entry.Replaces != "" || len(entry.Skips) != 0 {
+			hasEdges = true
+		}
+
+		if entry.SkipRange == "" {
+			continue
+		}
+
+		hasEdges = true
+
+		skipRange, err := semver.ParseRange(entry.SkipRange)
+		if err != nil {
+			glog.V(100).Infof("Ignoring invalid skipRange '%s' of %s: %v", entry.SkipRange, entry.Name, err)
+
+			continue
+		}
+
+		if skipRange(from.Version) {
+			return true, true
+		}
+	}
+
+	return !hasEdges, hasEdges
+}
+
+// Entry returns the entry of the CSV named csvName in any channel of the graph. When the CSV is not found,
+// the version of the returned entry is parsed from csvName.
+func (graph *UpgradeGraph) Entry(csvName string) (ChannelEntry, error) {
+	for _, channel := range graph.Channels {
+		for _, entry := range channel.Entries {
+			if entry.Name == csvName {
+				return entry, nil
+			}
+		}
+	}
+
+	version, err := parseCSVVersion(csvName, "")
+	if err != nil {
+		return ChannelEntry{}, err
+	}
+
+	return ChannelEntry{Name: csvName, Version: version}, nil
+}
+
+// UpgradePath returns the hops upgrading an operator installed at fromCSV to the head of toChannel. Of the valid
+// paths, the one going through the most channels whose head version lies between the two versions is
+// returned, so that each intermediate version is exercised. The last hop is always toChannel. An error is
+// returned when toChannel cannot be reached.
+func (graph *UpgradeGraph) UpgradePath(fromCSV, toChannel string) ([]UpgradeHop, error) {
+	from, err := graph.Entry(fromCSV)
+	if err != nil {
+		return nil, err
+	}
+
+	target := graph.Channel(toChannel)
+	if target == nil {
+		return nil, fmt.Errorf("package %s has no channel '%s'", graph.Package, toChannel)
+	}
+
+	targetHead := target.Head()
+	if !targetHead.Version.GT(from.Version) {
+		return nil, fmt.Errorf("head %s of channel '%s' is not newer than installed CSV %s", targetHead.Name,
+			toChannel, fromCSV)
+	}
+
+	var intermediates []*UpgradeChannel
+
+	for index := range graph.Channels {
+		channel := &graph.Channels[index]
+		head := channel.Head()
+
+		if head.Name == targetHead.Name || !head.Version.GT(from.Version) || !targetHead.Version.GT(head.Version) {
+			continue
+		}
+
+		// Channels sharing their head, like a version channel and stable, are the same hop.
+		if slices.ContainsFunc(intermediates, func(other *UpgradeChannel) bool {
+			return other.CurrentCSV == channel.CurrentCSV
+		}) {
+			continue
+		}
+
+		intermediates = append(intermediates, channel)
+	}
+
+	sort.SliceStable(intermediates, func(i, j int) bool {
+		return intermediates[i].Head().Version.LT(intermediates[j].Head().Version)
+	})
+
+	// channels are the nodes of the path, in version order: longest[index] is the number of hops of the longest
+	// valid path reaching channels[index], -1 when it cannot be reached, and previous[index] its previous node.
+	channels := append(intermediates, target)
+	longest := make([]int, len(channels))
+	previous := make([]int, len(channels))
+
+	for index, channel := range channels {
+		longest[index], previous[index] = -1, -1
+
+		if upgrades, known := channel.UpgradesFrom(from); upgrades {
+			longest[index] = 1
+
+			logUnknownEdges(channel, from, known)
+		}
+
+		for previousIndex := 0; previousIndex < index; previousIndex++ {
+			if longest[previousIndex] < 0 || longest[previousIndex]+1 <= longest[index] {
+				continue
+			}
+
+			upgrades, known := channel.UpgradesFrom(channels[previousIndex].Head())
+			if !upgrades {
+				continue
+			}
+
+			longest[index], previous[index] = longest[previousIndex]+1, previousIndex
+
+			logUnknownEdges(channel, channels[previousIndex].Head(), known)
+		}
+	}
+
+	last := len(channels) - 1
+	if longest[last] < 0 {
+		return nil, fmt.Errorf("no upgrade path from %s to channel '%s' of package %s", fromCSV, toChannel,
+			graph.Package)
+	}
+
+	hops := make([]UpgradeHop, longest[last])
+
+	for index, hop := last, len(hops)-1; index >= 0; index, hop = previous[index], hop-1 {
+		head := channels[index].Head()
+		hops[hop] = UpgradeHop{Channel: channels[index].Name, CSV: head.Name, Version: head.Version}
+	}
+
+	glog.V(100).Infof("Upgrade path of package %s from %s to channel %s: %v", graph.Package, fromCSV, toChannel,
+		hops)
+
+	return hops, nil
+}
+
+func logUnknownEdges(channel *UpgradeChannel, from ChannelEntry, known bool) {
+	if !known {
+		glog.V(100).Infof("Channel %s reports no upgrade edges, assuming %s upgrades to %s", channel.Name,
+			from.Name, channel.CurrentCSV)
+	}
+}
+
+// parseCSVVersion parses version, or the version suffix of csvName, e.g. 24.3.0 for
+// gpu-operator-certified.v24.3.0, when version is empty.
+func parseCSVVersion(csvName, version string) (semver.Version, error) {
+	if version == "" {
+		index := strings.Index(csvName, ".v")
+		if index < 0 {
+			return semver.Version{}, fmt.Errorf("no version in CSV name '%s'", csvName)
+		}
+
+		version = csvName[index+len(".v"):]
+	}
+
+	parsed, err := semver.ParseTolerant(version)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("invalid version '%s' of CSV %s: %w", version, csvName, err)
+	}
+
+	return parsed, nil
+}
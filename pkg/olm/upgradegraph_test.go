@@ -0,0 +1,312 @@
+package olm
+
+import (
+	"strings"
+	"testing"
+
+	pkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+)
+
+const (
+	testCatalog          = "certified-operators"
+	testCatalogNamespace = "openshift-marketplace"
+)
+
+func TestUpgradeGraph(t *testing.T) {
+	testCases := []struct {
+		name             string
+		manifestCatalog  string
+		expectedError    string
+		expectedChannels map[string][]string
+	}{
+		{
+			name:            "package of the catalog",
+			manifestCatalog: testCatalog,
+			expectedChannels: map[string][]string{
+				"v24.9":  {"gpu-operator-certified.v24.9.2", "gpu-operator-certified.v24.9.1"},
+				"v25.3":  {"gpu-operator-certified.v25.3.0"},
+				"stable": {"gpu-operator-certified.v25.3.0"},
+			},
+		},
+		{
+			name:            "package of another catalog",
+			manifestCatalog: "community-operators",
+			expectedError:   "not found in namespace openshift-marketplace for catalog certified-operators",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// The PackageManifest is served without a scheme, so that its channel entries are kept.
+			apiClient := clients.GetTestClients(clients.TestClientParams{})
+			apiClient.Interface = dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					pkgManifestV1.SchemeGroupVersion.WithResource("packagemanifests"): "PackageManifestList",
+				}, buildUnstructuredPackageManifest(testCase.manifestCatalog))
+			builder := &PackageManifestBuilder{
+				apiClient:  apiClient,
+				Definition: buildPackageManifest(testCatalog),
+				Object:     buildPackageManifest(testCatalog),
+			}
+
+			graph, err := builder.UpgradeGraph()
+
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Errorf("expected error containing %q, got %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if graph.DefaultChannel != "stable" || len(graph.Channels) != len(testCase.expectedChannels) {
+				t.Fatalf("unexpected graph %+v", graph)
+			}
+
+			for channelName, expectedEntries := range testCase.expectedChannels {
+				channel := graph.Channel(channelName)
+				if channel == nil {
+					t.Fatalf("channel %s missing", channelName)
+				}
+
+				var entries []string
+				for _, entry := range channel.Entries {
+					entries = append(entries, entry.Name)
+				}
+
+				if strings.Join(entries, ",") != strings.Join(expectedEntries, ",") {
+					t.Errorf("expected entries %v in channel %s, got %v", expectedEntries, channelName, entries)
+				}
+			}
+
+			if head := graph.Channel("v25.3").Head(); head.SkipRange != ">=24.9.0 <25.3.0" {
+				t.Errorf("expected the skipRange of the head from its annotations, got %q", head.SkipRange)
+			}
+		})
+	}
+}
+
+func TestUpgradesFrom(t *testing.T) {
+	channel := UpgradeChannel{
+		Name:       "v25.3",
+		CurrentCSV: "gpu-operator-certified.v25.3.1",
+		Entries: []ChannelEntry{
+			testEntry("gpu-operator-certified.v25.3.1", "gpu-operator-certified.v25.3.0", "", nil),
+			testEntry("gpu-operator-certified.v25.3.0", "", ">=24.9.0 <25.3.0",
+				[]string{"gpu-operator-certified.v24.6.0"}),
+		},
+	}
+	noEdges := UpgradeChannel{
+		Name:       "v25.3",
+		CurrentCSV: "gpu-operator-certified.v25.3.1",
+		Entries:    []ChannelEntry{testEntry("gpu-operator-certified.v25.3.1", "", "", nil)},
+	}
+
+	testCases := []struct {
+		name             string
+		channel          UpgradeChannel
+		from             string
+		expectedUpgrades bool
+		expectedKnown    bool
+	}{
+		{"entry of the channel", channel, "gpu-operator-certified.v25.3.0", true, true},
+		{"in skipRange", channel, "gpu-operator-certified.v24.9.2", true, true},
+		{"skipped", channel, "gpu-operator-certified.v24.6.0", true, true},
+		{"no edge", channel, "gpu-operator-certified.v24.3.0", false, true},
+		{"head itself", channel, "gpu-operator-certified.v25.3.1", false, true},
+		{"newer than head", channel, "gpu-operator-certified.v25.10.0", false, true},
+		{"channel without edges", noEdges, "gpu-operator-certified.v24.3.0", true, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			upgrades, known := testCase.channel.UpgradesFrom(testEntry(testCase.from, "", "", nil))
+
+			if upgrades != testCase.expectedUpgrades || known != testCase.expectedKnown {
+				t.Errorf("expected upgrades %t known %t, got %t %t", testCase.expectedUpgrades,
+					testCase.expectedKnown, upgrades, known)
+			}
+		})
+	}
+}
+
+func TestUpgradePath(t *testing.T) {
+	graph := &UpgradeGraph{
+		Package: testPackage,
+		Channels: []UpgradeChannel{
+			{Name: "v24.9", CurrentCSV: "gpu-operator-certified.v24.9.2", Entries: []ChannelEntry{
+				testEntry("gpu-operator-certified.v24.9.2", "", ">=24.6.0 <24.9.2", nil)}},
+			{Name: "v25.3", CurrentCSV: "gpu-operator-certified.v25.3.0", Entries: []ChannelEntry{
+				testEntry("gpu-operator-certified.v25.3.0", "", ">=24.9.0 <25.3.0", nil)}},
+			{Name: "stable", CurrentCSV: "gpu-operator-certified.v25.3.0", Entries: []ChannelEntry{
+				testEntry("gpu-operator-certified.v25.3.0", "", ">=24.9.0 <25.3.0", nil)}},
+			{Name: "v25.10", CurrentCSV: "gpu-operator-certified.v25.10.0", Entries: []ChannelEntry{
+				testEntry("gpu-operator-certified.v25.10.0", "", ">=25.3.0 <25.10.0", nil)}},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		from          string
+		toChannel     string
+		expectedHops  []string
+		expectedError string
+	}{
+		{
+			name:         "through every intermediate channel",
+			from:         "gpu-operator-certified.v24.6.0",
+			toChannel:    "v25.10",
+			expectedHops: []string{"v24.9", "v25.3", "v25.10"},
+		},
+		{
+			name:         "direct",
+			from:         "gpu-operator-certified.v24.9.2",
+			toChannel:    "v25.3",
+			expectedHops: []string{"v25.3"},
+		},
+		{
+			name:          "unreachable",
+			from:          "gpu-operator-certified.v24.3.0",
+			toChannel:     "v25.10",
+			expectedError: "no upgrade path",
+		},
+		{
+			name:          "unknown channel",
+			from:          "gpu-operator-certified.v24.9.2",
+			toChannel:     "v26.1",
+			expectedError: "has no channel 'v26.1'",
+		},
+		{
+			name:          "not newer",
+			from:          "gpu-operator-certified.v25.3.0",
+			toChannel:     "stable",
+			expectedError: "is not newer than installed CSV",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			hops, err := graph.UpgradePath(testCase.from, testCase.toChannel)
+
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Errorf("expected error containing %q, got %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var channels []string
+			for _, hop := range hops {
+				channels = append(channels, hop.Channel)
+			}
+
+			if strings.Join(channels, ",") != strings.Join(testCase.expectedHops, ",") {
+				t.Errorf("expected hops %v, got %v", testCase.expectedHops, hops)
+			}
+		})
+	}
+}
+
+func TestParseCSVVersion(t *testing.T) {
+	testCases := []struct {
+		csvName       string
+		version       string
+		expected      string
+		expectedError bool
+	}{
+		{"gpu-operator-certified.v24.3.0", "", "24.3.0", false},
+		{"nvidia-network-operator.v25.1.0-beta.2", "", "25.1.0-beta.2", false},
+		{"gpu-operator-certified.v24.3.0", "24.3.1", "24.3.1", false},
+		{"gpu-operator-certified", "", "", true},
+		{"gpu-operator-certified.vnext", "", "", true},
+	}
+
+	for _, testCase := range testCases {
+		version, err := parseCSVVersion(testCase.csvName, testCase.version)
+
+		if testCase.expectedError != (err != nil) {
+			t.Errorf("%s: expected error %t, got %v", testCase.csvName, testCase.expectedError, err)
+
+			continue
+		}
+
+		if err == nil && version.String() != testCase.expected {
+			t.Errorf("%s: expected version %s, got %s", testCase.csvName, testCase.expected, version)
+		}
+	}
+}
+
+func testEntry(name, replaces, skipRange string, skips []string) ChannelEntry {
+	version, _ := parseCSVVersion(name, "")
+
+	return ChannelEntry{Name: name, Version: version, Replaces: replaces, SkipRange: skipRange, Skips: skips}
+}
+
+func buildPackageManifest(catalog string) *pkgManifestV1.PackageManifest {
+	return &pkgManifestV1.PackageManifest{
+		ObjectMeta: metav1.ObjectMeta{Name: testPackage, Namespace: testCatalogNamespace},
+		Status: pkgManifestV1.PackageManifestStatus{
+			CatalogSource:          catalog,
+			CatalogSourceNamespace: testCatalogNamespace,
+		},
+	}
+}
+
+// buildUnstructuredPackageManifest returns a PackageManifest of catalog with channel entries, which the typed
+// PackageManifest does not model.
+func buildUnstructuredPackageManifest(catalog string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": pkgManifestV1.SchemeGroupVersion.String(),
+		"kind":       "PackageManifest",
+		"metadata": map[string]interface{}{
+			"name":      testPackage,
+			"namespace": testCatalogNamespace,
+			"labels":    map[string]interface{}{"catalog": catalog},
+		},
+		"status": map[string]interface{}{
+			"catalogSource":  catalog,
+			"defaultChannel": "stable",
+			"channels": []interface{}{
+				map[string]interface{}{
+					"name":       "v24.9",
+					"currentCSV": "gpu-operator-certified.v24.9.2",
+					"entries": []interface{}{
+						map[string]interface{}{"name": "gpu-operator-certified.v24.9.1", "version": "24.9.1"},
+						map[string]interface{}{"name": "gpu-operator-certified.v24.9.2", "version": "24.9.2",
+							"replaces": "gpu-operator-certified.v24.9.1"},
+					},
+				},
+				map[string]interface{}{
+					"name":       "v25.3",
+					"currentCSV": "gpu-operator-certified.v25.3.0",
+					"currentCSVDesc": map[string]interface{}{
+						"version":     "25.3.0",
+						"annotations": map[string]interface{}{skipRangeAnnotation: ">=24.9.0 <25.3.0"},
+					},
+				},
+				map[string]interface{}{
+					"name":       "stable",
+					"currentCSV": "gpu-operator-certified.v25.3.0",
+					"currentCSVDesc": map[string]interface{}{
+						"version": "25.3.0",
+					},
+				},
+			},
+		},
+	}}
+}
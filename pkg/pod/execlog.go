@@ -0,0 +1,108 @@
+package pod
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// maxExecLogOutputBytes bounds the stdout and stderr kept in an exec record, so that copied files do not
+	// bloat the exec log.
+	maxExecLogOutputBytes = 64 * 1024
+)
+
+var (
+	// ExecLogPath is the file the exec records of the running spec are appended to. The reporter moves it into
+	// the report folder of a failed spec and removes it after every spec.
+	ExecLogPath = fmt.Sprintf("/tmp/pod_exec_logs_%d.log", os.Getpid())
+
+	execLogMutex sync.Mutex
+)
+
+// ExecRecord is the record of a command executed in a pod container, one JSON object per line of ExecLogPath.
+// Stderr is only populated by Copy: ExecCommand runs with a TTY, so its Stdout holds the combined output.
+type ExecRecord struct {
+	Time            time.Time `json:"time"`
+	Namespace       string    `json:"namespace"`
+	Pod             string    `json:"pod"`
+	Container       string    `json:"container"`
+	Command         []string  `json:"command"`
+	Stdout          string    `json:"stdout"`
+	StdoutTruncated bool      `json:"stdoutTruncated,omitempty"`
+	Stderr          string    `json:"stderr"`
+	StderrTruncated bool      `json:"stderrTruncated,omitempty"`
+	ExitCode        int       `json:"exitCode"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// exitStatus is implemented by the errors of commands exiting with a non-zero code.
+type exitStatus interface {
+	ExitStatus() int
+}
+
+// recordExec appends the record of a command executed in container of the pod to ExecLogPath.
+// Failing to record never fails the command.
+func (builder *Builder) recordExec(
+	container string, command []string, started time.Time, stdout, stderr []byte, execErr error) {
+	record := ExecRecord{
+		Time:            started,
+		Namespace:       builder.Object.Namespace,
+		Pod:             builder.Object.Name,
+		Container:       container,
+		Command:         command,
+		DurationSeconds: time.Since(started).Seconds(),
+	}
+
+	record.Stdout, record.StdoutTruncated = truncateOutput(stdout)
+	record.Stderr, record.StderrTruncated = truncateOutput(stderr)
+
+	if execErr != nil {
+		record.Error = execErr.Error()
+		record.ExitCode = -1
+
+		var exitErr exitStatus
+		if errors.As(execErr, &exitErr) {
+			record.ExitCode = exitErr.ExitStatus()
+		}
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		glog.V(100).Infof("Failed to marshal exec record: %v", err)
+
+		return
+	}
+
+	execLogMutex.Lock()
+	defer execLogMutex.Unlock()
+
+	file, err := os.OpenFile(ExecLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		glog.V(100).Infof("Failed to open exec log %s: %v", ExecLogPath, err)
+
+		return
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		glog.V(100).Infof("Failed to write exec log %s: %v", ExecLogPath, err)
+	}
+}
+
+func truncateOutput(output []byte) (string, bool) {
+	if len(output) > maxExecLogOutputBytes {
+		return string(output[:maxExecLogOutputBytes]), true
+	}
+
+	return string(output), false
+}
@@ -0,0 +1,172 @@
+package pod
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// testExitError is a command error exiting with a non-zero code.
+type testExitError struct {
+	code int
+}
+
+func (exitError testExitError) Error() string {
+	return "command terminated with non-zero exit code"
+}
+
+func (exitError testExitError) ExitStatus() int {
+	return exitError.code
+}
+
+func TestRecordExec(t *testing.T) {
+	testCases := []struct {
+		name             string
+		stdout           []byte
+		stderr           []byte
+		execErr          error
+		expectedExitCode int
+		expectedError    string
+	}{
+		{
+			name:   "successful command",
+			stdout: []byte("GPU 0: OK"),
+		},
+		{
+			name:             "command exiting with a non-zero code",
+			stdout:           []byte("partial output"),
+			stderr:           []byte("gpu_burn: no CUDA device"),
+			execErr:          testExitError{code: 3},
+			expectedExitCode: 3,
+			expectedError:    "command terminated with non-zero exit code",
+		},
+		{
+			name:             "failed stream",
+			execErr:          errors.New("connection reset"),
+			expectedExitCode: -1,
+			expectedError:    "connection reset",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			setTestExecLogPath(t)
+
+			builder := buildExecLogPod()
+			command := []string{"cat", "/tmp/gpu-burn.log"}
+
+			builder.recordExec("gpu-burn-ctr", command, time.Now(), testCase.stdout, testCase.stderr, testCase.execErr)
+
+			records := readExecRecords(t)
+			if len(records) != 1 {
+				t.Fatalf("expected 1 exec record, got %d", len(records))
+			}
+
+			record := records[0]
+
+			if record.Namespace != "test-gpu-burn" || record.Pod != "gpu-burn-pod" ||
+				record.Container != "gpu-burn-ctr" || len(record.Command) != len(command) {
+				t.Errorf("unexpected exec record identity: %+v", record)
+			}
+
+			if record.Stdout != string(testCase.stdout) || record.Stderr != string(testCase.stderr) {
+				t.Errorf("expected stdout %q and stderr %q, got %q and %q",
+					testCase.stdout, testCase.stderr, record.Stdout, record.Stderr)
+			}
+
+			if record.ExitCode != testCase.expectedExitCode || record.Error != testCase.expectedError {
+				t.Errorf("expected exit code %d and error %q, got %d and %q",
+					testCase.expectedExitCode, testCase.expectedError, record.ExitCode, record.Error)
+			}
+		})
+	}
+}
+
+func TestRecordExecAppends(t *testing.T) {
+	setTestExecLogPath(t)
+
+	builder := buildExecLogPod()
+
+	builder.recordExec("gpu-burn-ctr", []string{"nvidia-smi"}, time.Now(), []byte("ok"), nil, nil)
+	builder.recordExec("gpu-burn-ctr", []string{"cat", "/tmp/gpu-burn.log"}, time.Now(),
+		bytes.Repeat([]byte("x"), maxExecLogOutputBytes+1), nil, nil)
+
+	records := readExecRecords(t)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 exec records, got %d", len(records))
+	}
+
+	if records[0].StdoutTruncated || !records[1].StdoutTruncated || len(records[1].Stdout) != maxExecLogOutputBytes {
+		t.Errorf("expected only the second record to be truncated, got %t and %t",
+			records[0].StdoutTruncated, records[1].StdoutTruncated)
+	}
+}
+
+func TestTruncateOutput(t *testing.T) {
+	testCases := []struct {
+		name              string
+		output            []byte
+		expectedLength    int
+		expectedTruncated bool
+	}{
+		{"empty output", nil, 0, false},
+		{"short output", []byte("GPU 0: OK"), 9, false},
+		{"output at the limit", bytes.Repeat([]byte("x"), maxExecLogOutputBytes), maxExecLogOutputBytes, false},
+		{"output over the limit", bytes.Repeat([]byte("x"), maxExecLogOutputBytes+10), maxExecLogOutputBytes, true},
+	}
+
+	for _, testCase := range testCases {
+		output, truncated := truncateOutput(testCase.output)
+
+		if len(output) != testCase.expectedLength || truncated != testCase.expectedTruncated {
+			t.Errorf("%s: expected length %d and truncated %t, got %d and %t", testCase.name,
+				testCase.expectedLength, testCase.expectedTruncated, len(output), truncated)
+		}
+	}
+}
+
+func setTestExecLogPath(t *testing.T) {
+	t.Helper()
+
+	previousExecLogPath := ExecLogPath
+	ExecLogPath = filepath.Join(t.TempDir(), "pod_exec_logs.log")
+
+	t.Cleanup(func() {
+		ExecLogPath = previousExecLogPath
+	})
+}
+
+func readExecRecords(t *testing.T) []ExecRecord {
+	t.Helper()
+
+	content, err := os.ReadFile(ExecLogPath)
+	if err != nil {
+		t.Fatalf("failed to read exec log: %v", err)
+	}
+
+	var records []ExecRecord
+
+	for _, line := range bytes.Split(bytes.TrimSpace(content), []byte("\n")) {
+		var record ExecRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			t.Fatalf("failed to unmarshal exec record %q: %v", line, err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func buildExecLogPod() *Builder {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "gpu-burn-pod", Namespace: "test-gpu-burn"}}
+
+	return &Builder{Definition: pod, Object: pod}
+}
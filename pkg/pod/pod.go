@@ -248,6 +248,12 @@ func (builder *Builder) CreateAndWaitUntilRunning(timeout time.Duration) (*Build
 
 // WaitUntilRunning waits for the duration of the defined timeout or until the pod is running.
 func (builder *Builder) WaitUntilRunning(timeout time.Duration) error {
+	return builder.WaitUntilRunningWithContext(context.TODO(), timeout)
+}
+
+// WaitUntilRunningWithContext waits for the duration of the defined timeout or until the pod is running.
+// The wait stops as soon as ctx is cancelled.
+func (builder *Builder) WaitUntilRunningWithContext(ctx context.Context, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -255,11 +261,19 @@ func (builder *Builder) WaitUntilRunning(timeout time.Duration) error {
 	glog.V(100).Infof("Waiting for the defined period until pod %s in namespace %s is running",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	return builder.WaitUntilInStatus(corev1.PodRunning, timeout)
+	return builder.WaitUntilInStatusWithContext(ctx, corev1.PodRunning, timeout)
 }
 
 // WaitUntilInStatus waits for the duration of the defined timeout or until the pod gets to a specific status.
 func (builder *Builder) WaitUntilInStatus(status corev1.PodPhase, timeout time.Duration) error {
+	return builder.WaitUntilInStatusWithContext(context.TODO(), status, timeout)
+}
+
+// WaitUntilInStatusWithContext waits for the duration of the defined timeout or until the pod gets to a specific
+// status.
+// The wait stops as soon as ctx is cancelled.
+func (builder *Builder) WaitUntilInStatusWithContext(
+	ctx context.Context, status corev1.PodPhase, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -268,9 +282,9 @@ func (builder *Builder) WaitUntilInStatus(status corev1.PodPhase, timeout time.D
 		builder.Definition.Name, builder.Definition.Namespace, status)
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			updatePod, err := builder.apiClient.Pods(builder.Object.Namespace).Get(
-				context.TODO(), builder.Object.Name, metav1.GetOptions{})
+				ctx, builder.Object.Name, metav1.GetOptions{})
 			if err != nil {
 				return false, nil
 			}
@@ -281,6 +295,12 @@ func (builder *Builder) WaitUntilInStatus(status corev1.PodPhase, timeout time.D
 
 // WaitUntilDeleted waits for the duration of the defined timeout or until the pod is deleted.
 func (builder *Builder) WaitUntilDeleted(timeout time.Duration) error {
+	return builder.WaitUntilDeletedWithContext(context.TODO(), timeout)
+}
+
+// WaitUntilDeletedWithContext waits for the duration of the defined timeout or until the pod is deleted.
+// The wait stops as soon as ctx is cancelled.
+func (builder *Builder) WaitUntilDeletedWithContext(ctx context.Context, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -289,9 +309,9 @@ func (builder *Builder) WaitUntilDeleted(timeout time.Duration) error {
 		builder.Definition.Name, builder.Definition.Namespace)
 
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, false, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, false, func(ctx context.Context) (bool, error) {
 			_, err := builder.apiClient.Pods(builder.Definition.Namespace).Get(
-				context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+				ctx, builder.Definition.Name, metav1.GetOptions{})
 			if err == nil {
 				glog.V(100).Infof("pod %s/%s still present", builder.Definition.Namespace, builder.Definition.Name)
 
@@ -314,6 +334,12 @@ func (builder *Builder) WaitUntilDeleted(timeout time.Duration) error {
 
 // WaitUntilReady waits for the duration of the defined timeout or until the pod reaches the Ready condition.
 func (builder *Builder) WaitUntilReady(timeout time.Duration) error {
+	return builder.WaitUntilReadyWithContext(context.TODO(), timeout)
+}
+
+// WaitUntilReadyWithContext waits for the duration of the defined timeout or until the pod reaches the Ready
+// condition. The wait stops as soon as ctx is cancelled.
+func (builder *Builder) WaitUntilReadyWithContext(ctx context.Context, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -321,11 +347,19 @@ func (builder *Builder) WaitUntilReady(timeout time.Duration) error {
 	glog.V(100).Infof("Waiting for the defined period until pod %s in namespace %s is Ready",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	return builder.WaitUntilCondition(corev1.PodReady, timeout)
+	return builder.WaitUntilConditionWithContext(ctx, corev1.PodReady, timeout)
 }
 
 // WaitUntilCondition waits for the duration of the defined timeout or until the pod gets to a specific condition.
 func (builder *Builder) WaitUntilCondition(condition corev1.PodConditionType, timeout time.Duration) error {
+	return builder.WaitUntilConditionWithContext(context.TODO(), condition, timeout)
+}
+
+// WaitUntilConditionWithContext waits for the duration of the defined timeout or until the pod gets to a specific
+// condition.
+// The wait stops as soon as ctx is cancelled.
+func (builder *Builder) WaitUntilConditionWithContext(
+	ctx context.Context, condition corev1.PodConditionType, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -334,9 +368,9 @@ func (builder *Builder) WaitUntilCondition(condition corev1.PodConditionType, ti
 		builder.Definition.Name, builder.Definition.Namespace, condition)
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			updatePod, err := builder.apiClient.Pods(builder.Object.Namespace).Get(
-				context.TODO(), builder.Object.Name, metav1.GetOptions{})
+				ctx, builder.Object.Name, metav1.GetOptions{})
 			if err != nil {
 				return false, nil
 			}
@@ -352,15 +386,14 @@ func (builder *Builder) WaitUntilCondition(condition corev1.PodConditionType, ti
 }
 
 // ExecCommand runs command in the pod and returns the buffer output.
-func (builder *Builder) ExecCommand(command []string, containerName ...string) (bytes.Buffer, error) {
+// Every execution is recorded into ExecLogPath. The command runs with a TTY, so its stderr is merged into the
+// returned stdout.
+func (builder *Builder) ExecCommand(command []string, containerName ...string) (buffer bytes.Buffer, err error) {
 	if valid, err := builder.validate(); !valid {
 		return bytes.Buffer{}, err
 	}
 
-	var (
-		buffer bytes.Buffer
-		cName  string
-	)
+	var cName string
 
 	if len(containerName) > 0 {
 		cName = containerName[0]
@@ -371,6 +404,12 @@ func (builder *Builder) ExecCommand(command []string, containerName ...string) (
 	glog.V(100).Infof("Execute command %v in the pod %s container %s in namespace %s",
 		command, builder.Object.Name, cName, builder.Object.Namespace)
 
+	started := time.Now()
+
+	defer func() {
+		builder.recordExec(cName, command, started, buffer.Bytes(), nil, err)
+	}()
+
 	req := builder.apiClient.CoreV1Interface.RESTClient().
 		Post().
 		Namespace(builder.Object.Namespace).
@@ -407,8 +446,8 @@ func (builder *Builder) ExecCommand(command []string, containerName ...string) (
 }
 
 // Copy returns the contents of a file or path from a specified container into a buffer.
-// Setting the tar option returns a tar archive of the specified path.
-func (builder *Builder) Copy(path, containerName string, tar bool) (bytes.Buffer, error) {
+// Setting the tar option returns a tar archive of the specified path. Every copy is recorded into ExecLogPath.
+func (builder *Builder) Copy(path, containerName string, tar bool) (buffer bytes.Buffer, err error) {
 	if valid, err := builder.validate(); !valid {
 		return bytes.Buffer{}, err
 	}
@@ -431,7 +470,13 @@ func (builder *Builder) Copy(path, containerName string, tar bool) (bytes.Buffer
 		}
 	}
 
-	var buffer bytes.Buffer
+	var stderr bytes.Buffer
+
+	started := time.Now()
+
+	defer func() {
+		builder.recordExec(containerName, command, started, buffer.Bytes(), stderr.Bytes(), err)
+	}()
 
 	req := builder.apiClient.CoreV1Interface.RESTClient().
 		Post().
@@ -483,7 +528,7 @@ func (builder *Builder) Copy(path, containerName string, tar bool) (bytes.Buffer
 	err = exec.StreamWithContext(context.TODO(), remotecommand.StreamOptions{
 		Stdin:  os.Stdin,
 		Stdout: &buffer,
-		Stderr: os.Stderr,
+		Stderr: io.MultiWriter(os.Stderr, &stderr),
 		Tty:    false,
 	})
 
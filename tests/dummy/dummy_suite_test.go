@@ -6,7 +6,7 @@ import (
 	"runtime"
 	"testing"
 
-	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
+	"github.com/golang/glog"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/tsparams"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -17,7 +17,6 @@ var _, currentFile, _, _ = runtime.Caller(0)
 
 func TestDummy(t *testing.T) {
 	_, reporterConfig := GinkgoConfiguration()
-	reporterConfig.JUnitReport = inittools.GeneralConfig.GetJunitReportPath(currentFile)
 
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Dummy", Label(), reporterConfig)
@@ -27,3 +26,9 @@ var _ = JustAfterEach(func() {
 	reporter.ReportIfFailed(
 		CurrentSpecReport(), currentFile, tsparams.ReporterNamespacesToDump, tsparams.ReporterCRDsToDump, clients.SetScheme)
 })
+
+var _ = ReportAfterSuite("JUnit report", func(report Report) {
+	if err := reporter.WriteJUnitReport(report, currentFile); err != nil {
+		glog.Errorf("Error writing JUnit report, %v", err)
+	}
+})
@@ -3,15 +3,18 @@ package nvidiagpu
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiagpuv1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/networkparams"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/nvidiagpuconfig"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/redact"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
 	_ "github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
 	. "github.com/rh-ecosystem-edge/nvidia-ci/pkg/global"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/machine"
@@ -26,6 +29,7 @@ import (
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/deployment"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/namespace"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm/ocv1"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/pod"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
@@ -74,6 +78,12 @@ var (
 	SubscriptionChannel        = UndefinedValue
 	DefaultSubscriptionChannel = UndefinedValue
 	OperatorUpgradeToChannel   = UndefinedValue
+	operatorRollback           = false
+	StartingCSV                = UndefinedValue
+	useOLMv1                   = false
+	clusterExtensionAdmin      = false
+	ClusterCatalog             = UndefinedValue
+	VersionRange               = UndefinedValue
 	cleanupAfterTest           = true
 	deployFromBundle           = false
 	operatorBundleImage        = ""
@@ -87,6 +97,10 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 	var (
 		deployBundle       deploy.Deploy
 		deployBundleConfig deploy.BundleConfig
+		installedBundle    *ocv1.BundleMetadata
+
+		rollbackReport              *olm.RollbackReport
+		preUpgradeClusterPolicySpec *nvidiagpuv1.ClusterPolicySpec
 	)
 
 	nvidiaGPUConfig = nvidiagpuconfig.NewNvidiaGPUConfig()
@@ -94,6 +108,11 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 	Context("DeployGpu", Label("deploy-gpu-with-dtk"), func() {
 
 		BeforeAll(func() {
+			By("Validate NVIDIAGPU configuration")
+			Expect(nvidiaGPUConfig).ToNot(BeNil(), "failed to load NVIDIAGPU configuration")
+			Expect(nvidiaGPUConfig.Validate()).ToNot(HaveOccurred(), "invalid NVIDIAGPU configuration")
+			reporter.RecordConfig("nvidiagpu", nvidiaGPUConfig)
+
 			if nvidiaGPUConfig.InstanceType == "" {
 				glog.V(gpuparams.GpuLogLevel).Infof("env variable NVIDIAGPU_GPU_MACHINESET_INSTANCE_TYPE" +
 					" is not set, skipping scaling cluster")
@@ -165,6 +184,52 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 					"NVIDIAGPU_SUBSCRIPTION_UPGRADE_TO_CHANNEL value '%s'", OperatorUpgradeToChannel)
 			}
 
+			if nvidiaGPUConfig.InstallPlanApproval != "" {
+				InstallPlanApproval = v1alpha1.Approval(nvidiaGPUConfig.InstallPlanApproval)
+				glog.V(gpuparams.GpuLogLevel).Infof("InstallPlan approval now set to env variable "+
+					"NVIDIAGPU_INSTALL_PLAN_APPROVAL value '%s'", InstallPlanApproval)
+			}
+
+			if nvidiaGPUConfig.OperatorRollback {
+				operatorRollback = nvidiaGPUConfig.OperatorRollback
+				glog.V(gpuparams.GpuLogLevel).Infof("Flag to roll back the GPU Operator upgrade is set to env " +
+					"variable NVIDIAGPU_OPERATOR_ROLLBACK value 'true'")
+			}
+
+			if nvidiaGPUConfig.StartingCSV != "" {
+				StartingCSV = nvidiaGPUConfig.StartingCSV
+				glog.V(gpuparams.GpuLogLevel).Infof("Subscription starting CSV now set to env variable "+
+					"NVIDIAGPU_STARTING_CSV value '%s'", StartingCSV)
+			}
+
+			if nvidiaGPUConfig.UsesOLMv1() {
+				useOLMv1 = true
+				glog.V(gpuparams.GpuLogLevel).Infof("env variable NVIDIAGPU_INSTALL_MODE is set to '%s', will "+
+					"deploy GPU Operator with an OLM v1 ClusterExtension", nvidiaGPUConfig.InstallMode)
+
+				if nvidiaGPUConfig.ClusterCatalog == "" {
+					ClusterCatalog = nvidiagpu.ClusterCatalogDefault
+					glog.V(gpuparams.GpuLogLevel).Infof("env variable NVIDIAGPU_CLUSTERCATALOG is not set, "+
+						"using default GPU ClusterCatalog '%s'", ClusterCatalog)
+				} else {
+					ClusterCatalog = nvidiaGPUConfig.ClusterCatalog
+					glog.V(gpuparams.GpuLogLevel).Infof("GPU ClusterCatalog now set to env variable "+
+						"NVIDIAGPU_CLUSTERCATALOG value '%s'", ClusterCatalog)
+				}
+
+				if nvidiaGPUConfig.VersionRange != "" {
+					VersionRange = nvidiaGPUConfig.VersionRange
+					glog.V(gpuparams.GpuLogLevel).Infof("GPU ClusterExtension version range now set to env "+
+						"variable NVIDIAGPU_VERSION_RANGE value '%s'", VersionRange)
+				}
+
+				if nvidiaGPUConfig.ClusterExtensionClusterAdmin {
+					clusterExtensionAdmin = true
+					glog.V(gpuparams.GpuLogLevel).Infof("env variable NVIDIAGPU_CLUSTEREXTENSION_CLUSTER_ADMIN " +
+						"is set, the GPU ClusterExtension installer is bound to cluster-admin")
+				}
+			}
+
 			if nvidiaGPUConfig.GPUFallbackCatalogsourceIndexImage != "" {
 				glog.V(gpuparams.GpuLogLevel).Infof("env variable "+
 					"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_INDEX_IMAGE is set, and has value: '%s'",
@@ -218,6 +283,8 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			} else if err := inittools.GeneralConfig.WriteReport(OpenShiftVersionFile, []byte(ocpVersion)); err != nil {
 				glog.Error("Error writing an OpenShift version file: ", err)
 			}
+
+			reporter.RecordOpenShiftVersion(ocpVersion)
 			///////////////////////////////////////////////////////////////////////////////////////////////////////////
 			//start from here but then would need to pass Nfd instance by pointer
 			nfd.EnsureNFDIsInstalled(inittools.APIClient, Nfd, ocpVersion, gpuparams.GpuLogLevel)
@@ -235,26 +302,25 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 		AfterAll(func() {
 
 			if Nfd.CleanupAfterInstall && cleanupAfterTest {
-				// Here need to check if NFD CR is deployed, otherwise Deleting a non-existing CR will throw an error
-				// skipping error check for now cause any failure before entire NFD stack
-				By("Delete NFD CR instance in NFD namespace")
-				_ = nfd.NFDCRDeleteAndWait(inittools.APIClient, nfd.CRName, nfd.OperatorNamespace, nvidiagpu.DeletionPollInterval, nvidiagpu.DeletionTimeoutDuration)
-
-				By("Delete NFD CSV")
-				_ = nfd.DeleteNFDCSV(inittools.APIClient)
-
-				By("Delete NFD Subscription in NFD namespace")
-				_ = nfd.DeleteNFDSubscription(inittools.APIClient)
-
-				By("Delete NFD OperatorGroup in NFD namespace")
-				_ = nfd.DeleteNFDOperatorGroup(inittools.APIClient)
+				By("Uninstall NFD operator and report its leftover objects")
+				nfdReport, err := nfd.UninstallNFD(inittools.APIClient,
+					timeouts.Get(timeouts.DeletionPollInterval), timeouts.Get(timeouts.DeletionTimeout))
+				if nfdReport != nil {
+					glog.V(gpuparams.GpuLogLevel).Infof("%s", nfdReport)
+
+					if err := inittools.GeneralConfig.WriteReport(nfd.UninstallReportFile,
+						[]byte(nfdReport.String())); err != nil {
+						glog.Error("Error writing the NFD uninstall report file: ", err)
+					}
+				}
 
-				By("Delete NFD Namespace in NFD namespace")
-				_ = nfd.DeleteNFDNamespace(inittools.APIClient)
+				if err != nil {
+					glog.V(gpuparams.GpuLogLevel).Infof("NFD uninstall failed: %v", err)
+				}
 			}
 		})
 
-		It("Deploy NVIDIA GPU Operator with DTK", Label("nvidia-ci:gpu"), func() {
+		It("Deploy NVIDIA GPU Operator with DTK", Label("nvidia-ci:gpu"), func(ctx SpecContext) {
 
 			nfdcheck.CheckNfdInstallation(inittools.APIClient, nfd.RhcosLabel, nfd.RhcosLabelValue, inittools.GeneralConfig.WorkerLabelMap, networkparams.LogLevel)
 
@@ -310,8 +376,9 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				glog.V(gpuparams.GpuLogLevel).Infof("Just before waiting for GPU enabled machineset %s "+
 					"to be in Ready state", createdMsBuilder.Definition.ObjectMeta.Name)
 
-				err = machine.WaitForMachineSetReady(inittools.APIClient, createdMsBuilder.Definition.ObjectMeta.Name,
-					machineSetNamespace, nvidiagpu.MachineReadyWaitDuration)
+				err = machine.WaitForMachineSetReadyWithContext(ctx, inittools.APIClient,
+					createdMsBuilder.Definition.ObjectMeta.Name,
+					machineSetNamespace, timeouts.Get(timeouts.MachineReadyTimeout))
 
 				Expect(err).ToNot(HaveOccurred(), "Failed to detect at least one replica"+
 					" of MachineSet %s in Ready state during 15 min polling interval: %v",
@@ -328,8 +395,11 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 			// Here we don't need this step is we already have a GPU worker node on cluster
 			if ScaleCluster {
-				glog.V(gpuparams.GpuLogLevel).Infof("Sleeping for %s to allow the newly created GPU worker node to be labeled by NFD", nvidiagpu.NodeLabelingDelay.String())
-				time.Sleep(nvidiagpu.NodeLabelingDelay)
+				glog.V(gpuparams.GpuLogLevel).Infof("Waiting up to %s for the newly created GPU worker node to be "+
+					"labeled by NFD", timeouts.Get(timeouts.NodeLabelingTimeout))
+				err := wait.NodeLabeledWithContext(ctx, inittools.APIClient, WorkerNodeSelector,
+					timeouts.Get(timeouts.NodeLabelingCheckInterval), timeouts.Get(timeouts.NodeLabelingTimeout))
+				Expect(err).ToNot(HaveOccurred(), "error waiting for a GPU worker node labeled by NFD: %v", err)
 			}
 
 			By("Get Cluster Architecture from first GPU enabled worker node")
@@ -341,14 +411,27 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			clusterArchitecture = clusterArch
 			glog.V(gpuparams.GpuLogLevel).Infof("cluster architecture for GPU enabled worker node is: %s",
 				clusterArchitecture)
+			reporter.RecordClusterArchitecture(clusterArchitecture)
 
 			By("Check if GPU Operator Deployment is from Bundle")
 			if deployFromBundle {
 				// This returns the Deploy interface object initialized with the API client
 				deployBundle = deploy.NewDeploy(inittools.APIClient)
 				deployBundleConfig.BundleImage = operatorBundleImage
+				deployBundleConfig.SubscriptionName = nvidiagpu.SubscriptionName
+				deployBundleConfig.OperatorGroupName = nvidiagpu.OperatorGroupName
 				glog.V(gpuparams.GpuLogLevel).Infof("Deploying GPU operator from bundle image '%s'",
 					deployBundleConfig.BundleImage)
+			} else if useOLMv1 {
+				glog.V(gpuparams.GpuLogLevel).Infof("Deploying GPU operator from ClusterCatalog '%s'",
+					ClusterCatalog)
+
+				By(fmt.Sprintf("Wait for up to %s for GPU ClusterCatalog '%s' to be serving",
+					timeouts.Get(timeouts.ClusterCatalogServingTimeout), ClusterCatalog))
+				err = wait.ClusterCatalogServingWithContext(ctx, inittools.APIClient, ClusterCatalog,
+					timeouts.Get(timeouts.ClusterCatalogServingCheckInterval),
+					timeouts.Get(timeouts.ClusterCatalogServingTimeout))
+				Expect(err).ToNot(HaveOccurred(), "GPU ClusterCatalog '%s' is not serving: %v", ClusterCatalog, err)
 			} else {
 				glog.V(gpuparams.GpuLogLevel).Infof("Deploying GPU operator from catalogsource")
 
@@ -388,12 +471,13 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 						Expect(err).ToNot(HaveOccurred(), "error creating custom GPU catalogsource "+
 							"builder Object name %s:  %v", CustomCatalogSource, err)
 
-						By(fmt.Sprintf("Sleep for %s to allow the GPU custom catalogsource to be created", nvidiagpu.CatalogSourceCreationDelay))
-						time.Sleep(nvidiagpu.CatalogSourceCreationDelay)
-
-						glog.V(gpuparams.GpuLogLevel).Infof("Wait up to %s for custom GPU catalogsource to be ready", nvidiagpu.CatalogSourceReadyTimeout)
+						glog.V(gpuparams.GpuLogLevel).Infof("Wait up to %s for custom GPU catalogsource to be ready", timeouts.Get(timeouts.CatalogSourceReadyTimeout))
 
-						Expect(createdGPUCustomCatalogSourceBuilder.IsReady(nvidiagpu.CatalogSourceReadyTimeout)).NotTo(BeFalse())
+						_, err = createdGPUCustomCatalogSourceBuilder.WaitUntilReadyWithContext(ctx,
+							timeouts.Get(timeouts.CatalogSourceReadyTimeout), nvidiagpu.Package)
+						Expect(err).ToNot(HaveOccurred(), "custom GPU catalogsource '%s' with index image '%s' "+
+							"from NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_INDEX_IMAGE is not usable: %v",
+							CustomCatalogSource, CustomCatalogsourceIndexImage, err)
 
 						CatalogSource = createdGPUCustomCatalogSourceBuilder.Definition.Name
 
@@ -402,7 +486,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 						gpuPkgManifestBuilderByCustomCatalog, err := olm.PullPackageManifestByCatalogWithTimeout(inittools.APIClient,
 							nvidiagpu.Package, nvidiagpu.CatalogSourceNamespace, CustomCatalogSource,
-							nvidiagpu.PackageManifestCheckInterval, nvidiagpu.PackageManifestTimeout)
+							timeouts.Get(timeouts.PackageManifestCheckInterval), timeouts.Get(timeouts.PackageManifestTimeout))
 
 						Expect(err).ToNot(HaveOccurred(), "error getting GPU packagemanifest '%s' "+
 							"from custom catalog '%s':  %v", nvidiagpu.Package, CustomCatalogSource, err)
@@ -463,8 +547,35 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 			defer func() {
 				if cleanupAfterTest {
-					err := nsBuilder.Delete()
-					Expect(err).ToNot(HaveOccurred())
+					By("Uninstall GPU operator and report its leftover objects")
+					uninstallBuilder := olm.NewOperatorUninstallBuilder(inittools.APIClient, nvidiagpu.Package,
+						nvidiagpu.SubscriptionName, nvidiagpu.NvidiaGPUNamespace)
+					if useOLMv1 {
+						uninstallBuilder = olm.NewClusterExtensionUninstallBuilder(inittools.APIClient,
+							nvidiagpu.Package, nvidiagpu.ClusterExtensionName, nvidiagpu.NvidiaGPUNamespace)
+					}
+
+					uninstallReport, err := uninstallBuilder.
+						WithNamespace().
+						WithNodeLabelPrefixes(nvidiagpu.NodeLabelPrefix).
+						WithTimeout(timeouts.Get(timeouts.DeletionPollInterval), timeouts.Get(timeouts.DeletionTimeout)).
+						Uninstall()
+					if uninstallReport != nil {
+						glog.V(gpuparams.GpuLogLevel).Infof("%s", uninstallReport)
+
+						if err := inittools.GeneralConfig.WriteReport(nvidiagpu.UninstallReportFile,
+							[]byte(uninstallReport.String())); err != nil {
+							glog.Error("Error writing the GPU operator uninstall report file: ", err)
+						}
+					}
+
+					if useOLMv1 {
+						// The installer is only deleted once its ClusterExtension is gone.
+						err = errors.Join(err, olm.DeleteClusterExtensionInstaller(inittools.APIClient,
+							nvidiagpu.ClusterExtensionServiceAccount, nvidiagpu.NvidiaGPUNamespace))
+					}
+
+					Expect(err).ToNot(HaveOccurred(), "error uninstalling GPU operator: %v", err)
 				}
 			}()
 
@@ -476,11 +587,54 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 					deployBundleConfig.BundleImage)
 
 				err = deployBundle.DeployBundle(gpuparams.GpuLogLevel, &deployBundleConfig, nvidiagpu.NvidiaGPUNamespace,
-					nvidiagpu.GpuBundleDeploymentTimeout)
+					timeouts.Get(timeouts.BundleDeploymentTimeout))
 				Expect(err).ToNot(HaveOccurred(), "error from deploy.DeployBundle():  '%v' ", err)
 
 				glog.V(gpuparams.GpuLogLevel).Infof("GPU Operator bundle image '%s' deployed successfully "+
 					"in namespace '%s", deployBundleConfig.BundleImage, nvidiagpu.NvidiaGPUNamespace)
+			} else if useOLMv1 {
+				By("Create the ClusterExtension installer service account in NVIDIA GPU Operator Namespace")
+				if clusterExtensionAdmin {
+					err = olm.CreateClusterExtensionInstallerWithClusterAdmin(inittools.APIClient,
+						nvidiagpu.ClusterExtensionServiceAccount, nvidiagpu.NvidiaGPUNamespace)
+				} else {
+					err = olm.CreateClusterExtensionInstaller(inittools.APIClient,
+						nvidiagpu.ClusterExtensionServiceAccount, nvidiagpu.NvidiaGPUNamespace,
+						olm.ClusterExtensionInstallerRules(nvidiagpu.ClusterExtensionName))
+				}
+				Expect(err).ToNot(HaveOccurred(), "error creating GPU operator installer service account: %v", err)
+
+				By("Create ClusterExtension for the GPU operator")
+				clusterExtensionBuilder := olm.NewClusterExtensionBuilder(inittools.APIClient,
+					nvidiagpu.ClusterExtensionName, nvidiagpu.Package, nvidiagpu.NvidiaGPUNamespace,
+					nvidiagpu.ClusterExtensionServiceAccount).
+					WithCatalogs(ClusterCatalog).
+					WithWatchNamespace(nvidiagpu.NvidiaGPUNamespace)
+
+				if SubscriptionChannel != UndefinedValue {
+					glog.V(gpuparams.GpuLogLevel).Infof("Setting the ClusterExtension channel to: '%s'",
+						SubscriptionChannel)
+					clusterExtensionBuilder.WithChannels(SubscriptionChannel)
+				}
+
+				if VersionRange != UndefinedValue {
+					glog.V(gpuparams.GpuLogLevel).Infof("Setting the ClusterExtension version range to: '%s'",
+						VersionRange)
+					clusterExtensionBuilder.WithVersion(VersionRange)
+				}
+
+				_, err = clusterExtensionBuilder.Create()
+				Expect(err).ToNot(HaveOccurred(), "error creating GPU operator ClusterExtension: %v", err)
+
+				By(fmt.Sprintf("Wait for up to %s for the GPU operator ClusterExtension to be installed",
+					timeouts.Get(timeouts.ClusterExtensionInstalledTimeout)))
+				installedBundle, err = wait.ClusterExtensionInstalledWithContext(ctx, inittools.APIClient,
+					nvidiagpu.ClusterExtensionName, timeouts.Get(timeouts.ClusterExtensionInstalledCheckInterval),
+					timeouts.Get(timeouts.ClusterExtensionInstalledTimeout))
+				Expect(err).ToNot(HaveOccurred(), "error installing GPU operator ClusterExtension: %v", err)
+
+				glog.V(gpuparams.GpuLogLevel).Infof("GPU operator ClusterExtension installed bundle '%s' "+
+					"version '%s'", installedBundle.Name, installedBundle.Version)
 			} else {
 				By("Create OperatorGroup in NVIDIA GPU Operator Namespace")
 				ogBuilder := olm.NewOperatorGroupBuilder(inittools.APIClient, nvidiagpu.OperatorGroupName, nvidiagpu.NvidiaGPUNamespace)
@@ -496,13 +650,6 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 						ogBuilderCreated.Definition.Name, err)
 				}
 
-				defer func() {
-					if cleanupAfterTest {
-						err := ogBuilder.Delete()
-						Expect(err).ToNot(HaveOccurred())
-					}
-				}()
-
 				By("Create Subscription in NVIDIA GPU Operator Namespace")
 				subBuilder := olm.NewSubscriptionBuilder(inittools.APIClient, nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace,
 					CatalogSource, nvidiagpu.CatalogSourceNamespace, nvidiagpu.Package)
@@ -519,6 +666,11 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 				subBuilder.WithInstallPlanApproval(InstallPlanApproval)
 
+				if StartingCSV != UndefinedValue {
+					glog.V(gpuparams.GpuLogLevel).Infof("Setting the subscription starting CSV to: '%s'", StartingCSV)
+					subBuilder.WithStartingCSV(StartingCSV)
+				}
+
 				glog.V(gpuparams.GpuLogLevel).Infof("Creating the subscription, i.e Deploy the GPU operator")
 				createdSub, err := subBuilder.Create()
 
@@ -534,26 +686,46 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 						createdSub.Object.Status.CurrentCSV)
 				}
 
-				defer func() {
-					if cleanupAfterTest {
-						err := createdSub.Delete()
-						Expect(err).ToNot(HaveOccurred())
+				By(fmt.Sprintf("Wait for up to %s for the subscription to be resolved",
+					timeouts.Get(timeouts.SubscriptionResolutionTimeout)))
+				installPlanName, err := wait.SubscriptionResolvedWithContext(ctx, inittools.APIClient,
+					nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace,
+					timeouts.Get(timeouts.SubscriptionResolutionCheckInterval),
+					timeouts.Get(timeouts.SubscriptionResolutionTimeout))
+				Expect(err).ToNot(HaveOccurred(), "error resolving subscription '%s': %v",
+					nvidiagpu.SubscriptionName, err)
+
+				if InstallPlanApproval == v1alpha1.ApprovalManual {
+					targetCSV := ""
+					if StartingCSV != UndefinedValue {
+						targetCSV = StartingCSV
 					}
-				}()
 
-			}
+					By(fmt.Sprintf("Approve the pending InstallPlan for CSV '%s' and wait for up to %s for it "+
+						"to complete", targetCSV, timeouts.Get(timeouts.InstallPlanCompleteTimeout)))
+					_, err = olm.ApproveInstallPlanForCSV(ctx, inittools.APIClient, nvidiagpu.SubscriptionName,
+						nvidiagpu.SubscriptionNamespace, targetCSV, timeouts.Get(timeouts.InstallPlanCompleteTimeout))
+					Expect(err).ToNot(HaveOccurred(), "error approving the InstallPlan of subscription '%s': %v",
+						nvidiagpu.SubscriptionName, err)
+				} else {
+					By(fmt.Sprintf("Wait for up to %s for InstallPlan '%s' to complete",
+						timeouts.Get(timeouts.InstallPlanCompleteTimeout), installPlanName))
+					err = wait.InstallPlanCompleteWithContext(ctx, inittools.APIClient, installPlanName,
+						nvidiagpu.SubscriptionNamespace, timeouts.Get(timeouts.InstallPlanCompleteCheckInterval),
+						timeouts.Get(timeouts.InstallPlanCompleteTimeout))
+					Expect(err).ToNot(HaveOccurred(), "error waiting for InstallPlan '%s' to complete: %v",
+						installPlanName, err)
+				}
 
-			By(fmt.Sprintf("Sleep for %s to allow the GPU Operator deployment to be created", nvidiagpu.OperatorDeploymentCreationDelay))
-			glog.V(gpuparams.GpuLogLevel).Infof("Sleep for %s to allow the GPU Operator deployment to be created", nvidiagpu.OperatorDeploymentCreationDelay)
-			time.Sleep(nvidiagpu.OperatorDeploymentCreationDelay)
+			}
 
-			By(fmt.Sprintf("Wait for up to %s for GPU Operator deployment to be created", nvidiagpu.DeploymentCreationTimeout))
-			gpuDeploymentCreated := wait.DeploymentCreated(
+			By(fmt.Sprintf("Wait for up to %s for GPU Operator deployment to be created", timeouts.Get(timeouts.DeploymentCreationTimeout)))
+			gpuDeploymentCreated := wait.DeploymentCreatedWithContext(ctx,
 				inittools.APIClient,
 				nvidiagpu.OperatorDeployment,
 				nvidiagpu.NvidiaGPUNamespace,
-				nvidiagpu.DeploymentCreationCheckInterval,
-				nvidiagpu.DeploymentCreationTimeout)
+				timeouts.Get(timeouts.DeploymentCreationCheckInterval),
+				timeouts.Get(timeouts.DeploymentCreationTimeout))
 
 			Expect(gpuDeploymentCreated).ToNot(BeFalse(), "timed out waiting to deploy GPU operator")
 
@@ -566,28 +738,36 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(gpuparams.GpuLogLevel).Infof("Pulled GPU operator deployment is:  %v ",
 				gpuOperatorDeployment.Definition.Name)
 
-			if gpuOperatorDeployment.IsReady(nvidiagpu.OperatorDeploymentReadyTimeout) {
+			if gpuOperatorDeployment.IsReadyWithContext(ctx, timeouts.Get(timeouts.OperatorDeploymentReadyTimeout)) {
 				glog.V(gpuparams.GpuLogLevel).Infof("Pulled GPU operator deployment '%s' is Ready",
 					gpuOperatorDeployment.Definition.Name)
 			}
 
-			By("Get the CSV deployed in NVIDIA GPU Operator namespace")
-			csvBuilderList, err := olm.ListClusterServiceVersion(inittools.APIClient, nvidiagpu.NvidiaGPUNamespace)
+			if useOLMv1 {
+				// OLM v1 creates no ClusterServiceVersion, the bundle name and version are reported instead.
+				CurrentCSV = installedBundle.Name
+				CurrentCSVVersion = installedBundle.Version
+				glog.V(gpuparams.GpuLogLevel).Infof("Installed bundle is: '%s'", CurrentCSV)
+			} else {
+				By("Get the CSV deployed in NVIDIA GPU Operator namespace")
+				csvBuilderList, err := olm.ListClusterServiceVersion(inittools.APIClient, nvidiagpu.NvidiaGPUNamespace)
+
+				Expect(err).ToNot(HaveOccurred(), "Error getting list of CSVs in GPU operator "+
+					"namespace: '%v'", err)
+				Expect(csvBuilderList).To(HaveLen(1), "Exactly one GPU operator CSV is expected")
 
-			Expect(err).ToNot(HaveOccurred(), "Error getting list of CSVs in GPU operator "+
-				"namespace: '%v'", err)
-			Expect(csvBuilderList).To(HaveLen(1), "Exactly one GPU operator CSV is expected")
+				csvBuilder := csvBuilderList[0]
 
-			csvBuilder := csvBuilderList[0]
+				CurrentCSV = csvBuilder.Definition.Name
+				glog.V(gpuparams.GpuLogLevel).Infof("Deployed ClusterServiceVersion is: '%s", CurrentCSV)
 
-			CurrentCSV = csvBuilder.Definition.Name
-			glog.V(gpuparams.GpuLogLevel).Infof("Deployed ClusterServiceVersion is: '%s", CurrentCSV)
+				CurrentCSVVersion = csvBuilder.Definition.Spec.Version.String()
+			}
 
-			CurrentCSVVersion = csvBuilder.Definition.Spec.Version.String()
 			csvVersionString := CurrentCSVVersion
 
 			if deployFromBundle {
-				csvVersionString = fmt.Sprintf("%s(bundle)", csvBuilder.Definition.Spec.Version.String())
+				csvVersionString = fmt.Sprintf("%s(bundle)", CurrentCSVVersion)
 			}
 
 			glog.V(gpuparams.GpuLogLevel).Infof("ClusterServiceVersion version to be written in the operator "+
@@ -597,42 +777,54 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				glog.Error("Error writing an operator version file: ", err)
 			}
 
-			By("Wait for deployed ClusterServiceVersion to be in Succeeded phase")
-			glog.V(gpuparams.GpuLogLevel).Infof("Waiting for ClusterServiceVersion '%s' to be in Succeeded phase",
-				CurrentCSV)
-			err = wait.CSVSucceeded(inittools.APIClient, CurrentCSV, nvidiagpu.NvidiaGPUNamespace,
-				nvidiagpu.CsvSucceededCheckInterval, nvidiagpu.CsvSucceededTimeout)
-			glog.V(gpuparams.GpuLogLevel).Info("error waiting for ClusterServiceVersion '%s' to be "+
-				"in Succeeded phase:  %v ", CurrentCSV, err)
-			Expect(err).ToNot(HaveOccurred(), "error waiting for ClusterServiceVersion to be "+
-				"in Succeeded phase: ", err)
-
-			By("Pull existing CSV in NVIDIA GPU Operator Namespace")
-			clusterCSV, err := olm.PullClusterServiceVersion(inittools.APIClient, CurrentCSV, nvidiagpu.NvidiaGPUNamespace)
-			Expect(err).ToNot(HaveOccurred(), "error pulling CSV from cluster:  %v", err)
+			reporter.RecordOperatorVersion(nvidiagpu.Package, CurrentCSV, csvVersionString)
 
-			glog.V(gpuparams.GpuLogLevel).Infof("clusterCSV from cluster lastUpdatedTime is : %v ",
-				clusterCSV.Definition.Status.LastUpdateTime)
+			var almExamples string
 
-			glog.V(gpuparams.GpuLogLevel).Infof("clusterCSV from cluster Phase is : \"%v\"",
-				clusterCSV.Definition.Status.Phase)
+			if useOLMv1 {
+				By("Get ALM examples block from the bundle in the ClusterCatalog")
+				clusterCatalog, err := olm.PullClusterCatalog(inittools.APIClient, ClusterCatalog)
+				Expect(err).ToNot(HaveOccurred(), "error pulling ClusterCatalog '%s': %v", ClusterCatalog, err)
 
-			succeeded := v1alpha1.ClusterServiceVersionPhase("Succeeded")
-			Expect(clusterCSV.Definition.Status.Phase).To(Equal(succeeded), "CSV Phase is not "+
-				"succeeded")
+				catalogBundle, err := clusterCatalog.GetBundle(ctx, nvidiagpu.Package, CurrentCSV)
+				Expect(err).ToNot(HaveOccurred(), "error getting bundle '%s' from ClusterCatalog '%s': %v",
+					CurrentCSV, ClusterCatalog, err)
 
-			defer func() {
-				if cleanupAfterTest {
-					err := clusterCSV.Delete()
-					Expect(err).ToNot(HaveOccurred())
-				}
-			}()
-
-			By("Get ALM examples block form CSV")
-			almExamples, err := clusterCSV.GetAlmExamples()
-			Expect(err).ToNot(HaveOccurred(), "Error from pulling almExamples from csv "+
-				"from cluster:  %v ", err)
-			glog.V(gpuparams.GpuLogLevel).Infof("almExamples block from clusterCSV  is : %v ", almExamples)
+				almExamples, err = catalogBundle.GetAlmExamples()
+				Expect(err).ToNot(HaveOccurred(), "Error from pulling almExamples from bundle "+
+					"'%s':  %v ", CurrentCSV, err)
+				glog.V(gpuparams.GpuLogLevel).Infof("almExamples block from bundle is : %v ", almExamples)
+			} else {
+				By("Wait for deployed ClusterServiceVersion to be in Succeeded phase")
+				glog.V(gpuparams.GpuLogLevel).Infof("Waiting for ClusterServiceVersion '%s' to be in Succeeded phase",
+					CurrentCSV)
+				err = wait.CSVSucceededWithContext(ctx, inittools.APIClient, CurrentCSV, nvidiagpu.NvidiaGPUNamespace,
+					timeouts.Get(timeouts.CSVSucceededCheckInterval), timeouts.Get(timeouts.CSVSucceededTimeout))
+				glog.V(gpuparams.GpuLogLevel).Info("error waiting for ClusterServiceVersion '%s' to be "+
+					"in Succeeded phase:  %v ", CurrentCSV, err)
+				Expect(err).ToNot(HaveOccurred(), "error waiting for ClusterServiceVersion to be "+
+					"in Succeeded phase: ", err)
+
+				By("Pull existing CSV in NVIDIA GPU Operator Namespace")
+				clusterCSV, err := olm.PullClusterServiceVersion(inittools.APIClient, CurrentCSV, nvidiagpu.NvidiaGPUNamespace)
+				Expect(err).ToNot(HaveOccurred(), "error pulling CSV from cluster:  %v", err)
+
+				glog.V(gpuparams.GpuLogLevel).Infof("clusterCSV from cluster lastUpdatedTime is : %v ",
+					clusterCSV.Definition.Status.LastUpdateTime)
+
+				glog.V(gpuparams.GpuLogLevel).Infof("clusterCSV from cluster Phase is : \"%v\"",
+					clusterCSV.Definition.Status.Phase)
+
+				succeeded := v1alpha1.ClusterServiceVersionPhase("Succeeded")
+				Expect(clusterCSV.Definition.Status.Phase).To(Equal(succeeded), "CSV Phase is not "+
+					"succeeded")
+
+				By("Get ALM examples block form CSV")
+				almExamples, err = clusterCSV.GetAlmExamples()
+				Expect(err).ToNot(HaveOccurred(), "Error from pulling almExamples from csv "+
+					"from cluster:  %v ", err)
+				glog.V(gpuparams.GpuLogLevel).Infof("almExamples block from clusterCSV  is : %v ", almExamples)
+			}
 
 			By("Deploy ClusterPolicy")
 			glog.V(gpuparams.GpuLogLevel).Infof("Creating ClusterPolicy from CSV almExamples")
@@ -643,13 +835,6 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy '%s' is successfully created",
 				createdClusterPolicyBuilder.Definition.Name)
 
-			defer func() {
-				if cleanupAfterTest {
-					_, err := createdClusterPolicyBuilder.Delete()
-					Expect(err).ToNot(HaveOccurred())
-				}
-			}()
-
 			By("Pull the ClusterPolicy just created from cluster, with updated fields")
 			pulledClusterPolicy, err := nvidiagpu.Pull(inittools.APIClient, nvidiagpu.ClusterPolicyName)
 			Expect(err).ToNot(HaveOccurred(), "error pulling ClusterPolicy %s from cluster: "+
@@ -661,16 +846,16 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				glog.V(gpuparams.GpuLogLevel).Infof("The ClusterPolicy just created has name:  %v",
 					pulledClusterPolicy.Definition.Name)
 				glog.V(gpuparams.GpuLogLevel).Infof("The ClusterPolicy just created marshalled "+
-					"in json: %v", string(cpJSON))
+					"in json: %v", string(redact.Bytes(cpJSON)))
 			} else {
 				glog.V(gpuparams.GpuLogLevel).Infof("Error Marshalling ClusterPolicy into json:  %v",
 					err)
 			}
 
-			By(fmt.Sprintf("Wait up to %s for ClusterPolicy to be ready", nvidiagpu.ClusterPolicyReadyTimeout))
-			glog.V(gpuparams.GpuLogLevel).Infof("Waiting up to %s for ClusterPolicy to be ready", nvidiagpu.ClusterPolicyReadyTimeout)
-			err = wait.ClusterPolicyReady(inittools.APIClient, nvidiagpu.ClusterPolicyName,
-				nvidiagpu.ClusterPolicyReadyCheckInterval, nvidiagpu.ClusterPolicyReadyTimeout)
+			By(fmt.Sprintf("Wait up to %s for ClusterPolicy to be ready", timeouts.Get(timeouts.ClusterPolicyReadyTimeout)))
+			glog.V(gpuparams.GpuLogLevel).Infof("Waiting up to %s for ClusterPolicy to be ready", timeouts.Get(timeouts.ClusterPolicyReadyTimeout))
+			err = wait.ClusterPolicyReadyWithContext(ctx, inittools.APIClient, nvidiagpu.ClusterPolicyName,
+				timeouts.Get(timeouts.ClusterPolicyReadyCheckInterval), timeouts.Get(timeouts.ClusterPolicyReadyTimeout))
 
 			glog.V(gpuparams.GpuLogLevel).Infof("error waiting for ClusterPolicy to be Ready:  %v ", err)
 			Expect(err).ToNot(HaveOccurred(), "error waiting for ClusterPolicy to be Ready:  %v ",
@@ -687,7 +872,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				glog.V(gpuparams.GpuLogLevel).Infof("The ready ClusterPolicy just has name:  %v",
 					pulledReadyClusterPolicy.Definition.Name)
 				glog.V(gpuparams.GpuLogLevel).Infof("The ready ClusterPolicy just marshalled "+
-					"in json: %v", string(cpReadyJSON))
+					"in json: %v", string(redact.Bytes(cpReadyJSON)))
 			} else {
 				glog.V(gpuparams.GpuLogLevel).Infof("Error Marshalling the ready ClusterPolicy into json:  %v",
 					err)
@@ -758,7 +943,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				BurnImageName[clusterArchitecture], burn.Namespace)
 
 			gpuBurnPod, err := gpuburn.CreateGPUBurnPod(inittools.APIClient, burn.Namespace, burn.Namespace,
-				BurnImageName[(clusterArchitecture)], nvidiagpu.BurnPodCreationTimeout)
+				BurnImageName[(clusterArchitecture)], timeouts.Get(timeouts.BurnPodCreationTimeout))
 			Expect(err).ToNot(HaveOccurred(), "Error creating gpu burn pod: %v", err)
 
 			glog.V(gpuparams.GpuLogLevel).Infof("Creating gpu-burn pod '%s' in namespace '%s'",
@@ -791,14 +976,14 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				}
 			}()
 
-			By(fmt.Sprintf("Wait for up to %s for gpu-burn pod to be in Running phase", nvidiagpu.BurnPodRunningTimeout))
-			err = gpuPodPulled.WaitUntilInStatus(corev1.PodRunning, nvidiagpu.BurnPodRunningTimeout)
+			By(fmt.Sprintf("Wait for up to %s for gpu-burn pod to be in Running phase", timeouts.Get(timeouts.BurnPodRunningTimeout)))
+			err = gpuPodPulled.WaitUntilInStatusWithContext(ctx, corev1.PodRunning, timeouts.Get(timeouts.BurnPodRunningTimeout))
 			Expect(err).ToNot(HaveOccurred(), "timeout waiting for gpu-burn pod in "+
 				"namespace '%s' to go to Running phase:  %v ", burn.Namespace, err)
 			glog.V(gpuparams.GpuLogLevel).Infof("gpu-burn pod now in Running phase")
 
-			By(fmt.Sprintf("Wait for up to %s for gpu-burn pod to run to completion and be in Succeeded phase/Completed status", nvidiagpu.BurnPodSuccessTimeout))
-			err = gpuPodPulled.WaitUntilInStatus(corev1.PodSucceeded, nvidiagpu.BurnPodSuccessTimeout)
+			By(fmt.Sprintf("Wait for up to %s for gpu-burn pod to run to completion and be in Succeeded phase/Completed status", timeouts.Get(timeouts.BurnPodSuccessTimeout)))
+			err = gpuPodPulled.WaitUntilInStatusWithContext(ctx, corev1.PodSucceeded, timeouts.Get(timeouts.BurnPodSuccessTimeout))
 
 			Expect(err).ToNot(HaveOccurred(), "timeout waiting for gpu-burn pod '%s' in "+
 				"namespace '%s'to go Succeeded phase/Completed status:  %v ", burn.Namespace, burn.Namespace, err)
@@ -807,7 +992,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			By("Get the gpu-burn pod logs")
 			glog.V(gpuparams.GpuLogLevel).Infof("Get the gpu-burn pod logs")
 
-			gpuBurnLogs, err := gpuPodPulled.GetLog(nvidiagpu.BurnLogCollectionPeriod, "gpu-burn-ctr")
+			gpuBurnLogs, err := gpuPodPulled.GetLog(timeouts.Get(timeouts.BurnLogCollectionPeriod), "gpu-burn-ctr")
 
 			Expect(err).ToNot(HaveOccurred(), "error getting gpu-burn pod '%s' logs "+
 				"from gpu burn namespace '%s' :  %v ", burn.Namespace, err)
@@ -823,7 +1008,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 		})
 
-		It("Upgrade NVIDIA GPU Operator", Label("operator-upgrade"), func() {
+		It("Upgrade NVIDIA GPU Operator", Label("operator-upgrade"), func(ctx SpecContext) {
 
 			if OperatorUpgradeToChannel == UndefinedValue {
 				glog.V(gpuparams.GpuLogLevel).Infof("Operator Upgrade To Channel not set, skipping " +
@@ -844,6 +1029,46 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(100).Infof(
 				"Pulled ClusterPolicy builder structure named '%s'", pulledClusterPolicyBuilder.Object.Name)
 
+			if operatorRollback {
+				By("Record the pre-upgrade CSV and ClusterPolicy spec for the rollback testcase")
+				preUpgradeSubBuilder, err := olm.PullSubscription(inittools.APIClient, nvidiagpu.SubscriptionName,
+					nvidiagpu.SubscriptionNamespace)
+				Expect(err).ToNot(HaveOccurred(), "Error pulling subscription '%s' in "+
+					"namespace '%s': %v", nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace, err)
+
+				rollbackReport = &olm.RollbackReport{
+					Package:                nvidiagpu.Package,
+					CatalogSource:          preUpgradeSubBuilder.Object.Spec.CatalogSource,
+					CatalogSourceNamespace: preUpgradeSubBuilder.Object.Spec.CatalogSourceNamespace,
+					PreUpgradeChannel:      preUpgradeSubBuilder.Object.Spec.Channel,
+					PreUpgradeCSV:          preUpgradeSubBuilder.Object.Status.InstalledCSV,
+					UpgradeChannel:         OperatorUpgradeToChannel,
+				}
+				preUpgradeClusterPolicySpec = pulledClusterPolicyBuilder.Definition.Spec.DeepCopy()
+
+				glog.V(gpuparams.GpuLogLevel).Infof("Recorded pre-upgrade CSV '%s' of channel '%s'",
+					rollbackReport.PreUpgradeCSV, rollbackReport.PreUpgradeChannel)
+
+				DeferCleanup(func() {
+					glog.V(gpuparams.GpuLogLevel).Infof("%s", rollbackReport)
+
+					if err := inittools.GeneralConfig.WriteReport(nvidiagpu.RollbackReportFile,
+						[]byte(rollbackReport.String())); err != nil {
+						glog.Error("Error writing the GPU operator rollback report file: ", err)
+					}
+				})
+			}
+
+			// upgradeStep records the outcome of an upgrade step in the rollback report, when the rollback
+			// testcase is to run after the upgrade.
+			upgradeStep := func(step string, err error) error {
+				if rollbackReport == nil {
+					return err
+				}
+
+				return rollbackReport.Record(olm.DirectionUpgrade, step, err)
+			}
+
 			By("Capturing current clusterPolicy ResourceVersion")
 			initialClusterPolicyResourceVersion := pulledClusterPolicyBuilder.Object.ResourceVersion
 			glog.V(100).Infof(
@@ -873,7 +1098,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			pulledClusterPolicyBuilder.Definition.Spec.Daemonsets.RollingUpdate.MaxUnavailable = maxUnavailable
 			updatedPulledClusterPolicyBuilder, err := pulledClusterPolicyBuilder.Update(true)
 
-			Expect(err).ToNot(HaveOccurred(), "error updating pulled ClusterPolicy builder"+
+			Expect(upgradeStep("update ClusterPolicy", err)).ToNot(HaveOccurred(), "error updating pulled ClusterPolicy builder"+
 				" daemonset rollingUpdate.MaxUnavailable and Driver.UpgradePolicy fields:  %v", err)
 
 			By("Capturing updated clusterPolicy ResourceVersion")
@@ -901,37 +1126,121 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 			glog.V(100).Infof("Current Subscription Channel : %s", pulledSubBuilder.Definition.Spec.Channel)
 
-			pulledSubBuilder.Definition.Spec.Channel = OperatorUpgradeToChannel
-			glog.V(100).Infof("Updating Subscription Channel to upgrade to : %s",
-				pulledSubBuilder.Definition.Spec.Channel)
-
-			glog.V(100).Infof(
-				"Before Subcsription Channel upgrade the StartingCSV is now '%s'",
-				pulledSubBuilder.Object.Spec.StartingCSV)
+			previousInstalledCSV := pulledSubBuilder.Object.Status.InstalledCSV
+			glog.V(100).Infof("Current Subscription installed CSV : %s", previousInstalledCSV)
 
-			By("Update the Subscription builder object with new channel value")
-			updatedPulledSubBuilder, err := pulledSubBuilder.Update()
+			By("Discover the upgrade path to the upgrade channel from the PackageManifest channels")
+			upgradeHops := []olm.UpgradeHop{{Channel: OperatorUpgradeToChannel}}
 
-			Expect(err).ToNot(HaveOccurred(), "Error updating pulled subscription '%s' in "+
-				"namespace '%s': %v", nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace, err)
+			upgradePackageManifest, err := olm.PullPackageManifestByCatalog(inittools.APIClient,
+				pulledSubBuilder.Object.Spec.Package, pulledSubBuilder.Object.Spec.CatalogSourceNamespace,
+				pulledSubBuilder.Object.Spec.CatalogSource)
+			if err != nil {
+				glog.V(gpuparams.GpuLogLevel).Infof("Cannot discover the upgrade path, upgrading directly to "+
+					"channel '%s': %v", OperatorUpgradeToChannel, err)
+			} else {
+				upgradeGraph, err := upgradePackageManifest.UpgradeGraph()
+				Expect(err).ToNot(HaveOccurred(), "error reading the upgrade graph of package '%s': %v",
+					pulledSubBuilder.Object.Spec.Package, err)
 
-			glog.V(100).Infof("Successfully updated Subscription Channel to upgrade to '%s'",
-				updatedPulledSubBuilder.Definition.Spec.Channel)
+				upgradeHops, err = upgradeGraph.UpgradePath(previousInstalledCSV, OperatorUpgradeToChannel)
+				Expect(err).ToNot(HaveOccurred(), "error computing the upgrade path from '%s' to channel '%s': %v",
+					previousInstalledCSV, OperatorUpgradeToChannel, err)
+			}
 
-			glog.V(100).Infof("Sleeping for %s to allow new CSV to be deployed", nvidiagpu.CsvDeploymentSleepInterval)
-			time.Sleep(nvidiagpu.CsvDeploymentSleepInterval)
+			glog.V(gpuparams.GpuLogLevel).Infof("Upgrading GPU Operator from '%s' through %v", previousInstalledCSV,
+				upgradeHops)
+
+			for _, upgradeHop := range upgradeHops {
+				By(fmt.Sprintf("Upgrade hop to channel '%s'", upgradeHop.Channel))
+				pulledSubBuilder, err = olm.PullSubscription(inittools.APIClient, nvidiagpu.SubscriptionName,
+					nvidiagpu.SubscriptionNamespace)
+				Expect(err).ToNot(HaveOccurred(), "Error pulling subscription '%s' in "+
+					"namespace '%s': %v", nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace, err)
+
+				pulledSubBuilder.Definition.Spec.Channel = upgradeHop.Channel
+				glog.V(100).Infof("Updating Subscription Channel to upgrade to : %s",
+					pulledSubBuilder.Definition.Spec.Channel)
+
+				glog.V(100).Infof(
+					"Before Subcsription Channel upgrade the StartingCSV is now '%s'",
+					pulledSubBuilder.Object.Spec.StartingCSV)
+
+				By("Update the Subscription builder object with new channel value")
+				updatedPulledSubBuilder, err := pulledSubBuilder.Update()
+
+				Expect(upgradeStep("update subscription to channel "+upgradeHop.Channel, err)).ToNot(HaveOccurred(),
+					"Error updating pulled subscription '%s' in namespace '%s': %v", nvidiagpu.SubscriptionName,
+					nvidiagpu.SubscriptionNamespace, err)
+
+				glog.V(100).Infof("Successfully updated Subscription Channel to upgrade to '%s'",
+					updatedPulledSubBuilder.Definition.Spec.Channel)
+
+				// OLM may go through several CSVs of the channel before installing its head.
+				for upgradeHop.CSV == "" || previousInstalledCSV != upgradeHop.CSV {
+					if updatedPulledSubBuilder.Object.Spec.InstallPlanApproval == v1alpha1.ApprovalManual {
+						By(fmt.Sprintf("Approve the pending upgrade InstallPlan and wait for up to %s for it "+
+							"to complete", timeouts.Get(timeouts.InstallPlanCompleteTimeout)))
+						_, err = olm.ApproveInstallPlanForCSV(ctx, inittools.APIClient, nvidiagpu.SubscriptionName,
+							nvidiagpu.SubscriptionNamespace, "", timeouts.Get(timeouts.InstallPlanCompleteTimeout))
+						Expect(upgradeStep("approve upgrade InstallPlan", err)).ToNot(HaveOccurred(),
+							"error approving the upgrade InstallPlan of subscription '%s': %v", nvidiagpu.SubscriptionName,
+							err)
+					}
 
-			glog.V(100).Infof("After Subscription Channel upgrade, the StartingCSV is now '%s'",
-				updatedPulledSubBuilder.Object.Spec.StartingCSV)
+					By(fmt.Sprintf("Wait up to %s for the Subscription to install the new CSV",
+						timeouts.Get(timeouts.SubscriptionUpgradeTimeout)))
+					upgradedCSV, err := wait.SubscriptionCSVChangedWithContext(ctx, inittools.APIClient,
+						nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace, previousInstalledCSV,
+						timeouts.Get(timeouts.SubscriptionUpgradeCheckInterval),
+						timeouts.Get(timeouts.SubscriptionUpgradeTimeout))
+					Expect(upgradeStep("wait for new CSV", err)).ToNot(HaveOccurred(),
+						"error waiting for Subscription '%s' to install a CSV other than '%s': %v",
+						nvidiagpu.SubscriptionName, previousInstalledCSV, err)
+
+					glog.V(100).Infof("Subscription installed CSV '%s', waiting up to %s for it to succeed",
+						upgradedCSV, timeouts.Get(timeouts.CSVSucceededTimeout))
+					err = wait.CSVSucceededWithContext(ctx, inittools.APIClient, upgradedCSV,
+						nvidiagpu.SubscriptionNamespace, timeouts.Get(timeouts.CSVSucceededCheckInterval),
+						timeouts.Get(timeouts.CSVSucceededTimeout))
+					Expect(upgradeStep("wait for CSV "+upgradedCSV, err)).ToNot(HaveOccurred(),
+						"error waiting for CSV '%s' to succeed: %v", upgradedCSV, err)
+
+					upgradedCSVBuilder, err := olm.PullClusterServiceVersion(inittools.APIClient, upgradedCSV,
+						nvidiagpu.SubscriptionNamespace)
+					Expect(err).ToNot(HaveOccurred(), "error pulling upgraded CSV '%s': %v", upgradedCSV, err)
+
+					reporter.RecordOperatorVersion(nvidiagpu.Package, upgradedCSV,
+						upgradedCSVBuilder.Definition.Spec.Version.String())
+
+					previousInstalledCSV = upgradedCSV
+
+					if upgradeHop.CSV == "" {
+						break
+					}
+				}
 
-			By("Wait for daemonsets to be redeployed up to 15 minutes and for ClusterPolicy to be ready again")
-			glog.V(gpuparams.GpuLogLevel).Infof("Waiting up to 15 mins for ClusterPolicy to be ready again " +
-				"after upgrade")
-			err = wait.ClusterPolicyReady(inittools.APIClient, nvidiagpu.ClusterPolicyName, 60*time.Second, 15*time.Minute)
+				glog.V(100).Infof("After Subscription Channel upgrade, the StartingCSV is now '%s'",
+					updatedPulledSubBuilder.Object.Spec.StartingCSV)
+
+				By(fmt.Sprintf("Wait for daemonsets to be redeployed up to %s and for ClusterPolicy to be ready "+
+					"again", timeouts.Get(timeouts.ClusterPolicyUpgradeReadyTimeout)))
+				glog.V(gpuparams.GpuLogLevel).Infof("Waiting up to %s for ClusterPolicy to be ready again "+
+					"after upgrade to '%s'", timeouts.Get(timeouts.ClusterPolicyUpgradeReadyTimeout),
+					previousInstalledCSV)
+				err = wait.ClusterPolicyReadyWithContext(ctx, inittools.APIClient, nvidiagpu.ClusterPolicyName,
+					timeouts.Get(timeouts.ClusterPolicyReadyCheckInterval),
+					timeouts.Get(timeouts.ClusterPolicyUpgradeReadyTimeout))
+
+				glog.V(gpuparams.GpuLogLevel).Infof("error waiting for ClusterPolicy to be Ready:  %v ", err)
+				Expect(upgradeStep("wait for ClusterPolicy ready after upgrade to "+previousInstalledCSV, err)).
+					ToNot(HaveOccurred(), "error waiting for ClusterPolicy to be Ready after upgrade to '%s':  %v ",
+						previousInstalledCSV, err)
+			}
 
-			glog.V(gpuparams.GpuLogLevel).Infof("error waiting for ClusterPolicy to be Ready:  %v ", err)
-			Expect(err).ToNot(HaveOccurred(), "error waiting for ClusterPolicy to be Ready:  %v ",
-				err)
+			if rollbackReport != nil {
+				rollbackReport.UpgradedCSV = previousInstalledCSV
+			}
 
 			By("Pull the post-upgrade Ready ClusterPolicy from cluster, with updated fields")
 			pulledUpdatedReadyClusterPolicy, err := nvidiagpu.Pull(inittools.APIClient, nvidiagpu.ClusterPolicyName)
@@ -958,7 +1267,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(gpuparams.GpuLogLevel).Infof("The ready ClusterPolicy after upgrade has name:  %v",
 				pulledUpdatedReadyClusterPolicy.Definition.Name)
 			glog.V(gpuparams.GpuLogLevel).Infof("The ready ClusterPolicy just marshalled "+
-				"in json: %v", string(cpReadyAgainJSON))
+				"in json: %v", string(redact.Bytes(cpReadyAgainJSON)))
 
 			By("Pull the previously deployed gpu-burn pod object from the cluster")
 			currentGpuBurnPodPulled, err := pod.Pull(inittools.APIClient, burn.Namespace, burn.Namespace)
@@ -992,7 +1301,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				clusterArch)
 
 			gpuBurnPod2, err := gpuburn.CreateGPUBurnPod(inittools.APIClient, burn.Namespace, burn.Namespace,
-				BurnImageName[(clusterArch)], nvidiagpu.BurnPodPostUpgradeCreationTimeout)
+				BurnImageName[(clusterArch)], timeouts.Get(timeouts.BurnPodPostUpgradeCreationTimeout))
 			Expect(err).ToNot(HaveOccurred(), "Error re-building gpu burn pod object after "+
 				"upgrade: %v", err)
 
@@ -1001,8 +1310,9 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 			_, err = inittools.APIClient.Pods(burn.Namespace).Create(context.TODO(), gpuBurnPod2,
 				metav1.CreateOptions{})
-			Expect(err).ToNot(HaveOccurred(), "Error re-deploying gpu-burn '%s' after operator"+
-				" upgrade in namespace '%s': %v", burn.Namespace, burn.Namespace, err)
+			Expect(upgradeStep("create gpu-burn pod", err)).ToNot(HaveOccurred(),
+				"Error re-deploying gpu-burn '%s' after operator upgrade in namespace '%s': %v", burn.Namespace,
+				burn.Namespace, err)
 
 			glog.V(gpuparams.GpuLogLevel).Infof("The re-deployed post upgrade gpuBurnPod has name: %s has "+
 				"status: %v ", gpuBurnPod2.Name, gpuBurnPod2.Status)
@@ -1025,22 +1335,24 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				}
 			}()
 
-			By(fmt.Sprintf("Wait for up to %s for re-deployed burn pod to be in Running phase", nvidiagpu.RedeployedBurnPodRunningTimeout))
-			err = gpuBurnPod2Pulled.WaitUntilInStatus(corev1.PodRunning, nvidiagpu.RedeployedBurnPodRunningTimeout)
+			By(fmt.Sprintf("Wait for up to %s for re-deployed burn pod to be in Running phase", timeouts.Get(timeouts.RedeployedBurnPodRunningTimeout)))
+			err = gpuBurnPod2Pulled.WaitUntilInStatusWithContext(ctx, corev1.PodRunning,
+				timeouts.Get(timeouts.RedeployedBurnPodRunningTimeout))
 			Expect(err).ToNot(HaveOccurred(), "timeout waiting for re-deployed gpu-burn pod in "+
 				"namespace '%s' to go to Running phase:  %v ", burn.Namespace, err)
 			glog.V(gpuparams.GpuLogLevel).Infof("gpu-burn pod now in Running phase")
 
-			By(fmt.Sprintf("Wait for up to %s for re-deployed burn pod to run to completion and be in Succeeded phase/Completed status", nvidiagpu.RedeployedBurnPodSuccessTimeout))
-			err = gpuBurnPod2Pulled.WaitUntilInStatus(corev1.PodSucceeded, nvidiagpu.RedeployedBurnPodSuccessTimeout)
-			Expect(err).ToNot(HaveOccurred(), "timeout waiting for gpu-burn pod '%s' in "+
-				"namespace '%s'to go Succeeded phase/Completed status:  %v ", burn.Namespace, burn.Namespace, err)
+			By(fmt.Sprintf("Wait for up to %s for re-deployed burn pod to run to completion and be in Succeeded phase/Completed status", timeouts.Get(timeouts.RedeployedBurnPodSuccessTimeout)))
+			err = gpuBurnPod2Pulled.WaitUntilInStatusWithContext(ctx, corev1.PodSucceeded,
+				timeouts.Get(timeouts.RedeployedBurnPodSuccessTimeout))
+			Expect(upgradeStep("run gpu-burn pod", err)).ToNot(HaveOccurred(), "timeout waiting for gpu-burn pod "+
+				"'%s' in namespace '%s'to go Succeeded phase/Completed status:  %v ", burn.Namespace, burn.Namespace, err)
 			glog.V(gpuparams.GpuLogLevel).Infof("gpu-burn pod now in Succeeded Phase/Completed status")
 
 			By("Get the gpu-burn pod logs")
 			glog.V(gpuparams.GpuLogLevel).Infof("Get the re-created gpu-burn pod logs")
 
-			gpuBurnPod2Logs, err := gpuBurnPod2Pulled.GetLog(nvidiagpu.RedeployedBurnLogCollectionPeriod, "gpu-burn-ctr")
+			gpuBurnPod2Logs, err := gpuBurnPod2Pulled.GetLog(timeouts.Get(timeouts.RedeployedBurnLogCollectionPeriod), "gpu-burn-ctr")
 
 			Expect(err).ToNot(HaveOccurred(), "error getting gpu-burn pod '%s' logs "+
 				"from gpu burn namespace '%s' :  %v ", burn.Namespace, err)
@@ -1051,10 +1363,200 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			match1a := strings.Contains(gpuBurnPod2Logs, "GPU 0: OK")
 			match2a := strings.Contains(gpuBurnPod2Logs, "100.0%  proc'd:")
 
-			Expect(match1a && match2a).ToNot(BeFalse(), "Re-deployed gpu-burn pod execution was FAILED")
+			var burnErr error
+			if !match1a || !match2a {
+				burnErr = errors.New("re-deployed gpu-burn pod execution was FAILED")
+			}
+
+			Expect(upgradeStep("check gpu-burn logs", burnErr)).ToNot(HaveOccurred())
 			glog.V(gpuparams.GpuLogLevel).Infof("Gpu-burn pod execution was successful")
 
 		})
 
+		It("Roll back NVIDIA GPU Operator upgrade", Label("operator-rollback"), func(ctx SpecContext) {
+
+			if !operatorRollback {
+				glog.V(gpuparams.GpuLogLevel).Infof("Operator Rollback not set, skipping " +
+					"Operator Rollback Testcase")
+				Skip("Operator Rollback not set, skipping Operator Rollback Testcase")
+			}
+
+			Expect(rollbackReport).ToNot(BeNil(), "the pre-upgrade CSV was not recorded by the upgrade testcase")
+			Expect(rollbackReport.UpgradedCSV).ToNot(BeEmpty(), "the upgrade testcase did not complete")
+
+			By("Starting GPU Operator Rollback testcase")
+			glog.V(gpuparams.GpuLogLevel).Infof("Rolling back GPU Operator from '%s' to '%s'",
+				rollbackReport.UpgradedCSV, rollbackReport.PreUpgradeCSV)
+
+			DeferCleanup(func() {
+				glog.V(gpuparams.GpuLogLevel).Infof("%s", rollbackReport)
+
+				if err := inittools.GeneralConfig.WriteReport(nvidiagpu.RollbackReportFile,
+					[]byte(rollbackReport.String())); err != nil {
+					glog.Error("Error writing the GPU operator rollback report file: ", err)
+				}
+			})
+
+			rollbackStep := func(step string, err error) error {
+				return rollbackReport.Record(olm.DirectionRollback, step, err)
+			}
+
+			By("Uninstall the upgraded GPU operator, keeping its namespace and CRDs")
+			uninstallReport, err := olm.NewOperatorUninstallBuilder(inittools.APIClient, nvidiagpu.Package,
+				nvidiagpu.SubscriptionName, nvidiagpu.NvidiaGPUNamespace).
+				WithTimeout(timeouts.Get(timeouts.DeletionPollInterval), timeouts.Get(timeouts.DeletionTimeout)).
+				UninstallWithContext(ctx)
+			if uninstallReport != nil {
+				glog.V(gpuparams.GpuLogLevel).Infof("%s", uninstallReport)
+
+				if err := inittools.GeneralConfig.WriteReport(nvidiagpu.RollbackUninstallReportFile,
+					[]byte(uninstallReport.String())); err != nil {
+					glog.Error("Error writing the GPU operator rollback uninstall report file: ", err)
+				}
+			}
+
+			Expect(rollbackStep("uninstall upgraded operator", err)).ToNot(HaveOccurred())
+
+			By("Re-create the OperatorGroup in NVIDIA GPU Operator Namespace")
+			ogBuilder := olm.NewOperatorGroupBuilder(inittools.APIClient, nvidiagpu.OperatorGroupName,
+				nvidiagpu.NvidiaGPUNamespace)
+			if !ogBuilder.Exists() {
+				_, err = ogBuilder.Create()
+			}
+
+			Expect(rollbackStep("create OperatorGroup", err)).ToNot(HaveOccurred())
+
+			By(fmt.Sprintf("Re-create the Subscription pinned to CSV '%s' with Manual approval",
+				rollbackReport.PreUpgradeCSV))
+			_, err = olm.NewSubscriptionBuilder(inittools.APIClient, nvidiagpu.SubscriptionName,
+				nvidiagpu.SubscriptionNamespace, rollbackReport.CatalogSource, rollbackReport.CatalogSourceNamespace,
+				nvidiagpu.Package).
+				WithChannel(rollbackReport.PreUpgradeChannel).
+				WithStartingCSV(rollbackReport.PreUpgradeCSV).
+				WithInstallPlanApproval(v1alpha1.ApprovalManual).
+				Create()
+			Expect(rollbackStep("create pinned subscription", err)).ToNot(HaveOccurred())
+
+			By(fmt.Sprintf("Wait for up to %s for the subscription to be resolved",
+				timeouts.Get(timeouts.SubscriptionResolutionTimeout)))
+			_, err = wait.SubscriptionResolvedWithContext(ctx, inittools.APIClient, nvidiagpu.SubscriptionName,
+				nvidiagpu.SubscriptionNamespace, timeouts.Get(timeouts.SubscriptionResolutionCheckInterval),
+				timeouts.Get(timeouts.SubscriptionResolutionTimeout))
+			Expect(rollbackStep("resolve subscription", err)).ToNot(HaveOccurred())
+
+			By(fmt.Sprintf("Approve the pending InstallPlan for CSV '%s' and wait for up to %s for it "+
+				"to complete", rollbackReport.PreUpgradeCSV, timeouts.Get(timeouts.InstallPlanCompleteTimeout)))
+			_, err = olm.ApproveInstallPlanForCSV(ctx, inittools.APIClient, nvidiagpu.SubscriptionName,
+				nvidiagpu.SubscriptionNamespace, rollbackReport.PreUpgradeCSV,
+				timeouts.Get(timeouts.InstallPlanCompleteTimeout))
+			Expect(rollbackStep("approve InstallPlan", err)).ToNot(HaveOccurred())
+
+			By(fmt.Sprintf("Wait for up to %s for CSV '%s' to succeed", timeouts.Get(timeouts.CSVSucceededTimeout),
+				rollbackReport.PreUpgradeCSV))
+			err = wait.CSVSucceededWithContext(ctx, inittools.APIClient, rollbackReport.PreUpgradeCSV,
+				nvidiagpu.NvidiaGPUNamespace, timeouts.Get(timeouts.CSVSucceededCheckInterval),
+				timeouts.Get(timeouts.CSVSucceededTimeout))
+			Expect(rollbackStep("wait for pre-upgrade CSV", err)).ToNot(HaveOccurred())
+
+			By("Check that the Subscription installed the pre-upgrade CSV")
+			rolledBackSubBuilder, err := olm.PullSubscription(inittools.APIClient, nvidiagpu.SubscriptionName,
+				nvidiagpu.SubscriptionNamespace)
+			if err == nil {
+				rollbackReport.RolledBackCSV = rolledBackSubBuilder.Object.Status.InstalledCSV
+				if rollbackReport.RolledBackCSV != rollbackReport.PreUpgradeCSV {
+					err = fmt.Errorf("subscription installed CSV '%s' instead of '%s'",
+						rollbackReport.RolledBackCSV, rollbackReport.PreUpgradeCSV)
+				}
+			}
+
+			Expect(rollbackStep("check installed CSV", err)).ToNot(HaveOccurred())
+
+			rolledBackCSVBuilder, err := olm.PullClusterServiceVersion(inittools.APIClient,
+				rollbackReport.RolledBackCSV, nvidiagpu.SubscriptionNamespace)
+			Expect(err).ToNot(HaveOccurred(), "error pulling rolled back CSV '%s': %v", rollbackReport.RolledBackCSV,
+				err)
+
+			reporter.RecordOperatorVersion(nvidiagpu.Package, rollbackReport.RolledBackCSV,
+				rolledBackCSVBuilder.Definition.Spec.Version.String())
+
+			By(fmt.Sprintf("Wait for up to %s for GPU Operator deployment to be ready",
+				timeouts.Get(timeouts.OperatorDeploymentReadyTimeout)))
+			gpuOperatorDeployment, err := deployment.Pull(inittools.APIClient, nvidiagpu.OperatorDeployment,
+				nvidiagpu.NvidiaGPUNamespace)
+			if err == nil && !gpuOperatorDeployment.IsReadyWithContext(ctx,
+				timeouts.Get(timeouts.OperatorDeploymentReadyTimeout)) {
+				err = fmt.Errorf("deployment '%s' is not ready", nvidiagpu.OperatorDeployment)
+			}
+
+			Expect(rollbackStep("wait for operator deployment", err)).ToNot(HaveOccurred())
+
+			By("Re-create the ClusterPolicy from the pre-upgrade spec")
+			_, err = nvidiagpu.NewBuilderFromSpec(inittools.APIClient, nvidiagpu.ClusterPolicyName,
+				preUpgradeClusterPolicySpec).Create()
+			Expect(rollbackStep("restore ClusterPolicy", err)).ToNot(HaveOccurred())
+
+			By(fmt.Sprintf("Wait up to %s for ClusterPolicy to be ready after the rollback",
+				timeouts.Get(timeouts.ClusterPolicyUpgradeReadyTimeout)))
+			err = wait.ClusterPolicyReadyWithContext(ctx, inittools.APIClient, nvidiagpu.ClusterPolicyName,
+				timeouts.Get(timeouts.ClusterPolicyReadyCheckInterval),
+				timeouts.Get(timeouts.ClusterPolicyUpgradeReadyTimeout))
+			Expect(rollbackStep("wait for ClusterPolicy ready", err)).ToNot(HaveOccurred())
+
+			By("Delete the previously deployed gpu-burn pod, if any")
+			if previousGpuBurnPod, err := pod.Pull(inittools.APIClient, burn.Namespace, burn.Namespace); err == nil {
+				_, err = previousGpuBurnPod.DeleteAndWait(timeouts.Get(timeouts.DeletionTimeout))
+				Expect(err).ToNot(HaveOccurred(), "Error deleting previously deployed gpu-burn pod: %v", err)
+			}
+
+			By("Re-deploy gpu-burn pod in test-gpu-burn namespace")
+			clusterArch, err := get.GetClusterArchitecture(inittools.APIClient, WorkerNodeSelector)
+			Expect(err).ToNot(HaveOccurred(), "error getting cluster architecture:  %v ", err)
+
+			gpuBurnPod, err := gpuburn.CreateGPUBurnPod(inittools.APIClient, burn.Namespace, burn.Namespace,
+				BurnImageName[clusterArch], timeouts.Get(timeouts.BurnPodPostUpgradeCreationTimeout))
+			Expect(err).ToNot(HaveOccurred(), "Error re-building gpu burn pod object after rollback: %v", err)
+
+			_, err = inittools.APIClient.Pods(burn.Namespace).Create(context.TODO(), gpuBurnPod,
+				metav1.CreateOptions{})
+			Expect(rollbackStep("create gpu-burn pod", err)).ToNot(HaveOccurred())
+
+			gpuBurnPodPulled, err := pod.Pull(inittools.APIClient, gpuBurnPod.Name, burn.Namespace)
+			Expect(err).ToNot(HaveOccurred(), "error pulling re-deployed gpu-burn pod from "+
+				"namespace '%s' :  %v ", burn.Namespace, err)
+
+			defer func() {
+				if cleanupAfterTest {
+					_, err := gpuBurnPodPulled.Delete()
+					Expect(err).ToNot(HaveOccurred())
+				}
+			}()
+
+			By(fmt.Sprintf("Wait for up to %s for the gpu-burn pod to run to completion",
+				timeouts.Get(timeouts.RedeployedBurnPodSuccessTimeout)))
+			err = gpuBurnPodPulled.WaitUntilInStatusWithContext(ctx, corev1.PodRunning,
+				timeouts.Get(timeouts.RedeployedBurnPodRunningTimeout))
+			if err == nil {
+				err = gpuBurnPodPulled.WaitUntilInStatusWithContext(ctx, corev1.PodSucceeded,
+					timeouts.Get(timeouts.RedeployedBurnPodSuccessTimeout))
+			}
+
+			Expect(rollbackStep("run gpu-burn pod", err)).ToNot(HaveOccurred())
+
+			By("Parse the gpu-burn pod logs and check for successful execution")
+			gpuBurnLogs, err := gpuBurnPodPulled.GetLog(timeouts.Get(timeouts.RedeployedBurnLogCollectionPeriod),
+				"gpu-burn-ctr")
+			if err == nil && !(strings.Contains(gpuBurnLogs, "GPU 0: OK") &&
+				strings.Contains(gpuBurnLogs, "100.0%  proc'd:")) {
+				err = fmt.Errorf("gpu-burn pod execution was FAILED")
+			}
+
+			glog.V(gpuparams.GpuLogLevel).Infof("Gpu-burn pod '%s' logs:\n%s", gpuBurnPodPulled.Definition.Name,
+				gpuBurnLogs)
+			Expect(rollbackStep("check gpu-burn logs", err)).ToNot(HaveOccurred())
+
+			glog.V(gpuparams.GpuLogLevel).Infof("GPU Operator rolled back to '%s' and gpu-burn pod execution "+
+				"was successful", rollbackReport.RolledBackCSV)
+		})
+
 	})
 })
@@ -5,12 +5,13 @@ import (
 	"github.com/golang/glog"
 	"runtime"
 	"testing"
-	"time"
 
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
 
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/nvidiagpuconfig"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/tsparams"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -21,12 +22,26 @@ var _, currentFile, _, _ = runtime.Caller(0)
 
 func TestGPUDeploy(t *testing.T) {
 	_, reporterConfig := GinkgoConfiguration()
-	reporterConfig.JUnitReport = inittools.GeneralConfig.GetJunitReportPath(currentFile)
 
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "GPU", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = BeforeSuite(func() {
+	if nvidiaGPUConfig == nil {
+		return
+	}
+
+	if err := inittools.GeneralConfig.WriteEffectiveConfig(
+		nvidiagpuconfig.EffectiveConfigReportFile, nvidiaGPUConfig); err != nil {
+		glog.Error("Error writing the effective NVIDIAGPU configuration file: ", err)
+	}
+})
+
+var _ = BeforeEach(func() {
+	reporter.StartEventTimeline(tsparams.ReporterNamespacesToDump)
+})
+
 var _ = JustAfterEach(func() {
 	specReport := CurrentSpecReport()
 	reporter.ReportIfFailed(
@@ -35,8 +50,27 @@ var _ = JustAfterEach(func() {
 	dumpDir := inittools.GeneralConfig.GetDumpFailedTestReportLocation(currentFile)
 	if dumpDir != "" {
 		artifactDir := fmt.Sprintf("%s/gpu-must-gather", dumpDir)
-		if err := reporter.MustGatherIfFailed(specReport, artifactDir, 5*time.Minute); err != nil {
+		if err := reporter.MustGatherIfFailed(specReport, artifactDir,
+			timeouts.Get(timeouts.MustGatherTimeout)); err != nil {
 			glog.Errorf("Error running MustGatherIfFailed, %v", err)
 		}
 	}
 })
+
+var _ = ReportAfterSuite("API retries", func(report Report) {
+	if err := inittools.ReportAPIRetries(); err != nil {
+		glog.Errorf("Error writing API retries report, %v", err)
+	}
+})
+
+var _ = ReportAfterSuite("Run report", func(report Report) {
+	if err := reporter.WriteRunReport(report, currentFile, tsparams.GPUInventory); err != nil {
+		glog.Errorf("Error writing run report, %v", err)
+	}
+})
+
+var _ = ReportAfterSuite("JUnit report", func(report Report) {
+	if err := reporter.WriteJUnitReport(report, currentFile); err != nil {
+		glog.Errorf("Error writing JUnit report, %v", err)
+	}
+})
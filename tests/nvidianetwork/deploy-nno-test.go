@@ -1,16 +1,18 @@
 package nvidianetwork
 
 import (
-	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/nvidianetworkconfig"
 	rdmatest "github.com/rh-ecosystem-edge/nvidia-ci/internal/rdma"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter/redact"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nfdcheck"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"time"
 
 	"github.com/golang/glog"
 	. "github.com/onsi/ginkgo/v2"
@@ -30,6 +32,7 @@ import (
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/wait"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidianetwork"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm/ocv1"
 )
 
 var (
@@ -48,6 +51,11 @@ var (
 
 	DefaultSubscriptionChannel           = UndefinedValue
 	networkOperatorUpgradeToChannel      = UndefinedValue
+	StartingCSV                          = UndefinedValue
+	useOLMv1                        bool = false
+	clusterExtensionAdmin           bool = false
+	ClusterCatalog                       = UndefinedValue
+	VersionRange                         = UndefinedValue
 	cleanupAfterTest                bool = true
 	deployFromBundle                bool = false
 	networkOperatorBundleImage           = ""
@@ -58,6 +66,11 @@ var (
 	createNNOCustomCatalogsource  bool = false
 	CustomCatalogsourceIndexImage      = UndefinedValue
 
+	// rdmaSpecLabels are the labels of the RDMA spec, including the ones inherited from the suite and its
+	// containers, so a label filter can be matched against it before the spec runs.
+	rdmaSpecLabels = append(append([]string{}, tsparams.NetworkLabels...),
+		tsparams.LabelSuite, "deploy-nno-with-dtk", "rdma")
+
 	rdmaClientHostname = UndefinedValue
 	rdmaServerHostname = UndefinedValue
 	rdmaTestImage      = UndefinedValue
@@ -87,6 +100,12 @@ const (
 	nnoPackage                   = "nvidia-network-operator"
 	nnoNicClusterPolicyName      = "nic-cluster-policy"
 	nnoMacvlanNetworkNameDefault = "rdmashared-net"
+	nnoNodeLabelPrefix           = "network.nvidia.com/"
+	nnoUninstallReportFile       = "nno-uninstall.report"
+
+	nnoClusterCatalogDefault          = "openshift-certified-operators"
+	nnoClusterExtensionName           = "nvidia-network-operator"
+	nnoClusterExtensionServiceAccount = "nno-installer"
 
 	nnoCustomCatalogSourcePublisherName = "Red Hat"
 	nnoCustomCatalogSourceDisplayName   = "Certified Operators Custom"
@@ -101,6 +120,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 	var (
 		deployBundle       deploy.Deploy
 		deployBundleConfig deploy.BundleConfig
+		installedBundle    *ocv1.BundleMetadata
 	)
 
 	if mellanoxEthernetInterfaceName == "" {
@@ -116,6 +136,15 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 	Context("DeployNNO", Label("deploy-nno-with-dtk"), func() {
 
 		BeforeAll(func() {
+			By("Validate NVIDIANETWORK configuration")
+			Expect(nvidiaNetworkConfig).ToNot(BeNil(), "failed to load NVIDIANETWORK configuration")
+
+			// An empty label filter runs every spec, RDMA included.
+			rdmaSelected := GinkgoLabelFilter() == "" ||
+				Label(rdmaSpecLabels...).MatchesLabelFilter(GinkgoLabelFilter())
+			Expect(nvidiaNetworkConfig.Validate(rdmaSelected)).ToNot(HaveOccurred(),
+				"invalid NVIDIANETWORK configuration")
+			reporter.RecordConfig("nvidianetwork", nvidiaNetworkConfig)
 
 			if nvidiaNetworkConfig.CatalogSource == "" {
 				glog.V(networkparams.LogLevel).Infof("env variable NVIDIANETWORK_CATALOGSOURCE"+
@@ -284,6 +313,46 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 					" variable NVIDIANETWORK_SUBSCRIPTION_UPGRADE_TO_CHANNEL value '%s'", networkOperatorUpgradeToChannel)
 			}
 
+			if nvidiaNetworkConfig.InstallPlanApproval != "" {
+				InstallPlanApproval = v1alpha1.Approval(nvidiaNetworkConfig.InstallPlanApproval)
+				glog.V(networkparams.LogLevel).Infof("InstallPlan approval now set to env variable "+
+					"NVIDIANETWORK_INSTALL_PLAN_APPROVAL value '%s'", InstallPlanApproval)
+			}
+
+			if nvidiaNetworkConfig.StartingCSV != "" {
+				StartingCSV = nvidiaNetworkConfig.StartingCSV
+				glog.V(networkparams.LogLevel).Infof("NNO subscription starting CSV now set to env variable "+
+					"NVIDIANETWORK_STARTING_CSV value '%s'", StartingCSV)
+			}
+
+			if nvidiaNetworkConfig.UsesOLMv1() {
+				useOLMv1 = true
+				glog.V(networkparams.LogLevel).Infof("env variable NVIDIANETWORK_INSTALL_MODE is set to '%s', "+
+					"will deploy NNO with an OLM v1 ClusterExtension", nvidiaNetworkConfig.InstallMode)
+
+				if nvidiaNetworkConfig.ClusterCatalog == "" {
+					ClusterCatalog = nnoClusterCatalogDefault
+					glog.V(networkparams.LogLevel).Infof("env variable NVIDIANETWORK_CLUSTERCATALOG is not set, "+
+						"using default NNO ClusterCatalog '%s'", ClusterCatalog)
+				} else {
+					ClusterCatalog = nvidiaNetworkConfig.ClusterCatalog
+					glog.V(networkparams.LogLevel).Infof("NNO ClusterCatalog now set to env variable "+
+						"NVIDIANETWORK_CLUSTERCATALOG value '%s'", ClusterCatalog)
+				}
+
+				if nvidiaNetworkConfig.VersionRange != "" {
+					VersionRange = nvidiaNetworkConfig.VersionRange
+					glog.V(networkparams.LogLevel).Infof("NNO ClusterExtension version range now set to env "+
+						"variable NVIDIANETWORK_VERSION_RANGE value '%s'", VersionRange)
+				}
+
+				if nvidiaNetworkConfig.ClusterExtensionClusterAdmin {
+					clusterExtensionAdmin = true
+					glog.V(networkparams.LogLevel).Infof("env variable NVIDIANETWORK_CLUSTEREXTENSION_CLUSTER_ADMIN " +
+						"is set, the NNO ClusterExtension installer is bound to cluster-admin")
+				}
+			}
+
 			if nvidiaNetworkConfig.NNOFallbackCatalogsourceIndexImage != "" {
 				glog.V(networkparams.LogLevel).Infof("env variable "+
 					"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_INDEX_IMAGE is set, and has value: '%s'",
@@ -341,6 +410,8 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				}
 			}
 
+			reporter.RecordOpenShiftVersion(ocpVersion)
+
 			nfd.EnsureNFDIsInstalled(inittools.APIClient, Nfd, ocpVersion, networkparams.LogLevel)
 
 		})
@@ -356,28 +427,26 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 		AfterAll(func() {
 
 			if Nfd.CleanupAfterInstall && cleanupAfterTest {
-				// Here need to check if NFD CR is deployed, otherwise Deleting a non-existing CR will throw an error
-				// skipping error check for now cause any failure before entire NFD stack
-				By("Delete NFD CR instance in NFD namespace")
-				_ = nfd.NFDCRDeleteAndWait(inittools.APIClient, nfd.CRName, nfd.OperatorNamespace, 30*time.Second,
-					5*time.Minute)
-
-				By("Delete NFD CSV")
-				_ = nfd.DeleteNFDCSV(inittools.APIClient)
-
-				By("Delete NFD Subscription in NFD namespace")
-				_ = nfd.DeleteNFDSubscription(inittools.APIClient)
-
-				By("Delete NFD OperatorGroup in NFD namespace")
-				_ = nfd.DeleteNFDOperatorGroup(inittools.APIClient)
+				By("Uninstall NFD operator and report its leftover objects")
+				nfdReport, err := nfd.UninstallNFD(inittools.APIClient,
+					timeouts.Get(timeouts.DeletionPollInterval), timeouts.Get(timeouts.DeletionTimeout))
+				if nfdReport != nil {
+					glog.V(networkparams.LogLevel).Infof("%s", nfdReport)
+
+					if err := inittools.GeneralConfig.WriteReport(nfd.UninstallReportFile,
+						[]byte(nfdReport.String())); err != nil {
+						glog.Error("Error writing the NFD uninstall report file: ", err)
+					}
+				}
 
-				By("Delete NFD Namespace in NFD namespace")
-				_ = nfd.DeleteNFDNamespace(inittools.APIClient)
+				if err != nil {
+					glog.V(networkparams.LogLevel).Infof("NFD uninstall failed: %v", err)
+				}
 			}
 
 		})
 
-		It("Deploy NVIDIA Network Operator with DTK", Label("nno"), func() {
+		It("Deploy NVIDIA Network Operator with DTK", Label("nno"), func(ctx SpecContext) {
 
 			nfdcheck.CheckNfdInstallation(inittools.APIClient, nfd.RhcosLabel, nfd.RhcosLabelValue, inittools.GeneralConfig.WorkerLabelMap, networkparams.LogLevel)
 
@@ -404,6 +473,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 			clusterArchitecture = clusterArch
 			glog.V(networkparams.LogLevel).Infof("cluster architecture for network enabled worker node "+
 				"is: %s", clusterArchitecture)
+			reporter.RecordClusterArchitecture(clusterArchitecture)
 
 			By("Check if Network Operator Deployment is from Bundle")
 			if deployFromBundle {
@@ -411,9 +481,21 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				// This returns the Deploy interface object initialized with the API client
 				deployBundle = deploy.NewDeploy(inittools.APIClient)
 				deployBundleConfig.BundleImage = networkOperatorBundleImage
+				deployBundleConfig.SubscriptionName = nnoSubscriptionName
+				deployBundleConfig.OperatorGroupName = nnoOperatorGroupName
 				glog.V(networkparams.LogLevel).Infof("Deploying Network operator from bundle image '%s'",
 					deployBundleConfig.BundleImage)
 
+			} else if useOLMv1 {
+				glog.V(networkparams.LogLevel).Infof("Deploying Network Operator from ClusterCatalog '%s'",
+					ClusterCatalog)
+
+				By(fmt.Sprintf("Wait for up to %s for NNO ClusterCatalog '%s' to be serving",
+					timeouts.Get(timeouts.ClusterCatalogServingTimeout), ClusterCatalog))
+				err = wait.ClusterCatalogServingWithContext(ctx, inittools.APIClient, ClusterCatalog,
+					timeouts.Get(timeouts.ClusterCatalogServingCheckInterval),
+					timeouts.Get(timeouts.ClusterCatalogServingTimeout))
+				Expect(err).ToNot(HaveOccurred(), "NNO ClusterCatalog '%s' is not serving: %v", ClusterCatalog, err)
 			} else {
 				glog.V(networkparams.LogLevel).Infof("Deploying Network Operator from catalogsource")
 
@@ -452,13 +534,14 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 						Expect(err).ToNot(HaveOccurred(), "error creating custom NNO catalogsource "+
 							"builder Object name %s:  %v", CustomCatalogSource, err)
 
-						By("Sleep for 60 seconds to allow the NNO custom catalogsource to be created")
-						time.Sleep(60 * time.Second)
-
-						glog.V(networkparams.LogLevel).Infof("Wait up to 4 mins for custom NNO catalogsource " +
-							"to be ready")
+						glog.V(networkparams.LogLevel).Infof("Wait up to %s for custom NNO catalogsource "+
+							"to be ready", timeouts.Get(timeouts.CatalogSourceReadyTimeout))
 
-						Expect(createdNNOCustomCatalogSourceBuilder.IsReady(4 * time.Minute)).NotTo(BeFalse())
+						_, err = createdNNOCustomCatalogSourceBuilder.WaitUntilReadyWithContext(ctx,
+							timeouts.Get(timeouts.CatalogSourceReadyTimeout), nnoPackage)
+						Expect(err).ToNot(HaveOccurred(), "custom NNO catalogsource '%s' with index image '%s' "+
+							"from NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_INDEX_IMAGE is not usable: %v",
+							CustomCatalogSource, CustomCatalogsourceIndexImage, err)
 
 						CatalogSource = createdNNOCustomCatalogSourceBuilder.Definition.Name
 
@@ -527,8 +610,35 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 
 			defer func() {
 				if cleanupAfterTest {
-					err := nsBuilder.Delete()
-					Expect(err).ToNot(HaveOccurred())
+					By("Uninstall NNO operator and report its leftover objects")
+					uninstallBuilder := olm.NewOperatorUninstallBuilder(inittools.APIClient, nnoPackage,
+						nnoSubscriptionName, nnoNamespace)
+					if useOLMv1 {
+						uninstallBuilder = olm.NewClusterExtensionUninstallBuilder(inittools.APIClient, nnoPackage,
+							nnoClusterExtensionName, nnoNamespace)
+					}
+
+					uninstallReport, err := uninstallBuilder.
+						WithNamespace().
+						WithNodeLabelPrefixes(nnoNodeLabelPrefix).
+						WithTimeout(timeouts.Get(timeouts.DeletionPollInterval), timeouts.Get(timeouts.DeletionTimeout)).
+						Uninstall()
+					if uninstallReport != nil {
+						glog.V(networkparams.LogLevel).Infof("%s", uninstallReport)
+
+						if err := inittools.GeneralConfig.WriteReport(nnoUninstallReportFile,
+							[]byte(uninstallReport.String())); err != nil {
+							glog.Error("Error writing the NNO uninstall report file: ", err)
+						}
+					}
+
+					if useOLMv1 {
+						// The installer is only deleted once its ClusterExtension is gone.
+						err = errors.Join(err, olm.DeleteClusterExtensionInstaller(inittools.APIClient,
+							nnoClusterExtensionServiceAccount, nnoNamespace))
+					}
+
+					Expect(err).ToNot(HaveOccurred(), "error uninstalling NNO operator: %v", err)
 				}
 			}()
 
@@ -538,17 +648,60 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				deployBundle = deploy.NewDeploy(inittools.APIClient)
 
 				deployBundleConfig.BundleImage = networkOperatorBundleImage
+				deployBundleConfig.SubscriptionName = nnoSubscriptionName
+				deployBundleConfig.OperatorGroupName = nnoOperatorGroupName
 
 				glog.V(networkparams.LogLevel).Infof("Deploy the Network Operator bundle image '%s'",
 					deployBundleConfig.BundleImage)
 
 				err = deployBundle.DeployBundle(networkparams.LogLevel, &deployBundleConfig, nnoNamespace,
-					5*time.Minute)
+					timeouts.Get(timeouts.BundleDeploymentTimeout))
 				Expect(err).ToNot(HaveOccurred(), "error from deploy.DeployBundle():  '%v' ", err)
 
 				glog.V(networkparams.LogLevel).Infof("Network Operator bundle image '%s' deployed successfully "+
 					"in namespace '%s", deployBundleConfig.BundleImage, nnoNamespace)
 
+			} else if useOLMv1 {
+				By("Create the ClusterExtension installer service account in NVIDIA Network Operator Namespace")
+				if clusterExtensionAdmin {
+					err = olm.CreateClusterExtensionInstallerWithClusterAdmin(inittools.APIClient,
+						nnoClusterExtensionServiceAccount, nnoNamespace)
+				} else {
+					err = olm.CreateClusterExtensionInstaller(inittools.APIClient, nnoClusterExtensionServiceAccount,
+						nnoNamespace, olm.ClusterExtensionInstallerRules(nnoClusterExtensionName))
+				}
+				Expect(err).ToNot(HaveOccurred(), "error creating NNO installer service account: %v", err)
+
+				By("Create ClusterExtension for the Network Operator")
+				clusterExtensionBuilder := olm.NewClusterExtensionBuilder(inittools.APIClient,
+					nnoClusterExtensionName, nnoPackage, nnoNamespace, nnoClusterExtensionServiceAccount).
+					WithCatalogs(ClusterCatalog).
+					WithWatchNamespace(nnoNamespace)
+
+				if SubscriptionChannel != UndefinedValue {
+					glog.V(networkparams.LogLevel).Infof("Setting the ClusterExtension channel to: '%s'",
+						SubscriptionChannel)
+					clusterExtensionBuilder.WithChannels(SubscriptionChannel)
+				}
+
+				if VersionRange != UndefinedValue {
+					glog.V(networkparams.LogLevel).Infof("Setting the ClusterExtension version range to: '%s'",
+						VersionRange)
+					clusterExtensionBuilder.WithVersion(VersionRange)
+				}
+
+				_, err = clusterExtensionBuilder.Create()
+				Expect(err).ToNot(HaveOccurred(), "error creating NNO ClusterExtension: %v", err)
+
+				By(fmt.Sprintf("Wait for up to %s for the NNO ClusterExtension to be installed",
+					timeouts.Get(timeouts.ClusterExtensionInstalledTimeout)))
+				installedBundle, err = wait.ClusterExtensionInstalledWithContext(ctx, inittools.APIClient,
+					nnoClusterExtensionName, timeouts.Get(timeouts.ClusterExtensionInstalledCheckInterval),
+					timeouts.Get(timeouts.ClusterExtensionInstalledTimeout))
+				Expect(err).ToNot(HaveOccurred(), "error installing NNO ClusterExtension: %v", err)
+
+				glog.V(networkparams.LogLevel).Infof("NNO ClusterExtension installed bundle '%s' version '%s'",
+					installedBundle.Name, installedBundle.Version)
 			} else {
 				By("Create OperatorGroup in NVIDIA Network Operator Namespace")
 				ogBuilder := olm.NewOperatorGroupBuilder(inittools.APIClient, nnoOperatorGroupName, nnoNamespace)
@@ -565,13 +718,6 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 						ogBuilderCreated.Definition.Name, err)
 				}
 
-				defer func() {
-					if cleanupAfterTest {
-						err := ogBuilder.Delete()
-						Expect(err).ToNot(HaveOccurred())
-					}
-				}()
-
 				By("Create Subscription in NVIDIA Network Operator Namespace")
 				subBuilder := olm.NewSubscriptionBuilder(inittools.APIClient, nnoSubscriptionName,
 					nnoSubscriptionNamespace, CatalogSource, nnoCatalogSourceNamespace, nnoPackage)
@@ -588,6 +734,12 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 
 				subBuilder.WithInstallPlanApproval(InstallPlanApproval)
 
+				if StartingCSV != UndefinedValue {
+					glog.V(networkparams.LogLevel).Infof("Setting the NNO subscription starting CSV to: '%s'",
+						StartingCSV)
+					subBuilder.WithStartingCSV(StartingCSV)
+				}
+
 				glog.V(networkparams.LogLevel).Infof("Creating the subscription, i.e Deploy the Network operator")
 				createdSub, err := subBuilder.Create()
 
@@ -603,23 +755,43 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 						createdSub.Object.Status.CurrentCSV)
 				}
 
-				defer func() {
-					if cleanupAfterTest {
-						err := createdSub.Delete()
-						Expect(err).ToNot(HaveOccurred())
+				By(fmt.Sprintf("Wait for up to %s for the NNO subscription to be resolved",
+					timeouts.Get(timeouts.SubscriptionResolutionTimeout)))
+				installPlanName, err := wait.SubscriptionResolvedWithContext(ctx, inittools.APIClient,
+					nnoSubscriptionName, nnoSubscriptionNamespace,
+					timeouts.Get(timeouts.SubscriptionResolutionCheckInterval),
+					timeouts.Get(timeouts.SubscriptionResolutionTimeout))
+				Expect(err).ToNot(HaveOccurred(), "error resolving NNO subscription '%s': %v",
+					nnoSubscriptionName, err)
+
+				if InstallPlanApproval == v1alpha1.ApprovalManual {
+					targetCSV := ""
+					if StartingCSV != UndefinedValue {
+						targetCSV = StartingCSV
 					}
-				}()
 
-			}
+					By(fmt.Sprintf("Approve the pending NNO InstallPlan for CSV '%s' and wait for up to %s for "+
+						"it to complete", targetCSV, timeouts.Get(timeouts.InstallPlanCompleteTimeout)))
+					_, err = olm.ApproveInstallPlanForCSV(ctx, inittools.APIClient, nnoSubscriptionName,
+						nnoSubscriptionNamespace, targetCSV, timeouts.Get(timeouts.InstallPlanCompleteTimeout))
+					Expect(err).ToNot(HaveOccurred(), "error approving the InstallPlan of NNO subscription '%s': %v",
+						nnoSubscriptionName, err)
+				} else {
+					By(fmt.Sprintf("Wait for up to %s for NNO InstallPlan '%s' to complete",
+						timeouts.Get(timeouts.InstallPlanCompleteTimeout), installPlanName))
+					err = wait.InstallPlanCompleteWithContext(ctx, inittools.APIClient, installPlanName,
+						nnoSubscriptionNamespace, timeouts.Get(timeouts.InstallPlanCompleteCheckInterval),
+						timeouts.Get(timeouts.InstallPlanCompleteTimeout))
+					Expect(err).ToNot(HaveOccurred(), "error waiting for NNO InstallPlan '%s' to complete: %v",
+						installPlanName, err)
+				}
 
-			By("Sleep for 2 minutes to allow the Network Operator deployment to be created")
-			glog.V(networkparams.LogLevel).Infof("Sleep for 2 minutes to allow the Network Operator deployment" +
-				" to be created")
-			time.Sleep(2 * time.Minute)
+			}
 
-			By("Wait for up to 4 minutes for Network Operator deployment to be created")
-			nnoDeploymentCreated := wait.DeploymentCreated(inittools.APIClient, nnoDeployment, nnoNamespace,
-				30*time.Second, 4*time.Minute)
+			By(fmt.Sprintf("Wait for up to %s for Network Operator deployment to be created",
+				timeouts.Get(timeouts.DeploymentCreationTimeout)))
+			nnoDeploymentCreated := wait.DeploymentCreatedWithContext(ctx, inittools.APIClient, nnoDeployment, nnoNamespace,
+				timeouts.Get(timeouts.DeploymentCreationCheckInterval), timeouts.Get(timeouts.DeploymentCreationTimeout))
 			Expect(nnoDeploymentCreated).ToNot(BeFalse(), "timed out waiting to deploy "+
 				"Network operator")
 
@@ -632,24 +804,34 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(networkparams.LogLevel).Infof("Pulled Network operator deployment is:  %v ",
 				nnoOperatorDeployment.Definition.Name)
 
-			if nnoOperatorDeployment.IsReady(4 * time.Minute) {
+			if nnoOperatorDeployment.IsReadyWithContext(ctx, timeouts.Get(timeouts.OperatorDeploymentReadyTimeout)) {
 				glog.V(networkparams.LogLevel).Infof("Pulled Network operator deployment '%s' is Ready",
 					nnoOperatorDeployment.Definition.Name)
 			}
 
-			By("Get the CSV deployed in NVIDIA Network Operator namespace")
-			csvBuilderList, err := olm.ListClusterServiceVersion(inittools.APIClient, nnoNamespace)
+			var nnoCurrentCSV, nnoCurrentCSVVersion string
 
-			Expect(err).ToNot(HaveOccurred(), "Error getting list of CSVs in Network operator "+
-				"namespace: '%v'", err)
-			Expect(csvBuilderList).To(HaveLen(1), "Exactly one Network operator CSV is expected")
+			if useOLMv1 {
+				// OLM v1 creates no ClusterServiceVersion, the bundle name and version are reported instead.
+				nnoCurrentCSV = installedBundle.Name
+				nnoCurrentCSVVersion = installedBundle.Version
+				glog.V(networkparams.LogLevel).Infof("Installed bundle is: '%s'", nnoCurrentCSV)
+			} else {
+				By("Get the CSV deployed in NVIDIA Network Operator namespace")
+				csvBuilderList, err := olm.ListClusterServiceVersion(inittools.APIClient, nnoNamespace)
 
-			csvBuilder := csvBuilderList[0]
+				Expect(err).ToNot(HaveOccurred(), "Error getting list of CSVs in Network operator "+
+					"namespace: '%v'", err)
+				Expect(csvBuilderList).To(HaveLen(1), "Exactly one Network operator CSV is expected")
 
-			nnoCurrentCSV := csvBuilder.Definition.Name
-			glog.V(networkparams.LogLevel).Infof("Deployed ClusterServiceVersion is: '%s", nnoCurrentCSV)
+				csvBuilder := csvBuilderList[0]
+
+				nnoCurrentCSV = csvBuilder.Definition.Name
+				glog.V(networkparams.LogLevel).Infof("Deployed ClusterServiceVersion is: '%s", nnoCurrentCSV)
+
+				nnoCurrentCSVVersion = csvBuilder.Definition.Spec.Version.String()
+			}
 
-			nnoCurrentCSVVersion := csvBuilder.Definition.Spec.Version.String()
 			csvVersionString := nnoCurrentCSVVersion
 
 			glog.V(networkparams.LogLevel).Infof("ClusterServiceVersion version to be written in the operator "+
@@ -659,42 +841,54 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				glog.Error("Error writing an operator version file: ", err)
 			}
 
-			By("Wait for deployed ClusterServiceVersion to be in Succeeded phase")
-			glog.V(networkparams.LogLevel).Infof("Waiting for ClusterServiceVersion '%s' to be in Succeeded phase",
-				nnoCurrentCSV)
-			err = wait.CSVSucceeded(inittools.APIClient, nnoCurrentCSV, nnoNamespace, 60*time.Second,
-				5*time.Minute)
-			glog.V(networkparams.LogLevel).Info("error waiting for ClusterServiceVersion '%s' to be "+
-				"in Succeeded phase:  %v ", nnoCurrentCSV, err)
-			Expect(err).ToNot(HaveOccurred(), "error waiting for ClusterServiceVersion to be "+
-				"in Succeeded phase: ", err)
-
-			By("Pull existing CSV in NVIDIA Network Operator Namespace")
-			clusterCSV, err := olm.PullClusterServiceVersion(inittools.APIClient, nnoCurrentCSV, nnoNamespace)
-			Expect(err).ToNot(HaveOccurred(), "error pulling CSV from cluster:  %v", err)
+			reporter.RecordOperatorVersion(nnoPackage, nnoCurrentCSV, csvVersionString)
 
-			glog.V(networkparams.LogLevel).Infof("clusterCSV from cluster lastUpdatedTime is : %v ",
-				clusterCSV.Definition.Status.LastUpdateTime)
+			var almExamples string
 
-			glog.V(networkparams.LogLevel).Infof("clusterCSV from cluster Phase is : \"%v\"",
-				clusterCSV.Definition.Status.Phase)
+			if useOLMv1 {
+				By("Get ALM examples block from the bundle in the ClusterCatalog")
+				clusterCatalog, err := olm.PullClusterCatalog(inittools.APIClient, ClusterCatalog)
+				Expect(err).ToNot(HaveOccurred(), "error pulling ClusterCatalog '%s': %v", ClusterCatalog, err)
 
-			succeeded := v1alpha1.ClusterServiceVersionPhase("Succeeded")
-			Expect(clusterCSV.Definition.Status.Phase).To(Equal(succeeded), "CSV Phase is not "+
-				"succeeded")
+				catalogBundle, err := clusterCatalog.GetBundle(ctx, nnoPackage, nnoCurrentCSV)
+				Expect(err).ToNot(HaveOccurred(), "error getting bundle '%s' from ClusterCatalog '%s': %v",
+					nnoCurrentCSV, ClusterCatalog, err)
 
-			defer func() {
-				if cleanupAfterTest {
-					err := clusterCSV.Delete()
-					Expect(err).ToNot(HaveOccurred())
-				}
-			}()
-
-			By("Get ALM examples block form CSV")
-			almExamples, err := clusterCSV.GetAlmExamples()
-			Expect(err).ToNot(HaveOccurred(), "Error from pulling almExamples from csv "+
-				"from cluster:  %v ", err)
-			glog.V(networkparams.LogLevel).Infof("almExamples block from clusterCSV  is : %v ", almExamples)
+				almExamples, err = catalogBundle.GetAlmExamples()
+				Expect(err).ToNot(HaveOccurred(), "Error from pulling almExamples from bundle "+
+					"'%s':  %v ", nnoCurrentCSV, err)
+				glog.V(networkparams.LogLevel).Infof("almExamples block from bundle is : %v ", almExamples)
+			} else {
+				By("Wait for deployed ClusterServiceVersion to be in Succeeded phase")
+				glog.V(networkparams.LogLevel).Infof("Waiting for ClusterServiceVersion '%s' to be in Succeeded phase",
+					nnoCurrentCSV)
+				err = wait.CSVSucceededWithContext(ctx, inittools.APIClient, nnoCurrentCSV, nnoNamespace,
+					timeouts.Get(timeouts.CSVSucceededCheckInterval), timeouts.Get(timeouts.CSVSucceededTimeout))
+				glog.V(networkparams.LogLevel).Info("error waiting for ClusterServiceVersion '%s' to be "+
+					"in Succeeded phase:  %v ", nnoCurrentCSV, err)
+				Expect(err).ToNot(HaveOccurred(), "error waiting for ClusterServiceVersion to be "+
+					"in Succeeded phase: ", err)
+
+				By("Pull existing CSV in NVIDIA Network Operator Namespace")
+				clusterCSV, err := olm.PullClusterServiceVersion(inittools.APIClient, nnoCurrentCSV, nnoNamespace)
+				Expect(err).ToNot(HaveOccurred(), "error pulling CSV from cluster:  %v", err)
+
+				glog.V(networkparams.LogLevel).Infof("clusterCSV from cluster lastUpdatedTime is : %v ",
+					clusterCSV.Definition.Status.LastUpdateTime)
+
+				glog.V(networkparams.LogLevel).Infof("clusterCSV from cluster Phase is : \"%v\"",
+					clusterCSV.Definition.Status.Phase)
+
+				succeeded := v1alpha1.ClusterServiceVersionPhase("Succeeded")
+				Expect(clusterCSV.Definition.Status.Phase).To(Equal(succeeded), "CSV Phase is not "+
+					"succeeded")
+
+				By("Get ALM examples block form CSV")
+				almExamples, err = clusterCSV.GetAlmExamples()
+				Expect(err).ToNot(HaveOccurred(), "Error from pulling almExamples from csv "+
+					"from cluster:  %v ", err)
+				glog.V(networkparams.LogLevel).Infof("almExamples block from clusterCSV  is : %v ", almExamples)
+			}
 
 			By("Deploy NicClusterPolicy")
 			glog.V(networkparams.LogLevel).Infof("Creating NicClusterPolicy from CSV almExamples")
@@ -831,13 +1025,6 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(networkparams.LogLevel).Infof("NicClusterPolicy '%s' is successfully created",
 				createdNicClusterPolicyBuilder.Definition.Name)
 
-			defer func() {
-				if cleanupAfterTest {
-					_, err := createdNicClusterPolicyBuilder.Delete()
-					Expect(err).ToNot(HaveOccurred())
-				}
-			}()
-
 			By("Pull the NicClusterPolicy just created from cluster, with updated fields")
 			pulledNicClusterPolicy, err := nvidianetwork.PullNicClusterPolicy(inittools.APIClient,
 				nnoNicClusterPolicyName)
@@ -850,16 +1037,17 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				glog.V(networkparams.LogLevel).Infof("The NicClusterPolicy just created has name:  %v",
 					pulledNicClusterPolicy.Definition.Name)
 				glog.V(networkparams.LogLevel).Infof("The NicClusterPolicy just created marshalled "+
-					"in json: %v", string(cpJSON))
+					"in json: %v", string(redact.Bytes(cpJSON)))
 			} else {
 				glog.V(networkparams.LogLevel).Infof("Error Marshalling NicClusterPolicy into json:  %v",
 					err)
 			}
 
-			By("Wait up to 24 minutes for NicClusterPolicy to be ready")
+			By(fmt.Sprintf("Wait up to %s for NicClusterPolicy to be ready",
+				timeouts.Get(timeouts.NicClusterPolicyReadyTimeout)))
 			glog.V(networkparams.LogLevel).Infof("Waiting for NicClusterPolicy to be ready")
-			err = wait.NicClusterPolicyReady(inittools.APIClient, nnoNicClusterPolicyName, 60*time.Second,
-				24*time.Minute)
+			err = wait.NicClusterPolicyReadyWithContext(ctx, inittools.APIClient, nnoNicClusterPolicyName,
+				timeouts.Get(timeouts.NicClusterPolicyReadyCheckInterval), timeouts.Get(timeouts.NicClusterPolicyReadyTimeout))
 
 			glog.V(networkparams.LogLevel).Infof("error waiting for NicClusterPolicy to be Ready:  %v ", err)
 			Expect(err).ToNot(HaveOccurred(), "error waiting for NicClusterPolicy to be Ready: "+
@@ -877,7 +1065,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				glog.V(networkparams.LogLevel).Infof("The ready NicClusterPolicy just has name:  %v",
 					pulledReadyNicClusterPolicy.Definition.Name)
 				glog.V(networkparams.LogLevel).Infof("The ready NicClusterPolicy just marshalled "+
-					"in json: %v", string(ncpReadyJSON))
+					"in json: %v", string(redact.Bytes(ncpReadyJSON)))
 			} else {
 				glog.V(networkparams.LogLevel).Infof("Error Marshalling the ready NicClusterPolicy into "+
 					"json:  %v", err)
@@ -922,13 +1110,6 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(networkparams.LogLevel).Infof("MacvlanNetwork '%s' is successfully created",
 				createdMacvlanNetworkBuilder.Definition.Name)
 
-			defer func() {
-				if cleanupAfterTest {
-					_, err := createdMacvlanNetworkBuilder.Delete()
-					Expect(err).ToNot(HaveOccurred())
-				}
-			}()
-
 			By("Pull the MacvlanNetwork just created from cluster, with updated fields")
 			pulledMacvlanNetwork, err := nvidianetwork.PullMacvlanNetwork(inittools.APIClient, macvlanNetworkName)
 			Expect(err).ToNot(HaveOccurred(), "error pulling MacvlanNetwork %s from cluster: "+
@@ -940,16 +1121,17 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				glog.V(networkparams.LogLevel).Infof("The MacvlanNetwork just created has name:  %v",
 					pulledMacvlanNetwork.Definition.Name)
 				glog.V(networkparams.LogLevel).Infof("The MacvlanNetwork just created marshalled "+
-					"in json: %v", string(mvnJSON))
+					"in json: %v", string(redact.Bytes(mvnJSON)))
 			} else {
 				glog.V(networkparams.LogLevel).Infof("Error Marshalling MacvlanNetwork into json:  %v",
 					err)
 			}
 
-			By("Wait up to 5 minutes for MacvlanNetwork to be ready")
+			By(fmt.Sprintf("Wait up to %s for MacvlanNetwork to be ready",
+				timeouts.Get(timeouts.MacvlanNetworkReadyTimeout)))
 			glog.V(networkparams.LogLevel).Infof("Waiting for MacvlanNetwork to be ready")
-			err = wait.MacvlanNetworkReady(inittools.APIClient, macvlanNetworkName, 60*time.Second,
-				5*time.Minute)
+			err = wait.MacvlanNetworkReadyWithContext(ctx, inittools.APIClient, macvlanNetworkName,
+				timeouts.Get(timeouts.MacvlanNetworkReadyCheckInterval), timeouts.Get(timeouts.MacvlanNetworkReadyTimeout))
 
 			glog.V(networkparams.LogLevel).Infof("error waiting for MacvlanNetwork to be Ready:  %v ", err)
 			Expect(err).ToNot(HaveOccurred(), "error waiting for MacvlanNetwork to be Ready: "+
@@ -966,7 +1148,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				glog.V(networkparams.LogLevel).Infof("The ready MacvlanNetwork just has name:  %v",
 					pulledReadyMacvlanNetwork.Definition.Name)
 				glog.V(networkparams.LogLevel).Infof("The ready MacvlanNetwork just marshalled "+
-					"in json: %v", string(cpReadyJSON))
+					"in json: %v", string(redact.Bytes(cpReadyJSON)))
 			} else {
 				glog.V(networkparams.LogLevel).Infof("Error Marshalling the ready MacvlanNetwork into "+
 					"json:  %v", err)
@@ -974,7 +1156,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 
 		})
 
-		It("Run RDMA connectivity test with ib_write_bw", Label("rdma"), func() {
+		It("Run RDMA connectivity test with ib_write_bw", Label("rdma"), func(ctx SpecContext) {
 
 			var (
 				rdmaNamespace     = "default"
@@ -992,7 +1174,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				rdmaNamespace, "no", "server", rdmaServerHostname, "mlx5_1",
 				macvlanNetworkName, rdmaTestImage, "none")
 
-			createdRdmaServerPod, err := inittools.APIClient.Pods(rdmaServerPod.Namespace).Create(context.TODO(),
+			createdRdmaServerPod, err := inittools.APIClient.Pods(rdmaServerPod.Namespace).Create(ctx,
 				rdmaServerPod, metav1.CreateOptions{})
 
 			// DEBUG:
@@ -1004,9 +1186,14 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(networkparams.LogLevel).Infof("Successfully created RDMA ib_write_bw server workload pod '%s'",
 				createdRdmaServerPod.Name)
 
-			By("Wait 4 minutes for RDMA server pod to be running")
-			glog.V(networkparams.LogLevel).Infof("Waiting for 4 minutes for the RDMA server to be running")
-			time.Sleep(4 * time.Minute)
+			By(fmt.Sprintf("Wait up to %s for RDMA server pod to be running",
+				timeouts.Get(timeouts.RdmaServerRunningTimeout)))
+			glog.V(networkparams.LogLevel).Infof("Waiting up to %s for the RDMA server to be running",
+				timeouts.Get(timeouts.RdmaServerRunningTimeout))
+			err = wait.PodRunningWithContext(ctx, inittools.APIClient, rdmaServerPodName, rdmaNamespace,
+				timeouts.Get(timeouts.RdmaServerRunningCheckInterval), timeouts.Get(timeouts.RdmaServerRunningTimeout))
+			Expect(err).ToNot(HaveOccurred(), "error waiting for RDMA Server '%s' to be running: %v",
+				rdmaServerPodName, err)
 
 			By("Get the interface net1 IP address in the ib_write_bw server workload pod")
 			glog.V(networkparams.LogLevel).Infof("Get the interface net1 interface Ip address in the "+
@@ -1031,7 +1218,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				rdmaNamespace, "no", "client", rdmaClientHostname, "mlx5_1",
 				macvlanNetworkName, rdmaTestImage, net1IntIpAddrServer)
 
-			createdRdmaClientPod, err := inittools.APIClient.Pods(rdmaClientPod.Namespace).Create(context.TODO(),
+			createdRdmaClientPod, err := inittools.APIClient.Pods(rdmaClientPod.Namespace).Create(ctx,
 				rdmaClientPod, metav1.CreateOptions{})
 
 			// DEBUG:
@@ -1044,11 +1231,17 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				"namespace '%s' and passed server IP Address '%s'", createdRdmaClientPod.Name,
 				createdRdmaClientPod.Namespace, net1IntIpAddrServer)
 
-			// Later remove sleep time and detect when RDMA test has completed
-			By("Wait 7 minutes for RDMA ib_write_bw tests to complete")
-			glog.V(networkparams.LogLevel).Infof("Waiting for 7 minutes for the RDMA ib_write_bw tests to " +
-				"complete")
-			time.Sleep(7 * time.Minute)
+			By(fmt.Sprintf("Wait up to %s for RDMA ib_write_bw tests to complete",
+				timeouts.Get(timeouts.RdmaClientCompletedTimeout)))
+			glog.V(networkparams.LogLevel).Infof("Waiting up to %s for the RDMA client pod '%s' to complete",
+				timeouts.Get(timeouts.RdmaClientCompletedTimeout), rdmaClientPodName)
+			rdmaClientPhase, err := wait.PodCompletedWithContext(ctx, inittools.APIClient, rdmaClientPodName,
+				rdmaNamespace, timeouts.Get(timeouts.RdmaClientCompletedCheckInterval),
+				timeouts.Get(timeouts.RdmaClientCompletedTimeout))
+			Expect(err).ToNot(HaveOccurred(), "error waiting for RDMA Client '%s' to complete: %v",
+				rdmaClientPodName, err)
+			Expect(rdmaClientPhase).To(Equal(corev1.PodSucceeded), "RDMA Client '%s' completed in %s phase",
+				rdmaClientPodName, rdmaClientPhase)
 
 			By("Collect logs from RDMA ib_write_bw tests from server workload pod")
 			glog.V(networkparams.LogLevel).Infof("Collect logs from RDMA ib_write_bw tests from server " +
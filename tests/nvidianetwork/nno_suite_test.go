@@ -1,13 +1,17 @@
 package nvidianetwork
 
 import (
+	"fmt"
 	"runtime"
 	"testing"
 
+	"github.com/golang/glog"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/reporter"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
 
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/nvidianetworkconfig"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/timeouts"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/tsparams"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -18,14 +22,56 @@ var _, currentFile, _, _ = runtime.Caller(0)
 
 func TestNNODeploy(t *testing.T) {
 	_, reporterConfig := GinkgoConfiguration()
-	reporterConfig.JUnitReport = inittools.GeneralConfig.GetJunitReportPath(currentFile)
 
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "NNO", Label(tsparams.NetworkLabels...), reporterConfig)
 }
 
+var _ = BeforeSuite(func() {
+	if nvidiaNetworkConfig == nil {
+		return
+	}
+
+	if err := inittools.GeneralConfig.WriteEffectiveConfig(
+		nvidianetworkconfig.EffectiveConfigReportFile, nvidiaNetworkConfig); err != nil {
+		glog.Error("Error writing the effective NVIDIANETWORK configuration file: ", err)
+	}
+})
+
+var _ = BeforeEach(func() {
+	reporter.StartEventTimeline(tsparams.NetworkReporterNamespacesToDump)
+})
+
 var _ = JustAfterEach(func() {
+	specReport := CurrentSpecReport()
 	reporter.ReportIfFailed(
-		CurrentSpecReport(), currentFile, tsparams.NetworkReporterNamespacesToDump, tsparams.NetworkReporterCRDsToDump,
+		specReport, currentFile, tsparams.NetworkReporterNamespacesToDump, tsparams.NetworkReporterCRDsToDump,
 		clients.SetScheme)
+
+	dumpDir := inittools.GeneralConfig.GetDumpFailedTestReportLocation(currentFile)
+	if dumpDir != "" {
+		artifactDir := fmt.Sprintf("%s/network-must-gather", dumpDir)
+		if err := reporter.NetworkMustGatherIfFailed(specReport, artifactDir, tsparams.NetworkOperatorNamespace,
+			tsparams.NetworkInventory, timeouts.Get(timeouts.MustGatherTimeout)); err != nil {
+			glog.Errorf("Error running NetworkMustGatherIfFailed, %v", err)
+		}
+	}
+})
+
+var _ = ReportAfterSuite("API retries", func(report Report) {
+	if err := inittools.ReportAPIRetries(); err != nil {
+		glog.Errorf("Error writing API retries report, %v", err)
+	}
+})
+
+var _ = ReportAfterSuite("Run report", func(report Report) {
+	if err := reporter.WriteRunReport(report, currentFile, tsparams.NetworkInventory); err != nil {
+		glog.Errorf("Error writing run report, %v", err)
+	}
+})
+
+var _ = ReportAfterSuite("JUnit report", func(report Report) {
+	if err := reporter.WriteJUnitReport(report, currentFile); err != nil {
+		glog.Errorf("Error writing JUnit report, %v", err)
+	}
 })